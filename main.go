@@ -65,4 +65,8 @@ func main() {
 		}
 		goplugin.Serve(&serveConfig)
 	}
+
+	if err := akamai.ShutdownTracing(); err != nil {
+		hclog.Default().Warn(err.Error())
+	}
 }
@@ -0,0 +1,286 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/botman"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/cloudlets"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/cps"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/datastream"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgeworkers"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/iam"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/imaging"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/networklists"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// PollRetryAttempts is the number of attempts polling loops make for a status request failing with a transient error
+	PollRetryAttempts = 3
+	// PollRetryInterval is the delay before the first repeated status request of a polling loop
+	PollRetryInterval = 2 * time.Second
+)
+
+// ErrorCategory is the class of an API failure, which decides how the provider reacts to it
+type ErrorCategory int
+
+const (
+	// ErrorCategoryUnknown is the category of errors which are not API failures or cannot be classified
+	ErrorCategoryUnknown ErrorCategory = iota
+	// ErrorCategoryNotFound is the category of requests for objects which do not exist
+	ErrorCategoryNotFound
+	// ErrorCategoryConflict is the category of requests conflicting with the object state, or for locked objects
+	ErrorCategoryConflict
+	// ErrorCategoryRateLimited is the category of requests rejected because of the API rate limits
+	ErrorCategoryRateLimited
+	// ErrorCategoryValidation is the category of requests with invalid input
+	ErrorCategoryValidation
+	// ErrorCategoryAuth is the category of requests rejected because of the credentials or their permissions
+	ErrorCategoryAuth
+	// ErrorCategoryTransient is the category of server and network failures, which may succeed when retried
+	ErrorCategoryTransient
+)
+
+type (
+	// APIError is an API failure classified into an ErrorCategory
+	//
+	// errors.Is matches APIError with the sentinel error of its category, like ErrAPINotFound,
+	// and errors.As still returns the error of the edgegrid client package
+	APIError struct {
+		Category   ErrorCategory
+		StatusCode int
+		Err        error
+	}
+)
+
+var (
+	// ErrAPINotFound matches API errors of ErrorCategoryNotFound
+	ErrAPINotFound = &Error{"not found", true}
+	// ErrAPIConflict matches API errors of ErrorCategoryConflict
+	ErrAPIConflict = &Error{"conflict", false}
+	// ErrAPIRateLimited matches API errors of ErrorCategoryRateLimited
+	ErrAPIRateLimited = &Error{"rate limited", false}
+	// ErrAPIValidation matches API errors of ErrorCategoryValidation
+	ErrAPIValidation = &Error{"validation failed", false}
+	// ErrAPIAuth matches API errors of ErrorCategoryAuth
+	ErrAPIAuth = &Error{"not authorized", false}
+	// ErrAPITransient matches API errors of ErrorCategoryTransient
+	ErrAPITransient = &Error{"transient failure", false}
+
+	categorySentinels = map[ErrorCategory]*Error{
+		ErrorCategoryNotFound:    ErrAPINotFound,
+		ErrorCategoryConflict:    ErrAPIConflict,
+		ErrorCategoryRateLimited: ErrAPIRateLimited,
+		ErrorCategoryValidation:  ErrAPIValidation,
+		ErrorCategoryAuth:        ErrAPIAuth,
+		ErrorCategoryTransient:   ErrAPITransient,
+	}
+)
+
+// String returns the name of the category
+func (c ErrorCategory) String() string {
+	switch c {
+	case ErrorCategoryNotFound:
+		return "not found"
+	case ErrorCategoryConflict:
+		return "conflict"
+	case ErrorCategoryRateLimited:
+		return "rate limited"
+	case ErrorCategoryValidation:
+		return "validation"
+	case ErrorCategoryAuth:
+		return "auth"
+	case ErrorCategoryTransient:
+		return "transient"
+	}
+	return "unknown"
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the classified error
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches the sentinel error of the category
+func (e *APIError) Is(target error) bool {
+	sentinel, ok := categorySentinels[e.Category]
+	return ok && target == sentinel
+}
+
+// ClassifyError wraps err in an APIError of its category, so that it can be matched with errors.Is
+//
+// errors which cannot be classified are returned unchanged
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return err
+	}
+	category := ErrorCategoryOf(err)
+	if category == ErrorCategoryUnknown {
+		return err
+	}
+	status, _ := StatusCode(err)
+	return &APIError{Category: category, StatusCode: status, Err: err}
+}
+
+// ErrorCategoryOf returns the category of the error returned by any edgegrid client package
+func ErrorCategoryOf(err error) ErrorCategory {
+	if err == nil {
+		return ErrorCategoryUnknown
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Category
+	}
+	if status, ok := StatusCode(err); ok {
+		return categoryForStatus(status)
+	}
+	if isTransientNetworkError(err) {
+		return ErrorCategoryTransient
+	}
+	return ErrorCategoryUnknown
+}
+
+// StatusCode returns the HTTP status code of the error returned by any edgegrid client package
+func StatusCode(err error) (int, bool) {
+	var status int
+	var (
+		appsecErr       *appsec.Error
+		botmanErr       *botman.Error
+		cloudletsErr    *cloudlets.Error
+		cpsErr          *cps.Error
+		datastreamErr   *datastream.Error
+		dnsErr          *dns.Error
+		edgeworkersErr  *edgeworkers.Error
+		gtmErr          *gtm.Error
+		hapiErr         *hapi.Error
+		iamErr          *iam.Error
+		imagingErr      *imaging.Error
+		networklistsErr *networklists.Error
+		papiErr         *papi.Error
+		apiErr          *APIError
+	)
+	switch {
+	case errors.As(err, &apiErr) && apiErr.StatusCode != 0:
+		status = apiErr.StatusCode
+	case errors.As(err, &appsecErr):
+		status = appsecErr.StatusCode
+	case errors.As(err, &botmanErr):
+		status = botmanErr.StatusCode
+	case errors.As(err, &cloudletsErr):
+		status = cloudletsErr.StatusCode
+	case errors.As(err, &cpsErr):
+		status = cpsErr.StatusCode
+	case errors.As(err, &datastreamErr):
+		status = datastreamErr.StatusCode
+	case errors.As(err, &dnsErr):
+		status = dnsErr.StatusCode
+	case errors.As(err, &edgeworkersErr):
+		status = edgeworkersErr.Status
+	case errors.As(err, &gtmErr):
+		status = gtmErr.StatusCode
+	case errors.As(err, &hapiErr):
+		status = hapiErr.Status
+	case errors.As(err, &iamErr):
+		status = iamErr.StatusCode
+	case errors.As(err, &imagingErr):
+		status = imagingErr.Status
+	case errors.As(err, &networklistsErr):
+		status = networklistsErr.StatusCode
+	case errors.As(err, &papiErr):
+		status = papiErr.StatusCode
+	}
+	return status, status != 0
+}
+
+func categoryForStatus(status int) ErrorCategory {
+	switch {
+	case status == http.StatusNotFound || status == http.StatusGone:
+		return ErrorCategoryNotFound
+	case status == http.StatusConflict || status == http.StatusLocked || status == http.StatusPreconditionFailed:
+		return ErrorCategoryConflict
+	case status == http.StatusTooManyRequests:
+		return ErrorCategoryRateLimited
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return ErrorCategoryValidation
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrorCategoryAuth
+	case status == http.StatusRequestTimeout || status >= http.StatusInternalServerError && status != http.StatusNotImplemented:
+		return ErrorCategoryTransient
+	}
+	return ErrorCategoryUnknown
+}
+
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// the operation itself ran out of time, retrying cannot help
+		return false
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsAPINotFound returns true if the API reported that the requested object does not exist
+func IsAPINotFound(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryNotFound
+}
+
+// IsRetryable returns true if the request may succeed when it is repeated
+func IsRetryable(err error) bool {
+	category := ErrorCategoryOf(err)
+	return category == ErrorCategoryTransient || category == ErrorCategoryRateLimited
+}
+
+// RemoveIfNotFound removes the resource from the state when the API reported that it no longer exists
+// and returns true, so that Read functions handle resources deleted outside of Terraform the same way
+func RemoveIfNotFound(d *schema.ResourceData, err error, logger log.Interface) bool {
+	if !IsAPINotFound(err) {
+		return false
+	}
+	logger.Warnf("Resource %s was not found, removing it from the state: %s", d.Id(), err)
+	d.SetId("")
+	return true
+}
+
+// RetryTransient calls f until it succeeds, fails with an error which is not retryable,
+// maxAttempts is reached or the context is done
+//
+// the interval between attempts doubles after every attempt
+func RetryTransient(ctx context.Context, maxAttempts int, interval time.Duration, f func() error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = f(); err == nil || !IsRetryable(err) || attempt >= maxAttempts {
+			return ClassifyError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s", ctx.Err(), err)
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+}
@@ -0,0 +1,174 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgeworkers"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestErrorCategoryOf(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected ErrorCategory
+	}{
+		"papi not found wrapped": {
+			err:      fmt.Errorf("%s: %w", papi.ErrGetProperty, &papi.Error{StatusCode: http.StatusNotFound}),
+			expected: ErrorCategoryNotFound,
+		},
+		"dns conflict": {
+			err:      &dns.Error{StatusCode: http.StatusConflict},
+			expected: ErrorCategoryConflict,
+		},
+		"gtm locked": {
+			err:      &gtm.Error{StatusCode: http.StatusLocked},
+			expected: ErrorCategoryConflict,
+		},
+		"appsec rate limited": {
+			err:      &appsec.Error{StatusCode: http.StatusTooManyRequests},
+			expected: ErrorCategoryRateLimited,
+		},
+		"edgeworkers validation": {
+			err:      &edgeworkers.Error{Status: http.StatusBadRequest},
+			expected: ErrorCategoryValidation,
+		},
+		"hapi forbidden": {
+			err:      &hapi.Error{Status: http.StatusForbidden},
+			expected: ErrorCategoryAuth,
+		},
+		"papi server error": {
+			err:      &papi.Error{StatusCode: http.StatusBadGateway},
+			expected: ErrorCategoryTransient,
+		},
+		"not implemented is not transient": {
+			err:      &papi.Error{StatusCode: http.StatusNotImplemented},
+			expected: ErrorCategoryUnknown,
+		},
+		"unexpected EOF": {
+			err:      fmt.Errorf("reading response: %w", io.ErrUnexpectedEOF),
+			expected: ErrorCategoryTransient,
+		},
+		"context deadline": {
+			err:      context.DeadlineExceeded,
+			expected: ErrorCategoryUnknown,
+		},
+		"plain error": {
+			err:      errors.New("oops"),
+			expected: ErrorCategoryUnknown,
+		},
+		"nil": {
+			expected: ErrorCategoryUnknown,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ErrorCategoryOf(test.err))
+		})
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	papiErr := &papi.Error{StatusCode: http.StatusNotFound, Title: "Not Found"}
+	err := ClassifyError(fmt.Errorf("%s: %w", papi.ErrGetProperty, papiErr))
+
+	assert.True(t, errors.Is(err, ErrAPINotFound))
+	assert.False(t, errors.Is(err, ErrAPITransient))
+	var target *papi.Error
+	require.True(t, errors.As(err, &target))
+	assert.Equal(t, papiErr, target)
+	status, ok := StatusCode(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	plain := errors.New("oops")
+	assert.Equal(t, plain, ClassifyError(plain))
+	assert.NoError(t, ClassifyError(nil))
+}
+
+func TestRemoveIfNotFound(t *testing.T) {
+	tests := map[string]struct {
+		err        error
+		removed    bool
+		expectedID string
+	}{
+		"not found": {
+			err:     &gtm.Error{StatusCode: http.StatusNotFound},
+			removed: true,
+		},
+		"other error": {
+			err:        &gtm.Error{StatusCode: http.StatusInternalServerError},
+			expectedID: "test",
+		},
+		"no error": {
+			expectedID: "test",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := (&schema.Resource{Schema: map[string]*schema.Schema{}}).TestResourceData()
+			d.SetId("test")
+			assert.Equal(t, test.removed, RemoveIfNotFound(d, test.err, log.Log))
+			assert.Equal(t, test.expectedID, d.Id())
+		})
+	}
+}
+
+func TestRetryTransient(t *testing.T) {
+	tests := map[string]struct {
+		errs          []error
+		maxAttempts   int
+		expectedCalls int
+		withError     error
+	}{
+		"succeeds after transient errors": {
+			errs:          []error{&papi.Error{StatusCode: http.StatusServiceUnavailable}, &papi.Error{StatusCode: http.StatusTooManyRequests}, nil},
+			maxAttempts:   5,
+			expectedCalls: 3,
+		},
+		"validation errors are not retried": {
+			errs:          []error{&papi.Error{StatusCode: http.StatusBadRequest}},
+			maxAttempts:   5,
+			expectedCalls: 1,
+			withError:     ErrAPIValidation,
+		},
+		"attempts are exhausted": {
+			errs:          []error{&dns.Error{StatusCode: http.StatusBadGateway}, &dns.Error{StatusCode: http.StatusBadGateway}},
+			maxAttempts:   2,
+			expectedCalls: 2,
+			withError:     ErrAPITransient,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			err := RetryTransient(context.Background(), test.maxAttempts, time.Millisecond, func() error {
+				err := test.errs[calls]
+				calls++
+				return err
+			})
+			assert.Equal(t, test.expectedCalls, calls)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/go-homedir"
+)
+
+const (
+	// auditRedacted replaces the values of sensitive fields in the audit log
+	auditRedacted = "REDACTED"
+
+	// auditMaxBodySize is the maximum size of a request body recorded in the audit log
+	auditMaxBodySize = 16 * 1024
+)
+
+type (
+	// auditSink appends audit records to a JSON Lines file
+	auditSink struct {
+		path string
+		mu   sync.Mutex
+	}
+
+	// auditor records the mutating calls of a session
+	auditor struct {
+		sink        *auditSink
+		operationID string
+		profile     string
+	}
+
+	// auditedSession is a session which records every mutating call in the audit log
+	auditedSession struct {
+		session.Session
+		auditor *auditor
+		now     func() time.Time
+	}
+
+	// auditResource identifies the resource on whose behalf the API calls are made
+	auditResource struct {
+		resourceType string
+		d            *schema.ResourceData
+
+		mu sync.Mutex
+		// pending writes the records of calls made before the resource had an ID, once the ID is known
+		pending []func(id string)
+	}
+
+	auditResourceKey struct{}
+
+	// auditRecord is a single line of the audit log
+	auditRecord struct {
+		Time         time.Time    `json:"time"`
+		OperationID  string       `json:"operation_id"`
+		Profile      string       `json:"profile,omitempty"`
+		ResourceType string       `json:"resource_type,omitempty"`
+		ResourceID   string       `json:"resource_id,omitempty"`
+		Method       string       `json:"method"`
+		Endpoint     string       `json:"endpoint"`
+		StatusCode   int          `json:"status_code"`
+		LatencyMS    int64        `json:"latency_ms"`
+		Request      auditRequest `json:"request"`
+		Error        string       `json:"error,omitempty"`
+	}
+
+	// auditRequest is the redacted summary of the request
+	auditRequest struct {
+		Query         map[string][]string `json:"query,omitempty"`
+		BodySize      int                 `json:"body_size"`
+		Body          interface{}         `json:"body,omitempty"`
+		BodyTruncated bool                `json:"body_truncated,omitempty"`
+	}
+)
+
+var (
+	// sensitiveFieldPattern matches the names of fields and query parameters whose values are never recorded
+	sensitiveFieldPattern = regexp.MustCompile(`(?i)(secret|token|password|passwd|privatekey|private_key|credential|signature|authorization|accountswitchkey|^key$|^certificate$|^cert$|^pem$)`)
+)
+
+// newAuditSink creates the audit sink, making sure that the file can be written
+func newAuditSink(path string) (*auditSink, error) {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrAuditLog, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrAuditLog, err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrAuditLog, err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrAuditLog, err)
+	}
+	return &auditSink{path: path}, nil
+}
+
+// write appends the record to the audit log
+func (s *auditSink) write(record auditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// withProfile returns a copy of the auditor recording the profile name
+func (a *auditor) withProfile(name string) *auditor {
+	if a == nil {
+		return nil
+	}
+	return &auditor{sink: a.sink, operationID: a.operationID, profile: name}
+}
+
+// newAuditedSession wraps the session with the auditor, or returns the session unchanged if auditing is disabled
+func newAuditedSession(sess session.Session, a *auditor) session.Session {
+	if a == nil {
+		return sess
+	}
+	return &auditedSession{Session: sess, auditor: a, now: time.Now}
+}
+
+// Exec executes the request and records it in the audit log if it is a mutating call
+func (s *auditedSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	if !isMutatingMethod(r.Method) {
+		return s.Session.Exec(r, out, in...)
+	}
+
+	record := auditRecord{
+		Time:        s.now().UTC(),
+		OperationID: s.auditor.operationID,
+		Profile:     s.auditor.profile,
+		Method:      r.Method,
+		Endpoint:    r.URL.Path,
+		Request:     auditRequestSummary(r, in...),
+	}
+
+	resp, err := s.Session.Exec(r, out, in...)
+
+	record.LatencyMS = s.now().Sub(record.Time).Milliseconds()
+	if resp != nil {
+		record.StatusCode = resp.StatusCode
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if res, ok := r.Context().Value(auditResourceKey{}).(*auditResource); ok {
+		record.ResourceType = res.resourceType
+		if res.d != nil {
+			record.ResourceID = res.d.Id()
+		}
+		if res.d != nil && record.ResourceID == "" {
+			// the ID of a created resource is known only once the create function has set it
+			res.deferWrite(func(id string) {
+				record.ResourceID = id
+				s.write(r.Context(), record)
+			})
+			return resp, err
+		}
+	}
+
+	s.write(r.Context(), record)
+	return resp, err
+}
+
+func (s *auditedSession) write(ctx context.Context, record auditRecord) {
+	if err := s.auditor.sink.write(record); err != nil {
+		s.Log(ctx).Errorf("writing audit log: %s", err)
+	}
+}
+
+// deferWrite keeps the write of a record until the resource ID is known
+func (r *auditResource) deferWrite(write func(id string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending = append(r.pending, write)
+}
+
+// flush writes the pending records with the resource ID, which is empty if the resource was not created
+func (r *auditResource) flush() {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+
+	for _, write := range pending {
+		write(r.d.Id())
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// auditRequestSummary returns the request query and body with the values of sensitive fields redacted
+func auditRequestSummary(r *http.Request, in ...interface{}) auditRequest {
+	var summary auditRequest
+
+	if query := r.URL.Query(); len(query) > 0 {
+		summary.Query = make(map[string][]string, len(query))
+		for k, v := range query {
+			if sensitiveFieldPattern.MatchString(k) {
+				v = []string{auditRedacted}
+			}
+			summary.Query[k] = v
+		}
+	}
+
+	var body []byte
+	switch {
+	case len(in) > 0 && in[0] != nil:
+		body, _ = json.Marshal(in[0])
+	case r.GetBody != nil:
+		if rc, err := r.GetBody(); err == nil {
+			body, _ = ioutil.ReadAll(rc)
+			_ = rc.Close()
+		}
+	}
+	summary.BodySize = len(body)
+	if len(body) == 0 {
+		return summary
+	}
+	if len(body) > auditMaxBodySize {
+		summary.BodyTruncated = true
+		return summary
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		// non JSON bodies may hold anything, only their size is recorded
+		summary.BodyTruncated = true
+		return summary
+	}
+	summary.Body = redact(parsed)
+
+	return summary
+}
+
+// redact replaces the values of sensitive fields in the decoded JSON value
+func redact(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if sensitiveFieldPattern.MatchString(k) {
+				val[k] = auditRedacted
+				continue
+			}
+			val[k] = redact(item)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = redact(item)
+		}
+		return val
+	}
+	return v
+}
+
+// addAuditSupport wraps the resource functions, so that the API calls they make are recorded with the resource type and ID
+func addAuditSupport(resourceType string, r *schema.Resource) {
+	r.CreateContext = withAuditResource(resourceType, r.CreateContext)
+	r.ReadContext = withAuditResource(resourceType, r.ReadContext)
+	r.UpdateContext = withAuditResource(resourceType, r.UpdateContext)
+	r.DeleteContext = withAuditResource(resourceType, r.DeleteContext)
+}
+
+func withAuditResource(resourceType string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		res := &auditResource{resourceType: resourceType, d: d}
+		defer res.flush()
+		return f(context.WithValue(ctx, auditResourceKey{}, res), d, m)
+	}
+}
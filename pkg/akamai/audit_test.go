@@ -0,0 +1,162 @@
+package akamai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestAuditedSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+	sink, err := newAuditSink(path)
+	require.NoError(t, err)
+
+	sess := &mockSession{responses: []int{200, 201, 204}}
+	audited := newAuditedSession(sess, &auditor{sink: sink, operationID: "op-1", profile: "child"})
+
+	d := (&schema.Resource{Schema: map[string]*schema.Schema{}}).TestResourceData()
+	d.SetId("prp_1")
+	ctx := context.WithValue(context.Background(), auditResourceKey{}, &auditResource{resourceType: "akamai_property", d: d})
+
+	get, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://host/papi/v1/properties/prp_1", nil)
+	require.NoError(t, err)
+	_, err = audited.Exec(get, nil)
+	require.NoError(t, err)
+
+	post, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://host/papi/v1/properties?contractId=ctr_1&accountSwitchKey=1-ABC", nil)
+	require.NoError(t, err)
+	_, err = audited.Exec(post, nil, map[string]interface{}{
+		"propertyName": "test",
+		"origin":       map[string]interface{}{"clientSecret": "s3cr3t", "hostname": "origin.example.com"},
+	})
+	require.NoError(t, err)
+
+	del, err := http.NewRequest(http.MethodDelete, "https://host/papi/v1/properties/prp_1", nil)
+	require.NoError(t, err)
+	_, err = audited.Exec(del, nil)
+	require.NoError(t, err)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+
+	// the GET request is not recorded
+	require.Len(t, records, 2)
+	assert.Equal(t, 3, sess.calls)
+
+	create := records[0]
+	assert.Equal(t, "op-1", create["operation_id"])
+	assert.Equal(t, "child", create["profile"])
+	assert.Equal(t, "akamai_property", create["resource_type"])
+	assert.Equal(t, "prp_1", create["resource_id"])
+	assert.Equal(t, http.MethodPost, create["method"])
+	assert.Equal(t, "/papi/v1/properties", create["endpoint"])
+	assert.Equal(t, float64(201), create["status_code"])
+	request := create["request"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"contractId":       []interface{}{"ctr_1"},
+		"accountSwitchKey": []interface{}{auditRedacted},
+	}, request["query"])
+	assert.Equal(t, map[string]interface{}{
+		"propertyName": "test",
+		"origin":       map[string]interface{}{"clientSecret": auditRedacted, "hostname": "origin.example.com"},
+	}, request["body"])
+
+	remove := records[1]
+	assert.Equal(t, http.MethodDelete, remove["method"])
+	assert.Equal(t, float64(204), remove["status_code"])
+	assert.NotContains(t, remove, "resource_type")
+}
+
+func TestAuditedSession_create(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := newAuditSink(path)
+	require.NoError(t, err)
+	audited := newAuditedSession(&mockSession{responses: []int{201}}, &auditor{sink: sink, operationID: "op-1"})
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			post, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://host/papi/v1/properties", nil)
+			require.NoError(t, err)
+			_, err = audited.Exec(post, nil)
+			require.NoError(t, err)
+
+			// the call is recorded only once the ID is known
+			content, err := ioutil.ReadFile(path)
+			require.NoError(t, err)
+			assert.Empty(t, content)
+
+			d.SetId("prp_2")
+			return nil
+		},
+	}
+	addAuditSupport("akamai_property", r)
+	diags := r.CreateContext(context.Background(), r.TestResourceData(), nil)
+	require.Nil(t, diags)
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &record))
+	assert.Equal(t, "akamai_property", record["resource_type"])
+	assert.Equal(t, "prp_2", record["resource_id"])
+	assert.Equal(t, http.MethodPost, record["method"])
+}
+
+func TestAuditedSession_disabled(t *testing.T) {
+	sess := &mockSession{}
+	assert.Equal(t, sess, newAuditedSession(sess, nil))
+}
+
+func TestRedact(t *testing.T) {
+	tests := map[string]struct {
+		given    interface{}
+		expected interface{}
+	}{
+		"nested fields": {
+			given: map[string]interface{}{
+				"name":  "test",
+				"token": "abc",
+				"items": []interface{}{map[string]interface{}{"password": "p", "user": "u"}},
+			},
+			expected: map[string]interface{}{
+				"name":  "test",
+				"token": auditRedacted,
+				"items": []interface{}{map[string]interface{}{"password": auditRedacted, "user": "u"}},
+			},
+		},
+		"certificate ids are kept": {
+			given:    map[string]interface{}{"certificateId": "123", "certificate": "-----BEGIN CERTIFICATE-----"},
+			expected: map[string]interface{}{"certificateId": "123", "certificate": auditRedacted},
+		},
+		"scalar": {
+			given:    "value",
+			expected: "value",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, redact(test.given))
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package akamai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/allegro/bigcache/v2"
+)
+
+const (
+	// CacheTypeMemory keeps cached API responses in the memory of a single provider process
+	CacheTypeMemory = "memory"
+
+	// CacheTypeFile keeps cached API responses on disk so they can be shared between provider processes
+	CacheTypeFile = "file"
+
+	// DefaultCacheTTL is the default time to live of cached API responses
+	DefaultCacheTTL = 10 * time.Minute
+
+	cacheDirName = "terraform-provider-akamai"
+)
+
+type (
+	// cacheStore is the storage backend used by meta.CacheGet and meta.CacheSet
+	cacheStore interface {
+		// Get returns the data stored for the given subprovider and key
+		Get(prov, key string) ([]byte, error)
+
+		// Set stores the data for the given subprovider and key
+		Set(prov, key string, data []byte) error
+
+		// Invalidate removes all entries stored for the given subprovider
+		Invalidate(prov string) error
+	}
+
+	memoryCache struct {
+		cache *bigcache.BigCache
+		mu    sync.Mutex
+		// keys tracks the keys set by every subprovider, as bigcache can only delete single entries
+		keys map[string]map[string]struct{}
+	}
+
+	fileCache struct {
+		dir string
+		ttl time.Duration
+	}
+
+	fileCacheEntry struct {
+		Key     string          `json:"key"`
+		Expires time.Time       `json:"expires"`
+		Data    json.RawMessage `json:"data"`
+	}
+)
+
+func newMemoryCache(cache *bigcache.BigCache) *memoryCache {
+	return &memoryCache{
+		cache: cache,
+		keys:  make(map[string]map[string]struct{}),
+	}
+}
+
+func (c *memoryCache) Get(prov, key string) ([]byte, error) {
+	data, err := c.cache.Get(memoryCacheKey(prov, key))
+	if err != nil {
+		if errors.Is(err, bigcache.ErrEntryNotFound) {
+			return nil, ErrCacheEntryNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *memoryCache) Set(prov, key string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.cache.Set(memoryCacheKey(prov, key), data); err != nil {
+		return err
+	}
+	if _, ok := c.keys[prov]; !ok {
+		c.keys[prov] = make(map[string]struct{})
+	}
+	c.keys[prov][key] = struct{}{}
+	return nil
+}
+
+func (c *memoryCache) Invalidate(prov string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.keys[prov] {
+		if err := c.cache.Delete(memoryCacheKey(prov, key)); err != nil && !errors.Is(err, bigcache.ErrEntryNotFound) {
+			return err
+		}
+	}
+	delete(c.keys, prov)
+	return nil
+}
+
+func memoryCacheKey(prov, key string) string {
+	return fmt.Sprintf("%s:%s", key, prov)
+}
+
+// newFileCache returns a cache store keeping one file per entry in a directory per subprovider
+//
+// if dir is empty, the terraform-provider-akamai directory in the user cache directory is used
+func newFileCache(dir string, ttl time.Duration) (cacheStore, error) {
+	if dir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrCacheDirectory, err)
+		}
+		dir = filepath.Join(userCacheDir, cacheDirName)
+	}
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCacheDirectory, err)
+	}
+	return &fileCache{dir: dir, ttl: ttl}, nil
+}
+
+func (c *fileCache) Get(prov, key string) ([]byte, error) {
+	path := c.entryPath(prov, key)
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrCacheEntryNotFound
+		}
+		return nil, err
+	}
+
+	var entry fileCacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.Key != key {
+		// corrupted entries and hash collisions are treated as a cache miss
+		return nil, ErrCacheEntryNotFound
+	}
+	if time.Now().After(entry.Expires) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, ErrCacheEntryNotFound
+	}
+
+	return entry.Data, nil
+}
+
+func (c *fileCache) Set(prov, key string, data []byte) error {
+	raw, err := json.Marshal(fileCacheEntry{
+		Key:     key,
+		Expires: time.Now().Add(c.ttl),
+		Data:    data,
+	})
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(c.dir, prov)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// write to a temporary file first and rename it, so that concurrent
+	// provider processes never read a partially written entry
+	tmp, err := ioutil.TempFile(dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.entryPath(prov, key)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+func (c *fileCache) Invalidate(prov string) error {
+	return os.RemoveAll(filepath.Join(c.dir, prov))
+}
+
+func (c *fileCache) entryPath(prov, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, prov, hex.EncodeToString(sum[:])+".json")
+}
+
+// credentialsCacheKey returns the hash of the API host, client token and account switch key,
+// which identifies the account whose responses are cached
+func credentialsCacheKey(edgerc *edgegrid.Config) string {
+	sum := sha256.Sum256([]byte(edgerc.Host + "\x00" + edgerc.ClientToken + "\x00" + edgerc.AccountKey))
+	return hex.EncodeToString(sum[:])
+}
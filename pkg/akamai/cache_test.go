@@ -2,14 +2,19 @@ package akamai
 
 import (
 	"context"
+	"errors"
 	"regexp"
 	"testing"
+	"time"
 
+	"github.com/allegro/bigcache/v2"
 	"github.com/apex/log"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
 )
 
 type (
@@ -293,3 +298,74 @@ func (d *cacheSubprovider) Configure(log log.Interface, _ *schema.ResourceData)
 
 	return nil
 }
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("set and get", func(t *testing.T) {
+		cache, err := newFileCache(dir, time.Minute)
+		require.NoError(t, err)
+
+		require.NoError(t, cache.Set("property", "groups", []byte(`{"foo":"bar"}`)))
+		data, err := cache.Get("property", "groups")
+		require.NoError(t, err)
+		assert.Equal(t, `{"foo":"bar"}`, string(data))
+	})
+
+	t.Run("entries are shared between cache instances", func(t *testing.T) {
+		cache, err := newFileCache(dir, time.Minute)
+		require.NoError(t, err)
+
+		data, err := cache.Get("property", "groups")
+		require.NoError(t, err)
+		assert.Equal(t, `{"foo":"bar"}`, string(data))
+	})
+
+	t.Run("missing entry", func(t *testing.T) {
+		cache, err := newFileCache(dir, time.Minute)
+		require.NoError(t, err)
+
+		_, err = cache.Get("property", "contracts")
+		assert.True(t, errors.Is(err, ErrCacheEntryNotFound))
+	})
+
+	t.Run("expired entry", func(t *testing.T) {
+		cache, err := newFileCache(dir, time.Nanosecond)
+		require.NoError(t, err)
+
+		require.NoError(t, cache.Set("appsec", "config", []byte(`1`)))
+		time.Sleep(time.Millisecond)
+		_, err = cache.Get("appsec", "config")
+		assert.True(t, errors.Is(err, ErrCacheEntryNotFound))
+	})
+
+	t.Run("invalidate subprovider", func(t *testing.T) {
+		cache, err := newFileCache(dir, time.Minute)
+		require.NoError(t, err)
+
+		require.NoError(t, cache.Set("appsec", "config", []byte(`1`)))
+		require.NoError(t, cache.Invalidate("property"))
+
+		_, err = cache.Get("property", "groups")
+		assert.True(t, errors.Is(err, ErrCacheEntryNotFound))
+		data, err := cache.Get("appsec", "config")
+		require.NoError(t, err)
+		assert.Equal(t, `1`, string(data))
+	})
+}
+
+func TestMemoryCacheInvalidate(t *testing.T) {
+	bc, err := bigcache.NewBigCache(bigcache.DefaultConfig(time.Minute))
+	require.NoError(t, err)
+	cache := newMemoryCache(bc)
+
+	require.NoError(t, cache.Set("property", "groups", []byte(`1`)))
+	require.NoError(t, cache.Set("appsec", "config", []byte(`2`)))
+	require.NoError(t, cache.Invalidate("property"))
+
+	_, err = cache.Get("property", "groups")
+	assert.True(t, errors.Is(err, ErrCacheEntryNotFound))
+	data, err := cache.Get("appsec", "config")
+	require.NoError(t, err)
+	assert.Equal(t, `2`, string(data))
+}
@@ -0,0 +1,274 @@
+package akamai
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/go-homedir"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+)
+
+const (
+	// DefaultPassphraseEnv is the environment variable holding the passphrase of the encrypted credentials file
+	DefaultPassphraseEnv = "AKAMAI_CREDENTIALS_PASSPHRASE"
+
+	// DefaultPBKDF2Iterations is the default number of PBKDF2 iterations used to derive the encrypted file key
+	DefaultPBKDF2Iterations = 10000
+
+	defaultCredentialCommandTimeout = time.Minute
+
+	opensslSaltHeader = "Salted__"
+)
+
+type (
+	// CredentialSource provides EdgeGrid credentials from outside of the Terraform configuration and state
+	CredentialSource interface {
+		// Credentials returns the EdgeGrid configuration
+		Credentials(ctx context.Context) (*edgegrid.Config, error)
+	}
+
+	// commandCredentialSource runs an external command printing the credentials as JSON to its standard output
+	commandCredentialSource struct {
+		command []string
+		timeout time.Duration
+	}
+
+	// encryptedFileCredentialSource decrypts a JSON credentials file encrypted with
+	// openssl enc -aes-256-cbc -pbkdf2, using a passphrase read from an environment variable
+	encryptedFileCredentialSource struct {
+		path          string
+		passphraseEnv string
+		iterations    int
+	}
+
+	// credentialsJSON is the JSON document produced by credential sources
+	credentialsJSON struct {
+		Host         string `json:"host"`
+		ClientToken  string `json:"client_token"`
+		ClientSecret string `json:"client_secret"`
+		AccessToken  string `json:"access_token"`
+		AccountKey   string `json:"account_key"`
+		MaxBody      int    `json:"max_body"`
+	}
+)
+
+// credentialSourceSchema returns the schema of the credential_source block
+func credentialSourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Reads EdgeGrid credentials from an external command or an encrypted file instead of the edgerc file",
+		Optional:    true,
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"command": {
+					Description: "The command and its arguments, which prints the credentials as JSON to its standard output",
+					Optional:    true,
+					Type:        schema.TypeList,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"command_timeout": {
+					Description:      "How long the command may run, e.g. 30s",
+					Optional:         true,
+					Type:             schema.TypeString,
+					Default:          defaultCredentialCommandTimeout.String(),
+					ValidateDiagFunc: tools.ValidateDuration,
+				},
+				"encrypted_file": {
+					Description: "The location of the credentials JSON file encrypted with openssl enc -aes-256-cbc -pbkdf2",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+				"passphrase_env": {
+					Description: "The environment variable holding the passphrase of the encrypted file",
+					Optional:    true,
+					Type:        schema.TypeString,
+					Default:     DefaultPassphraseEnv,
+				},
+				"pbkdf2_iterations": {
+					Description: "The number of PBKDF2 iterations used when encrypting the file",
+					Optional:    true,
+					Type:        schema.TypeInt,
+					Default:     DefaultPBKDF2Iterations,
+				},
+			},
+		},
+	}
+}
+
+// getCredentialSource returns the credential source configured in the credential_source block, or nil if there is none
+func getCredentialSource(rd tools.ResourceDataFetcher) (CredentialSource, error) {
+	blocks, err := tools.GetInterfaceArrayValue("credential_source", rd)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil, fmt.Errorf("%w: either command or encrypted_file must be set", ErrCredentialSource)
+	}
+	block, ok := blocks[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "credential_source", "map[string]interface{}")
+	}
+
+	var command []string
+	if list, ok := block["command"].([]interface{}); ok {
+		for _, arg := range list {
+			if s, ok := arg.(string); ok {
+				command = append(command, s)
+			}
+		}
+	}
+	path, _ := block["encrypted_file"].(string)
+
+	switch {
+	case len(command) > 0 && path != "":
+		return nil, fmt.Errorf("%w: only one of command or encrypted_file can be set", ErrCredentialSource)
+	case len(command) > 0:
+		timeout := defaultCredentialCommandTimeout
+		if t, ok := block["command_timeout"].(string); ok && t != "" {
+			if timeout, err = time.ParseDuration(t); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrCredentialSource, err)
+			}
+		}
+		return &commandCredentialSource{command: command, timeout: timeout}, nil
+	case path != "":
+		passphraseEnv, _ := block["passphrase_env"].(string)
+		if passphraseEnv == "" {
+			passphraseEnv = DefaultPassphraseEnv
+		}
+		iterations, _ := block["pbkdf2_iterations"].(int)
+		if iterations <= 0 {
+			iterations = DefaultPBKDF2Iterations
+		}
+		return &encryptedFileCredentialSource{path: path, passphraseEnv: passphraseEnv, iterations: iterations}, nil
+	}
+
+	return nil, fmt.Errorf("%w: either command or encrypted_file must be set", ErrCredentialSource)
+}
+
+// Credentials runs the command and parses its standard output
+func (s *commandCredentialSource) Credentials(ctx context.Context) (*edgegrid.Config, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.command[0], s.command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: command %q failed: %s: %s", ErrCredentialSource, s.command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseCredentials(stdout.Bytes())
+}
+
+// Credentials decrypts the file with the passphrase from the environment and parses its content
+func (s *encryptedFileCredentialSource) Credentials(_ context.Context) (*edgegrid.Config, error) {
+	passphrase, ok := os.LookupEnv(s.passphraseEnv)
+	if !ok || passphrase == "" {
+		return nil, fmt.Errorf("%w: passphrase environment variable %s is not set", ErrCredentialSource, s.passphraseEnv)
+	}
+
+	path, err := homedir.Expand(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid path: %s", ErrCredentialSource, err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCredentialSource, err)
+	}
+
+	plaintext, err := decryptOpenSSL(data, []byte(passphrase), s.iterations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot decrypt %s: %s", ErrCredentialSource, s.path, err)
+	}
+
+	return parseCredentials(plaintext)
+}
+
+func parseCredentials(data []byte) (*edgegrid.Config, error) {
+	var creds credentialsJSON
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("%w: invalid credentials JSON: %s", ErrCredentialSource, err)
+	}
+
+	var missing []string
+	for _, field := range []struct{ name, value string }{
+		{"host", creds.Host},
+		{"client_token", creds.ClientToken},
+		{"client_secret", creds.ClientSecret},
+		{"access_token", creds.AccessToken},
+	} {
+		if field.value == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: credentials are missing %s", ErrCredentialSource, strings.Join(missing, ", "))
+	}
+
+	edgerc := &edgegrid.Config{
+		Host:         creds.Host,
+		ClientToken:  creds.ClientToken,
+		ClientSecret: creds.ClientSecret,
+		AccessToken:  creds.AccessToken,
+		AccountKey:   creds.AccountKey,
+		MaxBody:      creds.MaxBody,
+	}
+	if edgerc.MaxBody <= 0 {
+		edgerc.MaxBody = edgegrid.MaxBodySize
+	}
+
+	return edgerc, nil
+}
+
+// decryptOpenSSL decrypts data produced by
+// openssl enc -aes-256-cbc -pbkdf2 -iter <iterations> -salt
+func decryptOpenSSL(data, passphrase []byte, iterations int) ([]byte, error) {
+	if len(data) < len(opensslSaltHeader)+8 || string(data[:len(opensslSaltHeader)]) != opensslSaltHeader {
+		return nil, errors.New("missing openssl salt header, make sure the file was encrypted with the -salt option")
+	}
+	salt := data[len(opensslSaltHeader) : len(opensslSaltHeader)+8]
+	ciphertext := data[len(opensslSaltHeader)+8:]
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid ciphertext length")
+	}
+
+	keyIV := pbkdf2.Key(passphrase, salt, iterations, 32+aes.BlockSize, sha256.New)
+	block, err := aes.NewCipher(keyIV[:32])
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, keyIV[32:]).CryptBlocks(plaintext, ciphertext)
+
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize || padding > len(plaintext) {
+		return nil, errors.New("bad padding, the passphrase may be wrong")
+	}
+	for _, b := range plaintext[len(plaintext)-padding:] {
+		if int(b) != padding {
+			return nil, errors.New("bad padding, the passphrase may be wrong")
+		}
+	}
+
+	return plaintext[:len(plaintext)-padding], nil
+}
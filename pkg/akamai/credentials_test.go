@@ -0,0 +1,170 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestGetCredentialSource(t *testing.T) {
+	tests := map[string]struct {
+		block        mapFetcher
+		expected     CredentialSource
+		withError    error
+		expectedNone bool
+	}{
+		"no credential source": {
+			block:        mapFetcher{},
+			expectedNone: true,
+		},
+		"command": {
+			block: mapFetcher{"credential_source": []interface{}{map[string]interface{}{
+				"command":         []interface{}{"vault-edgerc", "--section", "papi"},
+				"command_timeout": "30s",
+			}}},
+			expected: &commandCredentialSource{command: []string{"vault-edgerc", "--section", "papi"}, timeout: 30 * time.Second},
+		},
+		"encrypted file with defaults": {
+			block: mapFetcher{"credential_source": []interface{}{map[string]interface{}{
+				"encrypted_file": "~/.edgerc.enc",
+			}}},
+			expected: &encryptedFileCredentialSource{path: "~/.edgerc.enc", passphraseEnv: DefaultPassphraseEnv, iterations: DefaultPBKDF2Iterations},
+		},
+		"command and encrypted file": {
+			block: mapFetcher{"credential_source": []interface{}{map[string]interface{}{
+				"command":        []interface{}{"vault-edgerc"},
+				"encrypted_file": "~/.edgerc.enc",
+			}}},
+			withError: ErrCredentialSource,
+		},
+		"empty block": {
+			block:     mapFetcher{"credential_source": []interface{}{map[string]interface{}{}}},
+			withError: ErrCredentialSource,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			source, err := getCredentialSource(test.block)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			if test.expectedNone {
+				assert.Nil(t, source)
+				return
+			}
+			assert.Equal(t, test.expected, source)
+		})
+	}
+}
+
+func TestCommandCredentialSource(t *testing.T) {
+	tests := map[string]struct {
+		command   []string
+		withError bool
+	}{
+		"valid credentials": {
+			command: []string{"sh", "-c", `echo '{"host":"akaa-cmd.luna.akamaiapis.net","client_token":"ct","client_secret":"cs","access_token":"at","account_key":"1-ABCDE"}'`},
+		},
+		"missing fields": {
+			command:   []string{"sh", "-c", `echo '{"host":"akaa-cmd.luna.akamaiapis.net"}'`},
+			withError: true,
+		},
+		"invalid JSON": {
+			command:   []string{"sh", "-c", "echo not-json"},
+			withError: true,
+		},
+		"command fails": {
+			command:   []string{"sh", "-c", "echo denied >&2; exit 1"},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			source := &commandCredentialSource{command: test.command, timeout: defaultCredentialCommandTimeout}
+			edgerc, err := source.Credentials(context.Background())
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrCredentialSource), "want: %s; got: %s", ErrCredentialSource, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "akaa-cmd.luna.akamaiapis.net", edgerc.Host)
+			assert.Equal(t, "ct", edgerc.ClientToken)
+			assert.Equal(t, "cs", edgerc.ClientSecret)
+			assert.Equal(t, "at", edgerc.AccessToken)
+			assert.Equal(t, "1-ABCDE", edgerc.AccountKey)
+		})
+	}
+}
+
+func TestEncryptedFileCredentialSource(t *testing.T) {
+	// testdata/credentials.enc was created with:
+	// openssl enc -aes-256-cbc -pbkdf2 -iter 10000 -salt -md sha256 -pass pass:testpassphrase
+	tests := map[string]struct {
+		passphrase string
+		withError  bool
+	}{
+		"valid passphrase": {
+			passphrase: "testpassphrase",
+		},
+		"wrong passphrase": {
+			passphrase: "wrongpassphrase",
+			withError:  true,
+		},
+		"missing passphrase": {
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := "AKAMAI_TEST_CREDENTIALS_PASSPHRASE"
+			if test.passphrase != "" {
+				require.NoError(t, os.Setenv(env, test.passphrase))
+				defer func() {
+					require.NoError(t, os.Unsetenv(env))
+				}()
+			}
+			source := &encryptedFileCredentialSource{path: "testdata/credentials.enc", passphraseEnv: env, iterations: DefaultPBKDF2Iterations}
+			edgerc, err := source.Credentials(context.Background())
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrCredentialSource), "want: %s; got: %s", ErrCredentialSource, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "akaa-encrypted-xxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net", edgerc.Host)
+			assert.Equal(t, "akaa-encrypted-client-token", edgerc.ClientToken)
+			assert.Equal(t, "1-ABCDE", edgerc.AccountKey)
+		})
+	}
+}
+
+func TestGetEdgegridConfigCredentialSource(t *testing.T) {
+	t.Run("credential source is used", func(t *testing.T) {
+		edgerc, err := getEdgegridConfig(context.Background(), mapFetcher{
+			"credential_source": []interface{}{map[string]interface{}{
+				"command": []interface{}{"sh", "-c", `echo '{"host":"akaa-cmd.luna.akamaiapis.net","client_token":"ct","client_secret":"cs","access_token":"at"}'`},
+			}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "akaa-cmd.luna.akamaiapis.net", edgerc.Host)
+	})
+
+	t.Run("credential source cannot be combined with config", func(t *testing.T) {
+		_, err := getEdgegridConfig(context.Background(), mapFetcher{
+			"config": []interface{}{map[string]interface{}{"host": "h"}},
+			"credential_source": []interface{}{map[string]interface{}{
+				"command": []interface{}{"true"},
+			}},
+		})
+		assert.True(t, errors.Is(err, ErrCredentialSource), "want: %s; got: %s", ErrCredentialSource, err)
+	})
+}
@@ -0,0 +1,422 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+)
+
+const (
+	// DefaultsKey is the name of the provider block holding the values used by resources which omit them
+	DefaultsKey = "defaults"
+)
+
+type (
+	// defaults are the values of the provider defaults block
+	defaults struct {
+		contractID           string
+		groupID              string
+		notificationEmails   []string
+		activationNotePrefix string
+	}
+
+	// defaultAttribute lists the attribute names a provider default applies to, in order of preference
+	defaultAttribute struct {
+		names  []string
+		prefix string
+		value  func(defaults) interface{}
+		// isNote prepends the activation note prefix also to the configured values
+		isNote bool
+	}
+
+	// defaultField is a default attribute found in a resource schema, with the names the resource uses
+	defaultField struct {
+		defaultAttribute
+		keys     []string
+		schema   *schema.Schema
+		fallback interface{}
+		// required lists the keys of which one must be set, empty if the attribute is optional
+		required []string
+		// activating lists the keys whose change starts a new activation, so that the note prefix is applied
+		activating []string
+	}
+)
+
+// activatedVersionKeys are the attributes selecting what is activated, besides the ones forcing a new resource
+var activatedVersionKeys = []string{"version", "sync_point", "hostnames"}
+
+var defaultAttributes = []defaultAttribute{
+	{
+		names:  []string{"contract_id", "contract"},
+		prefix: "ctr_",
+		value:  func(d defaults) interface{} { return d.contractID },
+	},
+	{
+		names:  []string{"group_id", "group"},
+		prefix: "grp_",
+		value:  func(d defaults) interface{} { return d.groupID },
+	},
+	{
+		names: []string{"notification_emails", "notify_emails", "contact"},
+		value: func(d defaults) interface{} { return d.notificationEmails },
+	},
+	{
+		names:  []string{"note", "notes"},
+		value:  func(d defaults) interface{} { return d.activationNotePrefix },
+		isNote: true,
+	},
+}
+
+// defaultsSchema returns the schema of the provider defaults block
+func defaultsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Values used by the resources which omit them",
+		Optional:    true,
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"contract_id": {
+					Description: "The contract of the resources which do not set one",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+				"group_id": {
+					Description: "The group of the resources which do not set one",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+				"notification_emails": {
+					Description: "The emails notified of the activations which do not set any",
+					Optional:    true,
+					Type:        schema.TypeList,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"activation_note_prefix": {
+					Description: "The text prepended to the note of every activation",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// getDefaults reads the provider defaults block, adding the ctr_ and grp_ prefixes to the contract and group
+func getDefaults(d tools.ResourceDataFetcher) (defaults, error) {
+	blocks, err := tools.GetListValue(DefaultsKey, d)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return defaults{}, nil
+		}
+		return defaults{}, err
+	}
+	block, ok := blocks[0].(map[string]interface{})
+	if !ok {
+		// the block is empty
+		return defaults{}, nil
+	}
+	rd := mapFetcher(block)
+
+	var dflt defaults
+	for key, value := range map[string]*string{
+		"contract_id":            &dflt.contractID,
+		"group_id":               &dflt.groupID,
+		"activation_note_prefix": &dflt.activationNotePrefix,
+	} {
+		v, err := tools.GetStringValue(key, rd)
+		if err != nil && !errors.Is(err, tools.ErrNotFound) {
+			return defaults{}, fmt.Errorf("%s: %w", DefaultsKey, err)
+		}
+		*value = v
+	}
+	dflt.contractID = tools.AddPrefix(dflt.contractID, "ctr_")
+	dflt.groupID = tools.AddPrefix(dflt.groupID, "grp_")
+
+	emails, err := tools.GetListValue("notification_emails", rd)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return defaults{}, fmt.Errorf("%s: %w", DefaultsKey, err)
+	}
+	for _, email := range emails {
+		if s, ok := email.(string); ok && s != "" {
+			dflt.notificationEmails = append(dflt.notificationEmails, s)
+		}
+	}
+
+	return dflt, nil
+}
+
+// WithProviderDefaults lets the resource omit its contract, group, notification emails and activation note,
+// which are then taken from the provider defaults block
+//
+// The attributes become optional and computed, and the defaults are set when planning, so that the plan
+// and the state show the values used and the resource functions read them like configured ones.
+// Attributes which were required fail the plan when they are set neither in the resource nor in the defaults block.
+func WithProviderDefaults(r *schema.Resource) *schema.Resource {
+	var fields []*defaultField
+	for _, attr := range defaultAttributes {
+		if f := newDefaultField(r.Schema, attr); f != nil {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) == 0 {
+		return r
+	}
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		var dflt defaults
+		if mt, ok := m.(*meta); ok {
+			dflt = mt.defaults
+		}
+		for _, f := range fields {
+			if err := f.apply(d, dflt); err != nil {
+				return err
+			}
+		}
+		if customizeDiff != nil {
+			return customizeDiff(ctx, d, m)
+		}
+		return nil
+	}
+
+	return r
+}
+
+// newDefaultField makes the attributes of the resource matching the default attribute optional and computed,
+// or returns nil if the resource has none of them
+func newDefaultField(s map[string]*schema.Schema, attr defaultAttribute) *defaultField {
+	f := &defaultField{defaultAttribute: attr}
+	for _, key := range attr.names {
+		if sch, ok := s[key]; ok && (sch.Required || sch.Optional) {
+			f.keys = append(f.keys, key)
+		}
+	}
+	if len(f.keys) == 0 {
+		return nil
+	}
+	f.schema = s[f.keys[0]]
+
+	for _, key := range f.keys {
+		sch := s[key]
+		if sch.Required {
+			f.required = []string{key}
+			sch.Required = false
+			sch.Optional = true
+		}
+		// exactly one of the aliases must now be set either in the resource or in the defaults
+		if len(sch.ExactlyOneOf) > 0 {
+			f.required = append([]string{}, sch.ExactlyOneOf...)
+			sort.Strings(f.required)
+			for _, other := range sch.ExactlyOneOf {
+				if other != key {
+					sch.ConflictsWith = append(sch.ConflictsWith, other)
+				}
+			}
+			sch.ExactlyOneOf = nil
+		}
+		// computed attributes cannot have a default
+		if sch.Default != nil {
+			if key == f.keys[0] {
+				f.fallback = sch.Default
+			}
+			sch.Default = nil
+		}
+		sch.Computed = true
+	}
+
+	if f.isNote {
+		for key, sch := range s {
+			if sch.ForceNew || tools.ContainsString(activatedVersionKeys, key) {
+				if !tools.ContainsString(f.keys, key) {
+					f.activating = append(f.activating, key)
+				}
+			}
+		}
+		sort.Strings(f.activating)
+	}
+
+	return f
+}
+
+// apply sets the provider default of the attribute if the resource does not configure it
+func (f *defaultField) apply(d *schema.ResourceDiff, dflt defaults) error {
+	key := f.keys[0]
+	configured := ""
+	for _, k := range f.keys {
+		if isConfigured(d, k) {
+			configured = k
+			break
+		}
+	}
+
+	var value interface{}
+	switch {
+	case f.isNote:
+		prefix, _ := f.value(dflt).(string)
+		note, _ := f.fallback.(string)
+		if configured != "" {
+			if !d.NewValueKnown(configured) {
+				return nil
+			}
+			key = configured
+			note, _ = d.Get(configured).(string)
+		}
+		// existing resources keep their note until they are activated again,
+		// so that setting the prefix does not start new activations
+		if d.Id() != "" && !d.HasChanges(f.activating...) {
+			if old, _ := d.GetChange(key); old == note || old == prefix+note {
+				note = old.(string)
+				prefix = ""
+			}
+		}
+		if prefix != "" && !strings.HasPrefix(note, prefix) {
+			note = prefix + note
+		}
+		if note == "" {
+			return nil
+		}
+		value = note
+	case configured != "":
+		return nil
+	default:
+		value = f.value(dflt)
+		if isEmptyDefault(value) {
+			value = f.fallback
+		}
+		if isEmptyDefault(value) {
+			return f.missingValueError()
+		}
+	}
+
+	value, err := f.convert(key, value)
+	if err != nil {
+		return err
+	}
+	if d.NewValueKnown(key) && f.equal(d.Get(key), value) {
+		return nil
+	}
+	return d.SetNew(key, value)
+}
+
+// missingValueError returns the error of a required attribute set neither in the resource nor in the defaults,
+// worded like the schema validation errors it replaces
+func (f *defaultField) missingValueError() error {
+	switch len(f.required) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("%q is required: %w", f.required[0], ErrDefaultNotSet)
+	}
+	return fmt.Errorf("one of `%s` must be specified: %w", strings.Join(f.required, ","), ErrDefaultNotSet)
+}
+
+// convert converts the default to the type of the attribute
+func (f *defaultField) convert(key string, value interface{}) (interface{}, error) {
+	switch f.schema.Type {
+	case schema.TypeInt:
+		s, ok := value.(string)
+		if !ok {
+			return value, nil
+		}
+		id, err := tools.GetIntID(s, f.prefix)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, key, "int")
+		}
+		return id, nil
+	case schema.TypeSet, schema.TypeList:
+		values, ok := value.([]string)
+		if !ok {
+			return value, nil
+		}
+		list := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			list = append(list, v)
+		}
+		return list, nil
+	case schema.TypeString:
+		// attributes which neither normalise nor ignore the prefix take the bare ID
+		s, ok := value.(string)
+		if ok && f.schema.StateFunc == nil && f.schema.DiffSuppressFunc == nil {
+			return strings.TrimPrefix(s, f.prefix), nil
+		}
+	}
+	return value, nil
+}
+
+// equal returns true if the planned value is the default, ignoring the contract and group prefixes
+// which the resources do not always store in the state
+func (f *defaultField) equal(planned, value interface{}) bool {
+	switch p := planned.(type) {
+	case string:
+		v, ok := value.(string)
+		return ok && strings.TrimPrefix(p, f.prefix) == strings.TrimPrefix(v, f.prefix)
+	case *schema.Set:
+		return equalStrings(p.List(), value)
+	case []interface{}:
+		return equalStrings(p, value)
+	}
+	return planned == value
+}
+
+func equalStrings(planned []interface{}, value interface{}) bool {
+	values, ok := value.([]interface{})
+	if !ok || len(values) != len(planned) {
+		return false
+	}
+	toStrings := func(list []interface{}) []string {
+		res := make([]string, 0, len(list))
+		for _, v := range list {
+			res = append(res, fmt.Sprint(v))
+		}
+		sort.Strings(res)
+		return res
+	}
+	a, b := toStrings(planned), toStrings(values)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isEmptyDefault(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []string:
+		return len(v) == 0
+	}
+	return false
+}
+
+// isConfigured returns true if the resource configuration sets a non-empty value for the key
+func isConfigured(d *schema.ResourceDiff, key string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(key) {
+		_, ok := d.GetOk(key)
+		return ok
+	}
+	v := config.GetAttr(key)
+	switch {
+	case !v.IsKnown():
+		return true
+	case v.IsNull():
+		return false
+	case v.Type() == cty.String:
+		return v.AsString() != ""
+	case v.Type().IsCollectionType():
+		return v.LengthInt() > 0
+	}
+	return true
+}
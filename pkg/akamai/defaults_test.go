@@ -0,0 +1,321 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestGetDefaults(t *testing.T) {
+	tests := map[string]struct {
+		raw      map[string]interface{}
+		expected defaults
+	}{
+		"no defaults": {
+			raw: map[string]interface{}{},
+		},
+		"prefixes added": {
+			raw: map[string]interface{}{
+				"defaults": []interface{}{map[string]interface{}{
+					"contract_id":            "1-AB123",
+					"group_id":               "12345",
+					"notification_emails":    []interface{}{"user@example.com"},
+					"activation_note_prefix": "[terraform] ",
+				}},
+			},
+			expected: defaults{
+				contractID:           "ctr_1-AB123",
+				groupID:              "grp_12345",
+				notificationEmails:   []string{"user@example.com"},
+				activationNotePrefix: "[terraform] ",
+			},
+		},
+		"prefixes kept": {
+			raw: map[string]interface{}{
+				"defaults": []interface{}{map[string]interface{}{
+					"contract_id": "ctr_1-AB123",
+					"group_id":    "grp_12345",
+				}},
+			},
+			expected: defaults{
+				contractID: "ctr_1-AB123",
+				groupID:    "grp_12345",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"defaults": defaultsSchema()}, test.raw)
+			dflt, err := getDefaults(d)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, dflt)
+		})
+	}
+}
+
+func TestWithProviderDefaults(t *testing.T) {
+	newResource := func() *schema.Resource {
+		return WithProviderDefaults(&schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"contract_id": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ExactlyOneOf: []string{"contract_id", "contract"},
+					StateFunc: func(v interface{}) string {
+						return v.(string)
+					},
+				},
+				"contract": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"group_id": {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+				"notify_emails": {
+					Type:     schema.TypeSet,
+					Required: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"note": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "Activation",
+				},
+			},
+			CreateContext: schema.NoopContext,
+			ReadContext:   schema.NoopContext,
+			DeleteContext: schema.NoopContext,
+			UpdateContext: schema.NoopContext,
+		})
+	}
+	allDefaults := defaults{
+		contractID:           "ctr_1",
+		groupID:              "grp_2",
+		notificationEmails:   []string{"user@example.com"},
+		activationNotePrefix: "[terraform] ",
+	}
+
+	tests := map[string]struct {
+		config    map[string]interface{}
+		defaults  defaults
+		expected  map[string]string
+		withError string
+	}{
+		"defaults": {
+			config:   map[string]interface{}{"name": "test"},
+			defaults: allDefaults,
+			expected: map[string]string{
+				"contract_id":     "ctr_1",
+				"group_id":        "2",
+				"notify_emails.#": "1",
+				"note":            "[terraform] Activation",
+			},
+		},
+		"configured values": {
+			config: map[string]interface{}{
+				"name":          "test",
+				"contract":      "ctr_3",
+				"group_id":      4,
+				"notify_emails": []interface{}{"other@example.com", "user@example.com"},
+				"note":          "release",
+			},
+			defaults: allDefaults,
+			expected: map[string]string{
+				"contract":        "ctr_3",
+				"contract_id":     "",
+				"group_id":        "4",
+				"notify_emails.#": "2",
+				"note":            "[terraform] release",
+			},
+		},
+		"schema default without prefix": {
+			config: map[string]interface{}{
+				"name":          "test",
+				"contract_id":   "ctr_3",
+				"group_id":      4,
+				"notify_emails": []interface{}{"user@example.com"},
+			},
+			expected: map[string]string{
+				"contract_id": "ctr_3",
+				"note":        "Activation",
+			},
+		},
+		"missing contract": {
+			config:    map[string]interface{}{"name": "test", "group_id": 4},
+			withError: "one of `contract,contract_id` must be specified",
+		},
+		"missing emails": {
+			config:    map[string]interface{}{"name": "test", "contract_id": "ctr_3", "group_id": 4},
+			withError: `"notify_emails" is required`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := newResource()
+			diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(test.config), &meta{defaults: test.defaults})
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, ErrDefaultNotSet), "want: %s; got: %s", ErrDefaultNotSet, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			require.NoError(t, err)
+			for key, value := range test.expected {
+				attr, ok := diff.Attributes[key]
+				if value == "" {
+					assert.False(t, ok && attr.New != "", "unexpected value of %s: %s", key, attr)
+					continue
+				}
+				require.True(t, ok, "missing %s", key)
+				assert.Equal(t, value, attr.New, key)
+			}
+		})
+	}
+}
+
+func TestWithProviderDefaults_schema(t *testing.T) {
+	r := WithProviderDefaults(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"group_id", "group"},
+			},
+			"group": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Activation",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	})
+
+	assert.True(t, r.Schema["group_id"].Computed)
+	assert.Empty(t, r.Schema["group_id"].ExactlyOneOf)
+	assert.Equal(t, []string{"group"}, r.Schema["group_id"].ConflictsWith)
+	assert.True(t, r.Schema["group"].Computed)
+	assert.Nil(t, r.Schema["notes"].Default)
+	assert.NotNil(t, r.CustomizeDiff)
+	require.NoError(t, r.InternalValidate(nil, true))
+}
+
+func TestWithProviderDefaults_bareID(t *testing.T) {
+	r := WithProviderDefaults(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"contract_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool { return old == new },
+			},
+		},
+	})
+
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{}),
+		&meta{defaults: defaults{contractID: "ctr_1-AB123", groupID: "grp_12345"}})
+	require.NoError(t, err)
+	assert.Equal(t, "1-AB123", diff.Attributes["contract_id"].New)
+	assert.Equal(t, "grp_12345", diff.Attributes["group_id"].New)
+}
+
+func TestWithProviderDefaults_existingNote(t *testing.T) {
+	r := WithProviderDefaults(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"network": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"note": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		CreateContext: schema.NoopContext,
+		ReadContext:   schema.NoopContext,
+		DeleteContext: schema.NoopContext,
+		UpdateContext: schema.NoopContext,
+	})
+	dflt := defaults{activationNotePrefix: "[terraform] "}
+
+	tests := map[string]struct {
+		stateNote string
+		config    map[string]interface{}
+		expected  string
+	}{
+		"prefix newly set": {
+			stateNote: "release",
+			config:    map[string]interface{}{"network": "STAGING", "version": 1, "note": "release"},
+		},
+		"prefix already applied": {
+			stateNote: "[terraform] release",
+			config:    map[string]interface{}{"network": "STAGING", "version": 1, "note": "release"},
+		},
+		"version changes": {
+			stateNote: "release",
+			config:    map[string]interface{}{"network": "STAGING", "version": 2, "note": "release"},
+			expected:  "[terraform] release",
+		},
+		"network changes": {
+			stateNote: "release",
+			config:    map[string]interface{}{"network": "PRODUCTION", "version": 1, "note": "release"},
+			expected:  "[terraform] release",
+		},
+		"note changes": {
+			stateNote: "release",
+			config:    map[string]interface{}{"network": "STAGING", "version": 1, "note": "hotfix"},
+			expected:  "[terraform] hotfix",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "1",
+				Attributes: map[string]string{
+					"id":      "1",
+					"network": "STAGING",
+					"version": "1",
+					"note":    test.stateNote,
+				},
+			}
+			diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(test.config), &meta{defaults: dflt})
+			require.NoError(t, err)
+			if test.expected == "" {
+				assert.True(t, diff == nil || diff.Attributes["note"] == nil, "unexpected change of note: %s", diff)
+				return
+			}
+			require.NotNil(t, diff)
+			attr, ok := diff.Attributes["note"]
+			require.True(t, ok, "missing note")
+			assert.Equal(t, test.expected, attr.New)
+		})
+	}
+}
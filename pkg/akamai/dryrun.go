@@ -0,0 +1,92 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	// dryRunSession is a session which refuses every request other than GET
+	dryRunSession struct {
+		session.Session
+	}
+
+	// dryRunCalls collects the calls blocked while running a resource function
+	dryRunCalls struct {
+		mu    sync.Mutex
+		calls []string
+	}
+
+	dryRunCallsKey struct{}
+)
+
+// newDryRunSession wraps the session so that it cannot modify anything, or returns the session unchanged if dryRun is false
+func newDryRunSession(sess session.Session, dryRun bool) session.Session {
+	if !dryRun {
+		return sess
+	}
+	return &dryRunSession{Session: sess}
+}
+
+// Exec executes GET requests and blocks all other requests with ErrDryRun
+func (s *dryRunSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	if r.Method == http.MethodGet {
+		return s.Session.Exec(r, out, in...)
+	}
+
+	call := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+	if calls, ok := r.Context().Value(dryRunCallsKey{}).(*dryRunCalls); ok {
+		calls.add(call)
+	}
+	s.Log(r.Context()).Warnf("dry run: blocked %s", call)
+
+	return nil, fmt.Errorf("%w: %s", ErrDryRun, call)
+}
+
+func (c *dryRunCalls) add(call string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, call)
+}
+
+func (c *dryRunCalls) list() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.calls...)
+}
+
+// addDryRunSupport wraps the functions of the resource which modify it, so that the calls blocked in dry run mode
+// are returned as warnings
+func addDryRunSupport(r *schema.Resource) {
+	r.CreateContext = withDryRunWarnings(r.CreateContext)
+	r.UpdateContext = withDryRunWarnings(r.UpdateContext)
+	r.DeleteContext = withDryRunWarnings(r.DeleteContext)
+}
+
+func withDryRunWarnings(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if mt, ok := m.(OperationMeta); !ok || !mt.DryRun() {
+			return f(ctx, d, m)
+		}
+
+		calls := &dryRunCalls{}
+		diags := f(context.WithValue(ctx, dryRunCallsKey{}, calls), d, m)
+		for _, call := range calls.list() {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Dry run: API call blocked",
+				Detail:   fmt.Sprintf("The provider runs in dry run mode, this call would have been made: %s", call),
+			})
+		}
+		return diags
+	}
+}
@@ -0,0 +1,81 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestDryRunSession(t *testing.T) {
+	sess := &mockSession{responses: []int{200}}
+	dryRun := newDryRunSession(sess, true)
+
+	get, err := http.NewRequest(http.MethodGet, "https://host/papi/v1/groups", nil)
+	require.NoError(t, err)
+	resp, err := dryRun.Exec(get, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		req, err := http.NewRequest(method, "https://host/papi/v1/properties", nil)
+		require.NoError(t, err)
+		_, err = dryRun.Exec(req, nil)
+		assert.True(t, errors.Is(err, ErrDryRun), "want: %s; got: %s", ErrDryRun, err)
+	}
+
+	assert.Equal(t, 1, sess.calls)
+	assert.Equal(t, sess, newDryRunSession(sess, false))
+}
+
+func TestWithDryRunWarnings(t *testing.T) {
+	create := func(ctx context.Context, _ *schema.ResourceData, m interface{}) diag.Diagnostics {
+		sess := Meta(m).Session()
+		for _, path := range []string{"/papi/v1/properties", "/papi/v1/properties/prp_1/activations"} {
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://host"+path, nil)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if _, err := sess.Exec(req, nil); err != nil && !errors.Is(err, ErrDryRun) {
+				return diag.FromErr(err)
+			}
+		}
+		return nil
+	}
+
+	tests := map[string]struct {
+		dryRun           bool
+		expectedWarnings []string
+	}{
+		"dry run": {
+			dryRun: true,
+			expectedWarnings: []string{
+				"The provider runs in dry run mode, this call would have been made: POST /papi/v1/properties",
+				"The provider runs in dry run mode, this call would have been made: POST /papi/v1/properties/prp_1/activations",
+			},
+		},
+		"disabled": {
+			dryRun: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sess := &mockSession{responses: []int{201, 201}}
+			m := &meta{sess: newDryRunSession(sess, test.dryRun), dryRun: test.dryRun}
+			diags := withDryRunWarnings(create)(context.Background(), nil, m)
+
+			var warnings []string
+			for _, d := range diags {
+				assert.Equal(t, diag.Warning, d.Severity)
+				warnings = append(warnings, d.Detail)
+			}
+			assert.Equal(t, test.expectedWarnings, warnings)
+		})
+	}
+}
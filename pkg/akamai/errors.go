@@ -15,19 +15,49 @@ type (
 )
 
 var (
+	// ErrDryRun is returned for the requests blocked in dry run mode
+	ErrDryRun = &Error{"dry run: request blocked", false}
+
+	// ErrDefaultNotSet is returned when a required value is set neither in the resource nor in the provider defaults block
+	ErrDefaultNotSet = &Error{"value must be set in the resource or in the provider defaults block", false}
+
 	// ErrDuplicateSchemaKey is returned when a duplicate schema key is detected during merge
 	ErrDuplicateSchemaKey = &Error{"duplicate schema key", false}
 
+	// ErrAuditLog is returned when the audit log cannot be written
+	ErrAuditLog = &Error{"cannot write audit log", false}
+
 	// ErrCacheEntryNotFound returns a cache entry error
 	ErrCacheEntryNotFound = &Error{"cache entry not found", true}
 
 	// ErrCacheDisabled is returned when the cache is disabled
 	ErrCacheDisabled = &Error{"cache is disabled", false}
 
+	// ErrCacheDirectory is returned when the file cache directory cannot be created
+	ErrCacheDirectory = &Error{"cannot create cache directory", false}
+
+	// ErrCacheType is returned when an unsupported cache type is configured
+	ErrCacheType = &Error{"unsupported cache type", false}
+
+	// ErrConfigurationNotSpecified is returned when no EdgeGrid credentials are found
+	ErrConfigurationNotSpecified = &Error{ConfigurationIsNotSpecified, false}
+
+	// ErrCredentialSource is returned when the credentials cannot be read from the configured credential source
+	ErrCredentialSource = &Error{"cannot read credentials from credential source", false}
+
+	// ErrProfileName is returned when a provider profile has an invalid name
+	ErrProfileName = &Error{"invalid profile name", false}
+
+	// ErrProfileNotFound is returned when a resource selects a profile which is not defined on the provider
+	ErrProfileNotFound = &Error{"profile not found", true}
+
 	// ErrProviderNotLoaded returned and panic'd when a requested provider is not loaded
 	// Users should never see this, unit tests and sanity checks should pick this up
 	ErrProviderNotLoaded = &Error{"provider not loaded", false}
 
+	// ErrTracing is returned when the trace export cannot be configured
+	ErrTracing = &Error{"cannot configure tracing", false}
+
 	// NoticeDeprecatedUseAlias is returned for schema configurations that are deprecated
 	// Terraform now supports section aliases
 	// TODO: Add alias example to the examples directory
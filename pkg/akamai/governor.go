@@ -0,0 +1,282 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/apex/log"
+)
+
+const (
+	governorBaseBackoff = time.Second
+	governorMaxBackoff  = 30 * time.Second
+)
+
+type (
+	// governorConfig holds the request governor settings
+	governorConfig struct {
+		// RequestsPerSecond is the sustained request rate allowed per API family, 0 means unlimited
+		RequestsPerSecond float64
+		// Burst is the number of requests per API family which can be sent at once
+		Burst int
+		// MaxRetries is the number of times a throttled or failed request is retried
+		MaxRetries int
+	}
+
+	// governedSession wraps a session, limiting the request rate per API family
+	// and retrying requests rejected with 429 or failed with 5xx responses
+	governedSession struct {
+		session.Session
+		config governorConfig
+
+		mu       sync.Mutex
+		families map[string]*apiFamily
+
+		// sleep is replaced in tests
+		sleep func(ctx context.Context, d time.Duration) error
+	}
+
+	apiFamily struct {
+		bucket *tokenBucket
+
+		mu        sync.Mutex
+		requests  int
+		throttled int
+		retries   int
+		waited    time.Duration
+	}
+
+	tokenBucket struct {
+		mu        sync.Mutex
+		rate      float64
+		burst     float64
+		tokens    float64
+		last      time.Time
+		notBefore time.Time
+	}
+)
+
+// newGovernedSession wraps the session with the request governor
+//
+// if neither rate limiting nor retries are configured, the session is returned unchanged
+func newGovernedSession(sess session.Session, config governorConfig) session.Session {
+	if config.RequestsPerSecond <= 0 && config.MaxRetries <= 0 {
+		return sess
+	}
+	if config.Burst < 1 {
+		config.Burst = 1
+	}
+	return &governedSession{
+		Session:  sess,
+		config:   config,
+		families: make(map[string]*apiFamily),
+		sleep:    sleepContext,
+	}
+}
+
+// Exec waits for the API family rate limit, then executes the request retrying on 429 and 5xx responses
+func (s *governedSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	ctx := r.Context()
+	name := apiFamilyName(r)
+	family := s.family(name)
+	logger := s.Log(ctx).WithField("apiFamily", name)
+
+	for attempt := 0; ; attempt++ {
+		if wait := family.bucket.reserve(time.Now()); wait > 0 {
+			family.record(func(f *apiFamily) {
+				f.throttled++
+				f.waited += wait
+			})
+			logger.WithFields(family.fields()).Debugf("request governor: throttling request for %s", wait)
+			if err := s.sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+		}
+		family.record(func(f *apiFamily) { f.requests++ })
+
+		resp, err := s.Session.Exec(r, out, in...)
+		if err != nil || !s.shouldRetry(r, resp, attempt, len(in) > 0) {
+			return resp, err
+		}
+
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+		if r.GetBody != nil && len(in) == 0 {
+			body, err := r.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = body
+		}
+
+		family.record(func(f *apiFamily) { f.retries++ })
+		retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if ok {
+			// the API asks to hold off the whole family, the next reserve waits for it
+			family.bucket.pause(time.Now().Add(retryAfter))
+			logger.WithFields(family.fields()).Debugf("request governor: retrying %s %s after %d response, Retry-After %s (attempt %d of %d)",
+				r.Method, r.URL.Path, resp.StatusCode, retryAfter, attempt+1, s.config.MaxRetries)
+			continue
+		}
+
+		delay := backoff(attempt)
+		family.record(func(f *apiFamily) { f.waited += delay })
+		logger.WithFields(family.fields()).Debugf("request governor: retrying %s %s after %d response in %s (attempt %d of %d)",
+			r.Method, r.URL.Path, resp.StatusCode, delay, attempt+1, s.config.MaxRetries)
+		if err := s.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s *governedSession) shouldRetry(r *http.Request, resp *http.Response, attempt int, hasInput bool) bool {
+	if attempt >= s.config.MaxRetries {
+		return false
+	}
+	// a request body which cannot be recreated cannot be sent again
+	if r.Body != nil && r.GetBody == nil && !hasInput {
+		return false
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		// the API may have already applied a POST or PATCH, which is not safe to repeat
+		return r.Method != http.MethodPost && r.Method != http.MethodPatch
+	}
+	return false
+}
+
+func (s *governedSession) family(name string) *apiFamily {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.families[name]
+	if !ok {
+		f = &apiFamily{bucket: newTokenBucket(s.config.RequestsPerSecond, s.config.Burst)}
+		s.families[name] = f
+	}
+	return f
+}
+
+func (f *apiFamily) record(fn func(*apiFamily)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fn(f)
+}
+
+func (f *apiFamily) fields() log.Fields {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return log.Fields{
+		"requests":  f.requests,
+		"throttled": f.throttled,
+		"retries":   f.retries,
+		"waited":    f.waited.String(),
+	}
+}
+
+// apiFamilyName returns the API family of the request, which is the first segment of its path, e.g. papi or config-dns
+func apiFamilyName(r *http.Request) string {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if i := strings.Index(path, "/"); i >= 0 {
+		path = path[:i]
+	}
+	if path == "" {
+		return "default"
+	}
+	return path
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller has to wait before using it
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var wait time.Duration
+	if b.rate > 0 {
+		if elapsed := now.Sub(b.last); elapsed > 0 {
+			b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+			b.last = now
+		}
+		b.tokens--
+		if b.tokens < 0 {
+			wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+		}
+	}
+	if pause := b.notBefore.Sub(now); pause > wait {
+		wait = pause
+	}
+	return wait
+}
+
+// pause holds off all requests of the bucket until the given time
+func (b *tokenBucket) pause(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if until.After(b.notBefore) {
+		b.notBefore = until
+	}
+}
+
+// backoff returns the jittered exponential delay before the given retry attempt
+func backoff(attempt int) time.Duration {
+	d := governorBaseBackoff << uint(attempt)
+	if d <= 0 || d > governorMaxBackoff {
+		d = governorMaxBackoff
+	}
+	half := int64(d / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// parseRetryAfter parses the Retry-After header, given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		d := date.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("request governor: %w", ctx.Err())
+	case <-t.C:
+		return nil
+	}
+}
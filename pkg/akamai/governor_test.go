@@ -0,0 +1,166 @@
+package akamai
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/apex/log"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+type mockSession struct {
+	session.Session
+	responses []int
+	headers   []http.Header
+	calls     int
+}
+
+func (s *mockSession) Exec(_ *http.Request, _ interface{}, _ ...interface{}) (*http.Response, error) {
+	status := s.responses[s.calls]
+	header := http.Header{}
+	if s.calls < len(s.headers) && s.headers[s.calls] != nil {
+		header = s.headers[s.calls]
+	}
+	s.calls++
+	return &http.Response{StatusCode: status, Header: header}, nil
+}
+
+func (s *mockSession) Log(_ context.Context) log.Interface {
+	return log.Log
+}
+
+func newTestGovernedSession(sess session.Session, config governorConfig) (*governedSession, *[]time.Duration) {
+	var sleeps []time.Duration
+	gs := newGovernedSession(sess, config).(*governedSession)
+	gs.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	return gs, &sleeps
+}
+
+func TestGovernedSession_disabled(t *testing.T) {
+	sess := &mockSession{}
+	assert.Equal(t, session.Session(sess), newGovernedSession(sess, governorConfig{}))
+}
+
+func TestGovernedSession_rateLimit(t *testing.T) {
+	sess := &mockSession{responses: []int{200, 200, 200}}
+	gs, sleeps := newTestGovernedSession(sess, governorConfig{RequestsPerSecond: 1, Burst: 1})
+
+	for _, url := range []string{"https://host/papi/v1/groups", "https://host/papi/v1/contracts", "https://host/appsec/v1/configs"} {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		resp, err := gs.Exec(req, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+	}
+
+	// only the second papi request is throttled, appsec has its own bucket
+	require.Len(t, *sleeps, 1)
+	assert.InDelta(t, time.Second, (*sleeps)[0], float64(50*time.Millisecond))
+	assert.Equal(t, 3, sess.calls)
+}
+
+func TestGovernedSession_retry(t *testing.T) {
+	tests := map[string]struct {
+		method         string
+		responses      []int
+		headers        []http.Header
+		maxRetries     int
+		expectedStatus int
+		expectedCalls  int
+		expectedSleeps []time.Duration
+	}{
+		"429 honors Retry-After": {
+			method:         http.MethodPost,
+			responses:      []int{429, 201},
+			headers:        []http.Header{{"Retry-After": []string{"3"}}},
+			maxRetries:     2,
+			expectedStatus: 201,
+			expectedCalls:  2,
+			expectedSleeps: []time.Duration{3 * time.Second},
+		},
+		"5xx is retried for GET": {
+			method:         http.MethodGet,
+			responses:      []int{503, 502, 200},
+			maxRetries:     3,
+			expectedStatus: 200,
+			expectedCalls:  3,
+		},
+		"5xx is not retried for POST": {
+			method:         http.MethodPost,
+			responses:      []int{500},
+			maxRetries:     3,
+			expectedStatus: 500,
+			expectedCalls:  1,
+		},
+		"4xx is not retried": {
+			method:         http.MethodGet,
+			responses:      []int{404},
+			maxRetries:     3,
+			expectedStatus: 404,
+			expectedCalls:  1,
+		},
+		"retries are exhausted": {
+			method:         http.MethodDelete,
+			responses:      []int{429, 429, 429},
+			maxRetries:     2,
+			expectedStatus: 429,
+			expectedCalls:  3,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sess := &mockSession{responses: test.responses, headers: test.headers}
+			gs, sleeps := newTestGovernedSession(sess, governorConfig{MaxRetries: test.maxRetries})
+
+			req, err := http.NewRequest(test.method, "https://host/config-dns/v2/zones", bytes.NewBufferString(`{}`))
+			require.NoError(t, err)
+			resp, err := gs.Exec(req, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+			assert.Equal(t, test.expectedCalls, sess.calls)
+			assert.Len(t, *sleeps, test.expectedCalls-1)
+			for i, expected := range test.expectedSleeps {
+				assert.InDelta(t, expected, (*sleeps)[i], float64(50*time.Millisecond))
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		"empty":        {value: "", ok: false},
+		"seconds":      {value: "120", expected: 2 * time.Minute, ok: true},
+		"http date":    {value: "Thu, 01 Dec 2022 10:00:30 GMT", expected: 30 * time.Second, ok: true},
+		"date in past": {value: "Thu, 01 Dec 2022 09:00:00 GMT", expected: 0, ok: true},
+		"invalid":      {value: "soon", ok: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, ok := parseRetryAfter(test.value, now)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, d)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		d := backoff(attempt)
+		assert.True(t, d >= governorBaseBackoff/2)
+		assert.True(t, d <= governorMaxBackoff)
+	}
+}
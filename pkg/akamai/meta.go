@@ -2,12 +2,13 @@ package akamai
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
-	"github.com/allegro/bigcache/v2"
 	"github.com/apex/log"
 	"github.com/hashicorp/go-hclog"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 type (
@@ -28,6 +29,18 @@ type (
 
 		// CacheSet sets a value in the cache
 		CacheSet(prov Subprovider, key string, val interface{}) error
+
+		// CacheInvalidate removes all cached values of the subprovider
+		CacheInvalidate(prov Subprovider) error
+
+		// Profile returns the name of the credential profile, empty for the provider level credentials
+		Profile() string
+
+		// DryRun returns true if the provider refuses all requests which could modify anything
+		DryRun() bool
+
+		// RequireComplianceRecord returns true if the activations on the production network must set a compliance record
+		RequireComplianceRecord() bool
 	}
 
 	meta struct {
@@ -35,6 +48,17 @@ type (
 		log          hclog.Logger
 		sess         session.Session
 		cacheEnabled bool
+		cache        cacheStore
+		profile      string
+		profiles     map[string]session.Session
+		account      string
+		accounts     map[string]string
+		dryRun       bool
+		defaults     defaults
+
+		requireComplianceRecord bool
+
+		tracerProvider *sdktrace.TracerProvider
 	}
 )
 
@@ -58,6 +82,46 @@ func (m *meta) Session() session.Session {
 	return m.sess
 }
 
+// Profile returns the meta credential profile
+func (m *meta) Profile() string {
+	return m.profile
+}
+
+// DryRun returns true if the meta session is in dry run mode
+func (m *meta) DryRun() bool {
+	return m.dryRun
+}
+
+// RequireComplianceRecord returns true if the production activations of the meta account need a compliance record
+func (m *meta) RequireComplianceRecord() bool {
+	return m.requireComplianceRecord
+}
+
+// withProfile returns a copy of the meta using the session of the named profile
+func (m *meta) withProfile(name string) (*meta, error) {
+	sess, ok := m.profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+
+	profileMeta := *m
+	profileMeta.log = m.log.With("profile", name)
+	profileMeta.sess = sess
+	profileMeta.profile = name
+	profileMeta.account = m.accounts[name]
+
+	return &profileMeta, nil
+}
+
+// cacheKey suffixes the key with the hash of the credentials, so that configurations using
+// different credentials or account switch keys never share cached responses
+func (m *meta) cacheKey(key string) string {
+	if m.account == "" {
+		return key
+	}
+	return fmt.Sprintf("%s@%s", key, m.account)
+}
+
 func (m *meta) CacheSet(prov Subprovider, key string, val interface{}) error {
 	log := m.Log("meta", "CacheSet")
 
@@ -66,16 +130,16 @@ func (m *meta) CacheSet(prov Subprovider, key string, val interface{}) error {
 		return ErrCacheDisabled
 	}
 
-	key = fmt.Sprintf("%s:%s", key, prov.Name())
+	key = m.cacheKey(key)
 
 	data, err := json.Marshal(val)
 	if err != nil {
 		return fmt.Errorf("failed to marshal object to cache: %w", err)
 	}
 
-	log.Debugf("cache set for for key %s [%d bytes]", key, len(data))
+	log.Debugf("cache set for for key %s:%s [%d bytes]", key, prov.Name(), len(data))
 
-	return instance.cache.Set(key, data)
+	return m.cacheStore().Set(prov.Name(), key, data)
 }
 
 func (m *meta) CacheGet(prov Subprovider, key string, out interface{}) error {
@@ -86,19 +150,38 @@ func (m *meta) CacheGet(prov Subprovider, key string, out interface{}) error {
 		return ErrCacheDisabled
 	}
 
-	key = fmt.Sprintf("%s:%s", key, prov.Name())
+	key = m.cacheKey(key)
 
-	data, err := instance.cache.Get(key)
+	data, err := m.cacheStore().Get(prov.Name(), key)
 	if err != nil {
-		if err == bigcache.ErrEntryNotFound {
-			log.Debugf("cache miss for for key %s", key)
-
-			return ErrCacheEntryNotFound
+		if errors.Is(err, ErrCacheEntryNotFound) {
+			log.Debugf("cache miss for for key %s:%s", key, prov.Name())
 		}
 		return err
 	}
 
-	log.Debugf("cache get for for key %s: [%d bytes]", key, len(data))
+	log.Debugf("cache get for for key %s:%s: [%d bytes]", key, prov.Name(), len(data))
 
 	return json.Unmarshal(data, out)
 }
+
+func (m *meta) CacheInvalidate(prov Subprovider) error {
+	log := m.Log("meta", "CacheInvalidate")
+
+	if !m.cacheEnabled {
+		log.Debug("cache disabled")
+		return ErrCacheDisabled
+	}
+
+	log.Debugf("cache invalidate for subprovider %s", prov.Name())
+
+	return m.cacheStore().Invalidate(prov.Name())
+}
+
+// cacheStore returns the configured cache backend, falling back to the in-memory cache
+func (m *meta) cacheStore() cacheStore {
+	if m.cache != nil {
+		return m.cache
+	}
+	return instance.cache
+}
@@ -0,0 +1,392 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/config"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+)
+
+const (
+	// ProfileKey is the name of the attribute selecting the credential profile of a resource or data source
+	ProfileKey = "profile"
+
+	// ImportProfileEnv is the environment variable selecting the credential profile of imported resources,
+	// as the configuration of a resource is not available when it is imported
+	ImportProfileEnv = "AKAMAI_IMPORT_PROFILE"
+)
+
+type (
+	// profileFetcher is implemented by both schema.ResourceData and schema.ResourceDiff
+	profileFetcher interface {
+		Get(string) interface{}
+	}
+
+	// mapFetcher allows reading a nested block with the same functions as schema.ResourceData
+	mapFetcher map[string]interface{}
+)
+
+// profileSchema returns the schema of the provider profile blocks
+func profileSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Named EdgeGrid credentials, which resources and data sources can select with their profile attribute",
+		Optional:    true,
+		Type:        schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The name of the profile",
+					Required:    true,
+					Type:        schema.TypeString,
+				},
+				"edgerc": {
+					Description: "The location of the edgerc file containing credentials",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+				"config_section": {
+					Description: "The section of the edgerc file to use for configuration",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+				"config": {
+					Optional: true,
+					Type:     schema.TypeSet,
+					Elem:     config.Options("config"),
+					MaxItems: 1,
+				},
+				"credential_source": credentialSourceSchema(),
+				"account_key": {
+					Description: "The account switch key to use with the profile credentials",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// GetOk implements tools.ResourceDataFetcher
+func (m mapFetcher) GetOk(key string) (interface{}, bool) {
+	v, ok := m[key]
+	if !ok || v == nil {
+		return nil, false
+	}
+	switch val := v.(type) {
+	case string:
+		return val, val != ""
+	case *schema.Set:
+		return val, val.Len() > 0
+	case []interface{}:
+		return val, len(val) > 0
+	}
+	return v, true
+}
+
+// getEdgegridConfig resolves the EdgeGrid credentials from the credential source, the inline config block,
+// the AKAMAI_* environment variables or the edgerc file, without modifying the process environment
+func getEdgegridConfig(ctx context.Context, rd tools.ResourceDataFetcher) (*edgegrid.Config, error) {
+	source, err := getCredentialSource(rd)
+	if err != nil {
+		return nil, err
+	}
+	if source != nil {
+		if _, ok := rd.GetOk("config"); ok {
+			return nil, fmt.Errorf("%w: config and credential_source cannot be used together", ErrCredentialSource)
+		}
+		edgerc, err := source.Credentials(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := edgerc.Validate(); err != nil {
+			return nil, err
+		}
+		return edgerc, nil
+	}
+
+	edgercPath, err := tools.GetStringValue("edgerc", rd)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, err
+	}
+	edgercPath = getEdgercPath(edgercPath)
+
+	edgercOps := []edgegrid.Option{edgegrid.WithEnv(true), edgegrid.WithFile(edgercPath)}
+	edgercSection, err := tools.GetStringValue("config_section", rd)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		edgercOps = append(edgercOps, edgegrid.WithSection(edgercSection))
+	}
+
+	var edgerc *edgegrid.Config
+	envs, err := tools.GetSetValue("config", rd)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, err
+	}
+	if err == nil && len(envs.List()) > 0 {
+		envsMap, ok := envs.List()[0].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "config", "map[string]interface{}")
+		}
+		if edgerc, err = newEdgegridConfig(envsMap, edgercSection); err != nil {
+			return nil, err
+		}
+	} else {
+		if edgerc, err = edgegrid.New(edgercOps...); err != nil {
+			return nil, ErrConfigurationNotSpecified
+		}
+	}
+
+	if err := edgerc.Validate(); err != nil {
+		return nil, err
+	}
+
+	return edgerc, nil
+}
+
+// newEdgegridConfig creates the EdgeGrid configuration from the inline config block
+//
+// values set in the AKAMAI_{SECTION}_* environment variables take precedence over the inline values
+func newEdgegridConfig(envsMap map[string]interface{}, section string) (*edgegrid.Config, error) {
+	configEnvs := []string{"ACCESS_TOKEN", "CLIENT_TOKEN", "HOST", "CLIENT_SECRET", "MAX_BODY", "ACCOUNT_KEY"}
+	prefix := "AKAMAI"
+	if section != "" && section != edgegrid.DefaultSection {
+		prefix = fmt.Sprintf("%s_%s", prefix, strings.ToUpper(section))
+	}
+
+	edgerc := &edgegrid.Config{}
+	for _, env := range configEnvs {
+		var value string
+		var ok bool
+		switch env {
+		case "ACCESS_TOKEN":
+			value, ok = envsMap["access_token"].(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "access_token", "string")
+			}
+		case "CLIENT_TOKEN":
+			value, ok = envsMap["client_token"].(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "client_token", "string")
+			}
+		case "HOST":
+			value, ok = envsMap["host"].(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "host", "string")
+			}
+		case "CLIENT_SECRET":
+			value, ok = envsMap["client_secret"].(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "client_secret", "string")
+			}
+		case "MAX_BODY":
+			maxBody, ok := envsMap["max_body"].(int)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "max_body", "int")
+			}
+			value = strconv.Itoa(maxBody)
+		case "ACCOUNT_KEY":
+			// account_key is optional and may be missing in older configurations
+			value, _ = envsMap["account_key"].(string)
+		}
+		if v := os.Getenv(fmt.Sprintf("%s_%s", prefix, env)); v != "" {
+			value = v
+		}
+
+		switch env {
+		case "ACCESS_TOKEN":
+			edgerc.AccessToken = value
+		case "CLIENT_TOKEN":
+			edgerc.ClientToken = value
+		case "HOST":
+			edgerc.Host = value
+		case "CLIENT_SECRET":
+			edgerc.ClientSecret = value
+		case "MAX_BODY":
+			maxBody, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "max_body", "int")
+			}
+			edgerc.MaxBody = maxBody
+		case "ACCOUNT_KEY":
+			edgerc.AccountKey = value
+		}
+	}
+	if edgerc.MaxBody <= 0 {
+		edgerc.MaxBody = edgegrid.MaxBodySize
+	}
+
+	return edgerc, nil
+}
+
+// getProfileConfigs resolves the EdgeGrid credentials of every provider profile
+func getProfileConfigs(ctx context.Context, d tools.ResourceDataFetcher) (map[string]*edgegrid.Config, error) {
+	profiles, err := tools.GetInterfaceArrayValue(ProfileKey, d)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	configs := make(map[string]*edgegrid.Config, len(profiles))
+	for _, p := range profiles {
+		profileMap, ok := p.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, ProfileKey, "map[string]interface{}")
+		}
+		name, ok := profileMap["name"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: %s", ErrProfileName, "name cannot be empty")
+		}
+		if _, ok := configs[name]; ok {
+			return nil, fmt.Errorf("%w: %q is defined more than once", ErrProfileName, name)
+		}
+
+		edgerc, err := getEdgegridConfig(ctx, inheritProviderCredentials(mapFetcher(profileMap), d))
+		if err != nil {
+			return nil, fmt.Errorf("profile %q: %w", name, err)
+		}
+		if accountKey, ok := profileMap["account_key"].(string); ok && accountKey != "" {
+			edgerc.AccountKey = accountKey
+		}
+		configs[name] = edgerc
+	}
+
+	return configs, nil
+}
+
+// inheritProviderCredentials fills the credential settings missing in the profile with the provider level ones,
+// so that a profile may only set a different account_key or config_section
+func inheritProviderCredentials(profile mapFetcher, d tools.ResourceDataFetcher) mapFetcher {
+	merged := make(mapFetcher, len(profile))
+	for k, v := range profile {
+		merged[k] = v
+	}
+	_, hasConfig := profile.GetOk("config")
+	_, hasSource := profile.GetOk("credential_source")
+	if hasConfig || hasSource {
+		return merged
+	}
+	_, hasEdgerc := profile.GetOk("edgerc")
+	_, hasSection := profile.GetOk("config_section")
+	keys := []string{"edgerc", "config_section"}
+	if !hasEdgerc && !hasSection {
+		keys = append(keys, "config", "credential_source")
+	}
+	for _, k := range keys {
+		if _, ok := merged.GetOk(k); ok {
+			continue
+		}
+		if v, ok := d.GetOk(k); ok {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// addProfileSupport adds the optional profile attribute to the resource schema and wraps
+// its functions, so that akamai.Meta returns the meta of the selected profile
+func addProfileSupport(r *schema.Resource, isDataSource bool) {
+	if r.Schema == nil {
+		return
+	}
+	if _, ok := r.Schema[ProfileKey]; ok {
+		return
+	}
+	r.Schema[ProfileKey] = &schema.Schema{
+		Description: "The name of the provider profile whose credentials are used for this resource",
+		Optional:    true,
+		Type:        schema.TypeString,
+		// resources which cannot be updated must be recreated with the new credentials
+		ForceNew: !isDataSource && r.UpdateContext == nil && r.Update == nil,
+	}
+
+	r.CreateContext = withProfileMeta(r.CreateContext)
+	r.ReadContext = withProfileMeta(r.ReadContext)
+	r.UpdateContext = withProfileMeta(r.UpdateContext)
+	r.DeleteContext = withProfileMeta(r.DeleteContext)
+
+	if r.Importer != nil {
+		r.Importer.StateContext = withImportProfileMeta(r.Importer.StateContext)
+	}
+
+	if customizeDiff := r.CustomizeDiff; customizeDiff != nil {
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			m, err := metaForProfile(m, d)
+			if err != nil {
+				return err
+			}
+			return customizeDiff(ctx, d, m)
+		}
+	}
+}
+
+func withProfileMeta(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		m, err := metaForProfile(m, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, m)
+	}
+}
+
+// withImportProfileMeta selects the profile of the imported resource with ImportProfileEnv and keeps it in the state,
+// so that the resource is read with the same credentials after the import
+func withImportProfileMeta(f schema.StateContextFunc) schema.StateContextFunc {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if name := os.Getenv(ImportProfileEnv); name != "" && d.Get(ProfileKey).(string) == "" {
+			if err := d.Set(ProfileKey, name); err != nil {
+				return nil, err
+			}
+		}
+		m, err := metaForProfile(m, d)
+		if err != nil {
+			return nil, err
+		}
+		imported, err := f(ctx, d, m)
+		if err != nil {
+			return nil, err
+		}
+		name := d.Get(ProfileKey).(string)
+		if name == "" {
+			return imported, nil
+		}
+		for _, rd := range imported {
+			if err := rd.Set(ProfileKey, name); err != nil {
+				return nil, err
+			}
+		}
+		return imported, nil
+	}
+}
+
+// metaForProfile returns the meta of the profile selected by the resource, or the given meta if none is selected
+func metaForProfile(m interface{}, d profileFetcher) (interface{}, error) {
+	mt, ok := m.(*meta)
+	if !ok {
+		return m, nil
+	}
+	name, _ := d.Get(ProfileKey).(string)
+	if name == "" {
+		return m, nil
+	}
+	return mt.withProfile(name)
+}
@@ -0,0 +1,253 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestGetProfileConfigs(t *testing.T) {
+	tests := map[string]struct {
+		profiles       []interface{}
+		expectedHosts  map[string]string
+		expectedKeys   map[string]string
+		expectedErrors error
+	}{
+		"profile inherits provider credentials": {
+			profiles: []interface{}{
+				map[string]interface{}{
+					"name":        "child",
+					"account_key": "1-ABCDE",
+				},
+			},
+			expectedHosts: map[string]string{"child": "akaa-parent-xxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net"},
+			expectedKeys:  map[string]string{"child": "1-ABCDE"},
+		},
+		"profile with own section": {
+			profiles: []interface{}{
+				map[string]interface{}{
+					"name":           "other",
+					"config_section": "profile_other",
+				},
+			},
+			expectedHosts: map[string]string{"other": "akaa-other-xxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net"},
+			expectedKeys:  map[string]string{"other": ""},
+		},
+		"profile with credential source": {
+			profiles: []interface{}{
+				map[string]interface{}{
+					"name": "command",
+					"credential_source": []interface{}{map[string]interface{}{
+						"command":         []interface{}{"sh", "-c", `echo '{"host":"akaa-cmd.luna.akamaiapis.net","client_token":"ct","client_secret":"cs","access_token":"at"}'`},
+						"command_timeout": "30s",
+					}},
+					"account_key": "1-FGHIJ",
+				},
+			},
+			expectedHosts: map[string]string{"command": "akaa-cmd.luna.akamaiapis.net"},
+			expectedKeys:  map[string]string{"command": "1-FGHIJ"},
+		},
+		"duplicated profile name": {
+			profiles: []interface{}{
+				map[string]interface{}{"name": "child"},
+				map[string]interface{}{"name": "child"},
+			},
+			expectedErrors: ErrProfileName,
+		},
+		"missing section": {
+			profiles: []interface{}{
+				map[string]interface{}{
+					"name":           "missing",
+					"config_section": "does_not_exist",
+				},
+			},
+			expectedErrors: ErrConfigurationNotSpecified,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			existingEnvs := unsetEnvs(t)
+			defer restoreEnvs(t, existingEnvs)
+
+			d := schema.TestResourceDataRaw(t, instance.Schema, map[string]interface{}{
+				"edgerc":         "testdata/edgerc",
+				"config_section": "profile_parent",
+				"profile":        test.profiles,
+			})
+
+			configs, err := getProfileConfigs(context.Background(), d)
+			if test.expectedErrors != nil {
+				assert.True(t, errors.Is(err, test.expectedErrors), err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, configs, len(test.expectedHosts))
+			for name, host := range test.expectedHosts {
+				assert.Equal(t, host, configs[name].Host)
+				assert.Equal(t, test.expectedKeys[name], configs[name].AccountKey)
+			}
+		})
+	}
+}
+
+func TestMetaForProfile(t *testing.T) {
+	existingEnvs := unsetEnvs(t)
+	defer restoreEnvs(t, existingEnvs)
+
+	d := schema.TestResourceDataRaw(t, instance.Schema, map[string]interface{}{
+		"edgerc":         "testdata/edgerc",
+		"config_section": "profile_parent",
+		"profile": []interface{}{
+			map[string]interface{}{
+				"name":           "other",
+				"config_section": "profile_other",
+			},
+		},
+	})
+	m, diags := configureContext(context.Background(), d)
+	require.Nil(t, diags)
+	defaultMeta := m.(*meta)
+
+	t.Run("resource without profile uses provider credentials", func(t *testing.T) {
+		rd := schema.TestResourceDataRaw(t, testResource().Schema, map[string]interface{}{})
+		got, err := metaForProfile(defaultMeta, rd)
+		require.NoError(t, err)
+		assert.Equal(t, "", Meta(got).Profile())
+		assert.Equal(t, defaultMeta.sess, Meta(got).Session())
+	})
+
+	t.Run("resource with profile uses profile credentials", func(t *testing.T) {
+		r := testResource()
+		addProfileSupport(r, false)
+		rd := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"profile": "other"})
+		got, err := metaForProfile(defaultMeta, rd)
+		require.NoError(t, err)
+		assert.Equal(t, "other", Meta(got).Profile())
+		assert.Equal(t, defaultMeta.profiles["other"], Meta(got).Session())
+		assert.NotEqual(t, defaultMeta.sess, Meta(got).Session())
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		r := testResource()
+		addProfileSupport(r, false)
+		rd := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"profile": "unknown"})
+		_, err := metaForProfile(defaultMeta, rd)
+		assert.True(t, errors.Is(err, ErrProfileNotFound))
+	})
+
+	t.Run("wrapped functions receive profile meta", func(t *testing.T) {
+		var profile string
+		r := &schema.Resource{
+			ReadContext: func(_ context.Context, _ *schema.ResourceData, m interface{}) diag.Diagnostics {
+				profile = Meta(m).Profile()
+				return nil
+			},
+			Schema: map[string]*schema.Schema{},
+		}
+		addProfileSupport(r, true)
+		rd := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"profile": "other"})
+		diags := r.ReadContext(context.Background(), rd, defaultMeta)
+		assert.Nil(t, diags)
+		assert.Equal(t, "other", profile)
+	})
+
+	t.Run("importer receives profile meta selected by environment", func(t *testing.T) {
+		var profile string
+		r := &schema.Resource{
+			ReadContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+				return nil
+			},
+			Importer: &schema.ResourceImporter{
+				StateContext: func(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+					profile = Meta(m).Profile()
+					return []*schema.ResourceData{d}, nil
+				},
+			},
+			Schema: map[string]*schema.Schema{},
+		}
+		addProfileSupport(r, false)
+		require.NoError(t, os.Setenv(ImportProfileEnv, "other"))
+		defer func() {
+			require.NoError(t, os.Unsetenv(ImportProfileEnv))
+		}()
+		rd := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+		imported, err := r.Importer.StateContext(context.Background(), rd, defaultMeta)
+		require.NoError(t, err)
+		assert.Equal(t, "other", profile)
+		require.Len(t, imported, 1)
+		assert.Equal(t, "other", imported[0].Get(ProfileKey))
+	})
+}
+
+func TestCacheKey(t *testing.T) {
+	existingEnvs := unsetEnvs(t)
+	defer restoreEnvs(t, existingEnvs)
+
+	configure := func(attrs map[string]interface{}) *meta {
+		d := schema.TestResourceDataRaw(t, instance.Schema, attrs)
+		m, diags := configureContext(context.Background(), d)
+		require.Nil(t, diags)
+		return m.(*meta)
+	}
+	parent := configure(map[string]interface{}{
+		"edgerc":         "testdata/edgerc",
+		"config_section": "profile_parent",
+		"profile": []interface{}{
+			map[string]interface{}{
+				"name":           "child",
+				"config_section": "profile_parent",
+				"account_key":    "1-ABCDE",
+			},
+		},
+	})
+	other := configure(map[string]interface{}{
+		"edgerc":         "testdata/edgerc",
+		"config_section": "profile_other",
+	})
+	child, err := parent.withProfile("child")
+	require.NoError(t, err)
+
+	t.Run("credentials without profile", func(t *testing.T) {
+		assert.NotEqual(t, parent.cacheKey("groups"), other.cacheKey("groups"))
+
+		prov := &cacheSubprovider{}
+		require.NoError(t, parent.CacheSet(prov, "groups", "parent groups"))
+		var got string
+		assert.True(t, errors.Is(other.CacheGet(prov, "groups", &got), ErrCacheEntryNotFound))
+		require.NoError(t, parent.CacheGet(prov, "groups", &got))
+		assert.Equal(t, "parent groups", got)
+	})
+
+	t.Run("same credentials with account switch key", func(t *testing.T) {
+		assert.NotEqual(t, parent.cacheKey("groups"), child.cacheKey("groups"))
+	})
+
+	t.Run("same credentials in another configuration", func(t *testing.T) {
+		again := configure(map[string]interface{}{
+			"edgerc":         "testdata/edgerc",
+			"config_section": "profile_parent",
+		})
+		assert.Equal(t, parent.cacheKey("groups"), again.cacheKey("groups"))
+	})
+}
+
+func TestNewEdgegridConfigDefaultSection(t *testing.T) {
+	existingEnvs := unsetEnvs(t)
+	defer restoreEnvs(t, existingEnvs)
+
+	edgerc, err := getEdgegridConfig(context.Background(), mapFetcher{
+		"edgerc":         "testdata/edgerc",
+		"config_section": "profile_other",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, edgegrid.MaxBodySize, edgerc.MaxBody)
+	assert.Equal(t, "akaa-other-access-token-xxxxxxxxxxxxxxxx", edgerc.AccessToken)
+}
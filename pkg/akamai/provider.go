@@ -5,8 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -16,8 +14,10 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/spf13/cast"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
@@ -63,7 +63,7 @@ type (
 	provider struct {
 		schema.Provider
 		subs  map[string]Subprovider
-		cache *bigcache.BigCache
+		cache *memoryCache
 	}
 )
 
@@ -95,11 +95,84 @@ func Provider(provs ...Subprovider) plugin.ProviderFunc {
 						Elem:     config.Options("config"),
 						MaxItems: 1,
 					},
+					"credential_source": credentialSourceSchema(),
+					"profile":           profileSchema(),
+					"defaults":          defaultsSchema(),
 					"cache_enabled": {
 						Optional: true,
 						Default:  true,
 						Type:     schema.TypeBool,
 					},
+					"cache_type": {
+						Description:      "The cache backend to use, either memory or file",
+						Optional:         true,
+						Type:             schema.TypeString,
+						DefaultFunc:      schema.EnvDefaultFunc("AKAMAI_CACHE_TYPE", CacheTypeMemory),
+						ValidateDiagFunc: tools.ValidateStringInSlice([]string{CacheTypeMemory, CacheTypeFile}),
+					},
+					"cache_dir": {
+						Description: "The directory of the file cache, shared between provider processes",
+						Optional:    true,
+						Type:        schema.TypeString,
+						DefaultFunc: schema.EnvDefaultFunc("AKAMAI_CACHE_DIR", nil),
+					},
+					"cache_ttl": {
+						Description:      "The time to live of file cache entries, e.g. 30m",
+						Optional:         true,
+						Type:             schema.TypeString,
+						DefaultFunc:      schema.EnvDefaultFunc("AKAMAI_CACHE_TTL", DefaultCacheTTL.String()),
+						ValidateDiagFunc: tools.ValidateDuration,
+					},
+					"requests_per_second": {
+						Description:      "The maximum sustained number of requests per second sent to each Akamai API, 0 means unlimited",
+						Optional:         true,
+						Type:             schema.TypeFloat,
+						Default:          0.0,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+					},
+					"burst": {
+						Description:      "The maximum number of requests sent to each Akamai API at once when requests_per_second is set",
+						Optional:         true,
+						Type:             schema.TypeInt,
+						Default:          1,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+					},
+					"max_retries": {
+						Description:      "The maximum number of retries of requests rejected with 429 Too Many Requests or failed with a 5xx error",
+						Optional:         true,
+						Type:             schema.TypeInt,
+						Default:          0,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+					},
+					"dry_run": {
+						Description: "Refuses every request other than GET, so that nothing is modified",
+						Optional:    true,
+						Type:        schema.TypeBool,
+						DefaultFunc: schema.EnvDefaultFunc("AKAMAI_DRY_RUN", false),
+					},
+					"require_compliance_record": {
+						Description: "Requires a compliance record for every activation on the production network",
+						Optional:    true,
+						Type:        schema.TypeBool,
+						DefaultFunc: schema.EnvDefaultFunc("AKAMAI_REQUIRE_COMPLIANCE_RECORD", false),
+					},
+					"audit_log": {
+						Description: "The location of the JSON Lines file recording every mutating API call",
+						Optional:    true,
+						Type:        schema.TypeString,
+						DefaultFunc: schema.EnvDefaultFunc("AKAMAI_AUDIT_LOG", nil),
+					},
+					"otel_endpoint": {
+						Description: "The OTLP/HTTP endpoint receiving the traces of the provider operations, e.g. http://localhost:4318. The standard OTEL_EXPORTER_OTLP_ENDPOINT variables enable the export as well",
+						Optional:    true,
+						Type:        schema.TypeString,
+					},
+					"cache_invalidate": {
+						Description: "The subproviders whose cached entries are removed when the provider is configured",
+						Optional:    true,
+						Type:        schema.TypeSet,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
 				},
 				ResourcesMap:       make(map[string]*schema.Resource),
 				DataSourcesMap:     make(map[string]*schema.Resource),
@@ -113,7 +186,7 @@ func Provider(provs ...Subprovider) plugin.ProviderFunc {
 			panic(err)
 		}
 
-		instance.cache = cache
+		instance.cache = newMemoryCache(cache)
 
 		for _, p := range provs {
 			subSchema, err := mergeSchema(p.Schema(), instance.Schema)
@@ -135,6 +208,18 @@ func Provider(provs ...Subprovider) plugin.ProviderFunc {
 			instance.subs[p.Name()] = p
 		}
 
+		for name, r := range instance.ResourcesMap {
+			addProfileSupport(r, false)
+			addAuditSupport(name, r)
+			addDryRunSupport(r)
+			addTracingSupport(name, r)
+		}
+		for name, r := range instance.DataSourcesMap {
+			addProfileSupport(r, true)
+			addAuditSupport(name, r)
+			addTracingSupport(name, r)
+		}
+
 		instance.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 			return configureContext(ctx, d)
 		}
@@ -166,124 +251,202 @@ func configureContext(ctx context.Context, d *schema.ResourceData) (interface{},
 		return nil, diag.FromErr(err)
 	}
 
-	edgercOps := []edgegrid.Option{edgegrid.WithEnv(true)}
+	var cache cacheStore
+	if cacheEnabled {
+		cache, err = configureCache(d)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
 
-	edgercPath, err := tools.GetStringValue("edgerc", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+	edgerc, err := getEdgegridConfig(ctx, d)
+	if err != nil {
 		return nil, diag.FromErr(err)
 	}
-	edgercPath = getEdgercPath(edgercPath)
 
-	edgercOps = append(edgercOps, edgegrid.WithFile(edgercPath))
-	edgercSection, err := tools.GetStringValue("config_section", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+	profileConfigs, err := getProfileConfigs(ctx, d)
+	if err != nil {
 		return nil, diag.FromErr(err)
 	}
-	if err == nil {
-		edgercOps = append(edgercOps, edgegrid.WithSection(edgercSection))
+
+	governor, err := getGovernorConfig(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
 	}
-	envs, err := tools.GetSetValue("config", d)
-	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+
+	// PROVIDER_VERSION env value must be updated in version file, for every new release.
+	logger := LogFromHCLog(log)
+	logger.Infof("Provider version: %s", version.ProviderVersion)
+
+	audit, err := getAuditor(d, opid)
+	if err != nil {
 		return nil, diag.FromErr(err)
 	}
-	if err == nil && len(envs.List()) > 0 {
-		envsMap, ok := envs.List()[0].(map[string]interface{})
-		if !ok {
-			return nil, diag.FromErr(fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "config", "map[string]interface{}"))
-		}
-		err = setEdgegridEnvs(envsMap, edgercSection)
-		if err != nil {
-			return nil, diag.FromErr(err)
-		}
+
+	dryRun, _ := d.Get("dry_run").(bool)
+	if dryRun {
+		logger.Warn("Dry run mode is enabled, only GET requests are sent")
 	}
 
-	edgerc, err := edgegrid.New(edgercOps...)
+	requireComplianceRecord, _ := d.Get("require_compliance_record").(bool)
+
+	dflt, err := getDefaults(d)
 	if err != nil {
-		return nil, diag.Errorf(ConfigurationIsNotSpecified)
+		return nil, diag.FromErr(err)
 	}
 
-	if err := edgerc.Validate(); err != nil {
-		return nil, diag.Errorf(err.Error())
+	tracerProvider, err := getTracerProvider(ctx, d)
+	if err != nil {
+		return nil, diag.FromErr(err)
 	}
 
-	// PROVIDER_VERSION env value must be updated in version file, for every new release.
-	userAgent := instance.UserAgent(ProviderName, version.ProviderVersion)
-	logger := LogFromHCLog(log)
-	logger.Infof("Provider version: %s", version.ProviderVersion)
-
-	sess, err := session.New(
-		session.WithSigner(edgerc),
-		session.WithUserAgent(userAgent),
-		session.WithLog(logger),
-		session.WithHTTPTracing(cast.ToBool(os.Getenv("AKAMAI_HTTP_TRACE_ENABLED"))),
-	)
+	sess, err := newSession(edgerc, logger, governor, audit, dryRun, tracerProvider)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 
+	profiles := make(map[string]session.Session, len(profileConfigs))
+	accounts := make(map[string]string, len(profileConfigs))
+	for name, profileConfig := range profileConfigs {
+		profileSess, err := newSession(profileConfig, LogFromHCLog(log.With("profile", name)), governor, audit.withProfile(name), dryRun, tracerProvider)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		profiles[name] = profileSess
+		accounts[name] = credentialsCacheKey(profileConfig)
+	}
+
 	meta := &meta{
 		log:          log,
 		operationID:  opid,
 		sess:         sess,
 		cacheEnabled: cacheEnabled,
+		cache:        cache,
+		profiles:     profiles,
+		account:      credentialsCacheKey(edgerc),
+		accounts:     accounts,
+		dryRun:       dryRun,
+		defaults:     dflt,
+
+		requireComplianceRecord: requireComplianceRecord,
+
+		tracerProvider: tracerProvider,
 	}
 
 	return meta, nil
 }
 
-func getEdgercPath(edgercPath string) string {
-	if edgercPath == "" {
-		edgercPath = edgegrid.DefaultConfigFile
+// newSession creates the signed EdgeGrid session for the given credentials
+func newSession(edgerc *edgegrid.Config, logger log.Interface, governor governorConfig, audit *auditor, dryRun bool, tracerProvider *sdktrace.TracerProvider) (session.Session, error) {
+	userAgent := instance.UserAgent(ProviderName, version.ProviderVersion)
+
+	sess, err := session.New(
+		session.WithSigner(edgerc),
+		session.WithUserAgent(userAgent),
+		session.WithLog(logger),
+		session.WithHTTPTracing(cast.ToBool(os.Getenv("AKAMAI_HTTP_TRACE_ENABLED"))),
+	)
+	if err != nil {
+		return nil, err
 	}
-	return edgercPath
+
+	// the audit log records a single entry per call, including the time spent in retries,
+	// and calls blocked in dry run mode never reach it. Every retry is traced in its own span
+	return newDryRunSession(newAuditedSession(newGovernedSession(newTracedSession(sess, tracerProvider), governor), audit), dryRun), nil
 }
 
-func setEdgegridEnvs(envsMap map[string]interface{}, section string) error {
-	configEnvs := []string{"ACCESS_TOKEN", "CLIENT_TOKEN", "HOST", "CLIENT_SECRET", "MAX_BODY"}
-	prefix := "AKAMAI"
-	if section != "" {
-		prefix = fmt.Sprintf("%s_%s", prefix, strings.ToUpper(section))
+func configureCache(d *schema.ResourceData) (cacheStore, error) {
+	cacheType, err := tools.GetStringValue("cache_type", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, err
 	}
-	for _, env := range configEnvs {
-		var value string
-		var ok bool
-		switch env {
-		case "ACCESS_TOKEN":
-			value, ok = envsMap["access_token"].(string)
-			if !ok {
-				return fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "access_token", "string")
-			}
-		case "CLIENT_TOKEN":
-			value, ok = envsMap["client_token"].(string)
-			if !ok {
-				return fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "client_token", "string")
-			}
-		case "HOST":
-			value, ok = envsMap["host"].(string)
-			if !ok {
-				return fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "host", "string")
+
+	var cache cacheStore
+	switch cacheType {
+	case "", CacheTypeMemory:
+		cache = instance.cache
+	case CacheTypeFile:
+		cacheDir, err := tools.GetStringValue("cache_dir", d)
+		if err != nil && !errors.Is(err, tools.ErrNotFound) {
+			return nil, err
+		}
+		ttl := DefaultCacheTTL
+		cacheTTL, err := tools.GetStringValue("cache_ttl", d)
+		if err != nil && !errors.Is(err, tools.ErrNotFound) {
+			return nil, err
+		}
+		if cacheTTL != "" {
+			if ttl, err = time.ParseDuration(cacheTTL); err != nil {
+				return nil, fmt.Errorf("%w: %s", tools.ErrInvalidType, err)
 			}
-		case "CLIENT_SECRET":
-			value, ok = envsMap["client_secret"].(string)
-			if !ok {
-				return fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "client_secret", "string")
+		}
+		if cache, err = newFileCache(cacheDir, ttl); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrCacheType, cacheType)
+	}
+
+	invalidate, err := tools.GetSetValue("cache_invalidate", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		for _, name := range tools.SetToStringSlice(invalidate) {
+			if _, ok := instance.subs[name]; !ok {
+				return nil, fmt.Errorf("%w: %s", ErrProviderNotLoaded, name)
 			}
-		case "MAX_BODY":
-			maxBody, ok := envsMap["max_body"].(int)
-			if !ok {
-				return fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "max_body", "int")
+			if err := cache.Invalidate(name); err != nil {
+				return nil, err
 			}
-			value = strconv.Itoa(maxBody)
 		}
-		env = fmt.Sprintf("%s_%s", prefix, env)
-		if os.Getenv(env) != "" {
-			continue
-		}
-		if err := os.Setenv(env, value); err != nil {
-			return err
+	}
+
+	return cache, nil
+}
+
+// getAuditor returns the auditor recording the mutating calls, or nil if the audit log is not configured
+func getAuditor(d *schema.ResourceData, operationID string) (*auditor, error) {
+	path, err := tools.GetStringValue("audit_log", d)
+	if err != nil {
+		if errors.Is(err, tools.ErrNotFound) {
+			return nil, nil
 		}
+		return nil, err
+	}
+	sink, err := newAuditSink(path)
+	if err != nil {
+		return nil, err
+	}
+	return &auditor{sink: sink, operationID: operationID}, nil
+}
+
+func getGovernorConfig(d *schema.ResourceData) (governorConfig, error) {
+	var config governorConfig
+
+	rps, err := tools.GetFloat64Value("requests_per_second", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return config, err
+	}
+	burst, err := tools.GetIntValue("burst", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return config, err
+	}
+	maxRetries, err := tools.GetIntValue("max_retries", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return config, err
 	}
-	return nil
+	config.RequestsPerSecond = rps
+	config.Burst = burst
+	config.MaxRetries = maxRetries
+	return config, nil
+}
+
+func getEdgercPath(edgercPath string) string {
+	if edgercPath == "" {
+		edgercPath = edgegrid.DefaultConfigFile
+	}
+	return edgercPath
 }
 
 func mergeSchema(from, to map[string]*schema.Schema) (map[string]*schema.Schema, error) {
@@ -46,12 +46,12 @@ func restoreEnvs(t *testing.T, envs map[string]string) {
 	}
 }
 
-func TestSetEdgegridEnvs(t *testing.T) {
+func TestNewEdgegridConfig(t *testing.T) {
 	tests := map[string]struct {
-		givenMap     map[string]interface{}
-		givenSection string
-		setEnvs      map[string]string
-		expectedEnvs map[string]string
+		givenMap       map[string]interface{}
+		givenSection   string
+		setEnvs        map[string]string
+		expectedConfig edgegrid.Config
 	}{
 		"no section provided": {
 			givenMap: map[string]interface{}{
@@ -60,13 +60,15 @@ func TestSetEdgegridEnvs(t *testing.T) {
 				"client_secret": "test_client_secret",
 				"host":          "test_host",
 				"max_body":      123,
+				"account_key":   "test_account_key",
 			},
-			expectedEnvs: map[string]string{
-				"AKAMAI_ACCESS_TOKEN":  "test_access_token",
-				"AKAMAI_CLIENT_TOKEN":  "test_client_token",
-				"AKAMAI_CLIENT_SECRET": "test_client_secret",
-				"AKAMAI_HOST":          "test_host",
-				"AKAMAI_MAX_BODY":      "123",
+			expectedConfig: edgegrid.Config{
+				AccessToken:  "test_access_token",
+				ClientToken:  "test_client_token",
+				ClientSecret: "test_client_secret",
+				Host:         "test_host",
+				MaxBody:      123,
+				AccountKey:   "test_account_key",
 			},
 		},
 		"custom section provided": {
@@ -78,12 +80,15 @@ func TestSetEdgegridEnvs(t *testing.T) {
 				"max_body":      123,
 			},
 			givenSection: "test",
-			expectedEnvs: map[string]string{
-				"AKAMAI_TEST_ACCESS_TOKEN":  "test_access_token",
-				"AKAMAI_TEST_CLIENT_TOKEN":  "test_client_token",
-				"AKAMAI_TEST_CLIENT_SECRET": "test_client_secret",
-				"AKAMAI_TEST_HOST":          "test_host",
-				"AKAMAI_TEST_MAX_BODY":      "123",
+			setEnvs: map[string]string{
+				"AKAMAI_HOST": "default_section_host",
+			},
+			expectedConfig: edgegrid.Config{
+				AccessToken:  "test_access_token",
+				ClientToken:  "test_client_token",
+				ClientSecret: "test_client_secret",
+				Host:         "test_host",
+				MaxBody:      123,
 			},
 		},
 		"envs are already set": {
@@ -102,12 +107,28 @@ func TestSetEdgegridEnvs(t *testing.T) {
 				"AKAMAI_TEST_HOST":          "existing_host",
 				"AKAMAI_TEST_MAX_BODY":      "321",
 			},
-			expectedEnvs: map[string]string{
-				"AKAMAI_TEST_ACCESS_TOKEN":  "existing_access_token",
-				"AKAMAI_TEST_CLIENT_TOKEN":  "existing_client_token",
-				"AKAMAI_TEST_CLIENT_SECRET": "existing_client_secret",
-				"AKAMAI_TEST_HOST":          "existing_host",
-				"AKAMAI_TEST_MAX_BODY":      "321",
+			expectedConfig: edgegrid.Config{
+				AccessToken:  "existing_access_token",
+				ClientToken:  "existing_client_token",
+				ClientSecret: "existing_client_secret",
+				Host:         "existing_host",
+				MaxBody:      321,
+			},
+		},
+		"max_body defaults to edgegrid max body size": {
+			givenMap: map[string]interface{}{
+				"access_token":  "test_access_token",
+				"client_token":  "test_client_token",
+				"client_secret": "test_client_secret",
+				"host":          "test_host",
+				"max_body":      0,
+			},
+			expectedConfig: edgegrid.Config{
+				AccessToken:  "test_access_token",
+				ClientToken:  "test_client_token",
+				ClientSecret: "test_client_secret",
+				Host:         "test_host",
+				MaxBody:      edgegrid.MaxBodySize,
 			},
 		},
 	}
@@ -117,32 +138,25 @@ func TestSetEdgegridEnvs(t *testing.T) {
 			existingEnvs := unsetEnvs(t)
 			defer restoreEnvs(t, existingEnvs)
 
-			currentEnvs := make(map[string]string, len(test.expectedEnvs))
-			for k := range test.expectedEnvs {
-				currentEnvs[k] = os.Getenv(k)
-				err := os.Unsetenv(k)
-				require.NoError(t, err)
+			for k, v := range test.setEnvs {
+				require.NoError(t, os.Setenv(k, v))
 			}
 			defer func() {
-				for k, v := range currentEnvs {
-					err := os.Setenv(k, v)
-					require.NoError(t, err)
+				for k := range test.setEnvs {
+					require.NoError(t, os.Unsetenv(k))
 				}
 			}()
-			for k, v := range test.setEnvs {
-				require.NoError(t, os.Setenv(k, v))
-			}
+			envsBefore := os.Environ()
 
-			err := setEdgegridEnvs(test.givenMap, test.givenSection)
+			edgerc, err := newEdgegridConfig(test.givenMap, test.givenSection)
 			require.NoError(t, err)
-			for k, v := range test.expectedEnvs {
-				assert.Equal(t, v, os.Getenv(k))
-			}
+			assert.Equal(t, test.expectedConfig, *edgerc)
+			assert.Equal(t, envsBefore, os.Environ())
 		})
 	}
 }
 
-func TestSetWrongTypeForEdgegridEnvs(t *testing.T) {
+func TestNewEdgegridConfigWrongType(t *testing.T) {
 
 	tests := map[string]struct {
 		environmentVars map[string]interface{}
@@ -165,7 +179,7 @@ func TestSetWrongTypeForEdgegridEnvs(t *testing.T) {
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			err := setEdgegridEnvs(test.environmentVars, "some section")
+			_, err := newEdgegridConfig(test.environmentVars, "some section")
 			assert.True(t, errors.Is(err, tools.ErrInvalidType))
 		})
 	}
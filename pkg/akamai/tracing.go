@@ -0,0 +1,238 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/akamai/terraform-provider-akamai/v3/version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// tracerName is the instrumentation name of the spans created by the provider
+	tracerName = "github.com/akamai/terraform-provider-akamai"
+
+	// tracingShutdownTimeout limits the time spent exporting the remaining spans when the provider stops,
+	// Terraform kills the provider process 2 seconds after asking it to stop
+	tracingShutdownTimeout = 1500 * time.Millisecond
+)
+
+var (
+	// otelEndpointEnvs are the standard variables which enable the trace export without the otel_endpoint argument
+	otelEndpointEnvs = []string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT"}
+
+	// tracerProviders are the tracer providers of all configured provider instances, shut down when the provider stops
+	tracerProviders struct {
+		sync.Mutex
+		list []*sdktrace.TracerProvider
+	}
+)
+
+type (
+	// tracedSession is a session which records every request in a span, a child of the span in the request context
+	tracedSession struct {
+		session.Session
+		tracer trace.Tracer
+	}
+)
+
+// StartSpan starts a span, a child of the span of the traced operation in ctx
+//
+// when tracing is disabled, the span is not recorded
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// getTracerProvider returns the tracer provider exporting spans to the OTLP endpoint,
+// or nil if neither the otel_endpoint argument nor the standard OTEL variables are set
+func getTracerProvider(ctx context.Context, d *schema.ResourceData) (*sdktrace.TracerProvider, error) {
+	endpoint, err := tools.GetStringValue("otel_endpoint", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, err
+	}
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return nil, nil
+	}
+	if endpoint == "" && !otelEndpointFromEnv() {
+		return nil, nil
+	}
+	return newTracerProvider(ctx, endpoint)
+}
+
+// newTracerProvider creates the tracer provider exporting spans over OTLP/HTTP
+//
+// an empty endpoint leaves the exporter configuration to the standard OTEL variables
+func newTracerProvider(ctx context.Context, endpoint string) (*sdktrace.TracerProvider, error) {
+	var opts []otlptracehttp.Option
+	if endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("%w: invalid otel_endpoint %q", ErrTracing, endpoint)
+		}
+		opts = append(opts, otlptracehttp.WithEndpoint(u.Host))
+		if u.Scheme == "http" {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if u.Path != "" && u.Path != "/" {
+			opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+		}
+	}
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTracing, err)
+	}
+
+	// the attributes from the OTEL variables take precedence over the defaults
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String("terraform-provider-akamai"),
+			semconv.ServiceVersionKey.String(version.ProviderVersion),
+		),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTracing, err)
+	}
+
+	// the batch span processor exports the spans in the background, the rest is exported by ShutdownTracing
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	tracerProviders.Lock()
+	tracerProviders.list = append(tracerProviders.list, tp)
+	tracerProviders.Unlock()
+	return tp, nil
+}
+
+// ShutdownTracing exports the spans which were not exported yet and shuts down the tracer providers
+//
+// it is called once, when the provider stops serving Terraform
+func ShutdownTracing() error {
+	tracerProviders.Lock()
+	defer tracerProviders.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	var errs []string
+	for _, tp := range tracerProviders.list {
+		if err := tp.Shutdown(ctx); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	tracerProviders.list = nil
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: exporting traces: %s", ErrTracing, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func otelEndpointFromEnv() bool {
+	for _, env := range otelEndpointEnvs {
+		if os.Getenv(env) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// newTracedSession wraps the session so that every request is recorded in a span, or returns the session unchanged if tracing is disabled
+func newTracedSession(sess session.Session, tp *sdktrace.TracerProvider) session.Session {
+	if tp == nil {
+		return sess
+	}
+	return &tracedSession{Session: sess, tracer: tp.Tracer(tracerName)}
+}
+
+// Exec executes the request in a client span
+func (s *tracedSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	ctx, span := s.tracer.Start(r.Context(), fmt.Sprintf("HTTP %s", r.Method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String(r.Method),
+			semconv.HTTPTargetKey.String(r.URL.Path),
+			semconv.NetPeerNameKey.String(r.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	resp, err := s.Session.Exec(r.WithContext(ctx), out, in...)
+
+	if resp != nil {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return resp, err
+}
+
+// addTracingSupport records every call of the resource functions in a span
+func addTracingSupport(resourceType string, r *schema.Resource) {
+	r.CreateContext = withTracing(resourceType, "Create", r.CreateContext)
+	r.ReadContext = withTracing(resourceType, "Read", r.ReadContext)
+	r.UpdateContext = withTracing(resourceType, "Update", r.UpdateContext)
+	r.DeleteContext = withTracing(resourceType, "Delete", r.DeleteContext)
+}
+
+func withTracing(resourceType, operation string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		mt, ok := m.(*meta)
+		if !ok || mt.tracerProvider == nil {
+			return f(ctx, d, m)
+		}
+
+		ctx, span := mt.tracerProvider.Tracer(tracerName).Start(ctx, fmt.Sprintf("%s.%s", resourceType, operation),
+			trace.WithAttributes(
+				attribute.String("akamai.resource_type", resourceType),
+				attribute.String("akamai.operation", operation),
+				attribute.String("akamai.operation_id", mt.operationID),
+			),
+		)
+
+		// the ID is known before Delete and after Create
+		id := d.Id()
+		diags := f(ctx, d, m)
+		if d.Id() != "" {
+			id = d.Id()
+		}
+		span.SetAttributes(attribute.String("akamai.resource_id", id))
+		for _, diagnostic := range diags {
+			if diagnostic.Severity == diag.Error {
+				span.SetStatus(codes.Error, diagnostic.Summary)
+				break
+			}
+		}
+		span.End()
+
+		return diags
+	}
+}
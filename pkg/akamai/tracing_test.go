@@ -0,0 +1,193 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// testCollector is a stand-in of an OTLP/HTTP collector, keeping the received spans
+type testCollector struct {
+	*httptest.Server
+	mu    sync.Mutex
+	spans map[string]*tracepb.Span
+}
+
+func newTestCollector(t *testing.T) *testCollector {
+	c := &testCollector{spans: make(map[string]*tracepb.Span)}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		var req collectortrace.ExportTraceServiceRequest
+		require.NoError(t, proto.Unmarshal(body, &req))
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.InstrumentationLibrarySpans {
+				for _, span := range ss.Spans {
+					c.spans[span.Name] = span
+				}
+			}
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		resp, err := proto.Marshal(&collectortrace.ExportTraceServiceResponse{})
+		require.NoError(t, err)
+		_, err = w.Write(resp)
+		require.NoError(t, err)
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func (c *testCollector) span(name string) *tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.spans[name]
+}
+
+func spanAttributes(span *tracepb.Span) map[string]interface{} {
+	attrs := make(map[string]interface{})
+	for _, kv := range span.Attributes {
+		switch v := kv.Value.Value.(type) {
+		case *commonpb.AnyValue_StringValue:
+			attrs[kv.Key] = v.StringValue
+		case *commonpb.AnyValue_IntValue:
+			attrs[kv.Key] = v.IntValue
+		}
+	}
+	return attrs
+}
+
+func TestTracing(t *testing.T) {
+	collector := newTestCollector(t)
+	tp, err := newTracerProvider(context.Background(), collector.URL)
+	require.NoError(t, err)
+
+	sess := &mockSession{responses: []int{201}}
+	m := &meta{operationID: "test-operation", sess: newTracedSession(sess, tp), tracerProvider: tp}
+
+	create := withTracing("akamai_property", "Create", func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://host/papi/v1/properties?contractId=ctr_1", nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if _, err := Meta(m).Session().Exec(req, nil); err != nil {
+			return diag.FromErr(err)
+		}
+		_, span := StartSpan(ctx, "activation polling")
+		span.End()
+		d.SetId("prp_1")
+		return nil
+	})
+
+	d := (&schema.Resource{Schema: map[string]*schema.Schema{}}).TestResourceData()
+	require.False(t, create(context.Background(), d, m).HasError())
+
+	// the spans are exported in batches and the rest when the provider stops
+	assert.Nil(t, collector.span("akamai_property.Create"))
+	require.NoError(t, ShutdownTracing())
+	assert.Empty(t, tracerProviders.list)
+
+	operation := collector.span("akamai_property.Create")
+	require.NotNil(t, operation)
+	assert.Equal(t, map[string]interface{}{
+		"akamai.resource_type": "akamai_property",
+		"akamai.resource_id":   "prp_1",
+		"akamai.operation":     "Create",
+		"akamai.operation_id":  "test-operation",
+	}, spanAttributes(operation))
+
+	request := collector.span("HTTP POST")
+	require.NotNil(t, request)
+	assert.Equal(t, operation.SpanId, request.ParentSpanId)
+	assert.Equal(t, operation.TraceId, request.TraceId)
+	attrs := spanAttributes(request)
+	assert.Equal(t, "/papi/v1/properties", attrs["http.target"])
+	assert.Equal(t, int64(201), attrs["http.status_code"])
+
+	polling := collector.span("activation polling")
+	require.NotNil(t, polling)
+	assert.Equal(t, operation.SpanId, polling.ParentSpanId)
+}
+
+func TestTracing_disabled(t *testing.T) {
+	sess := &mockSession{}
+	assert.Equal(t, sess, newTracedSession(sess, nil))
+
+	var called bool
+	read := withTracing("akamai_property", "Read", func(ctx context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+		called = true
+		_, span := StartSpan(ctx, "not recorded")
+		assert.False(t, span.IsRecording())
+		span.End()
+		return nil
+	})
+	d := (&schema.Resource{Schema: map[string]*schema.Schema{}}).TestResourceData()
+	assert.Nil(t, read(context.Background(), d, &meta{}))
+	assert.True(t, called)
+}
+
+func TestGetTracerProvider(t *testing.T) {
+	tests := map[string]struct {
+		endpoint  string
+		env       map[string]string
+		enabled   bool
+		withError error
+	}{
+		"disabled": {},
+		"argument": {
+			endpoint: "http://localhost:4318",
+			enabled:  true,
+		},
+		"environment": {
+			env:     map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"},
+			enabled: true,
+		},
+		"sdk disabled": {
+			endpoint: "http://localhost:4318",
+			env:      map[string]string{"OTEL_SDK_DISABLED": "true"},
+		},
+		"invalid endpoint": {
+			endpoint:  "localhost",
+			withError: ErrTracing,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, env := range append(otelEndpointEnvs, "OTEL_SDK_DISABLED") {
+				t.Setenv(env, test.env[env])
+			}
+			raw := map[string]interface{}{}
+			if test.endpoint != "" {
+				raw["otel_endpoint"] = test.endpoint
+			}
+			d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+				"otel_endpoint": {Type: schema.TypeString, Optional: true},
+			}, raw)
+
+			tp, err := getTracerProvider(context.Background(), d)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.enabled, tp != nil)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/appsec"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -78,6 +79,8 @@ func resourceActivations() *schema.Resource {
 				Computed:    true,
 				Description: "The results of the activation",
 			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(ActivationPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: &AppsecResourceTimeout,
@@ -170,18 +173,9 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	for activation.Status != appsec.StatusActive {
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivations(ctx, getActivationRequest)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act
-
-		case <-ctx.Done():
-			return diag.Errorf("activation context terminated: %s", ctx.Err())
-		}
+	activation, err = waitForActivationStatus(ctx, d, client, getActivationRequest, activation, appsec.StatusActive, logger)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	return resourceActivationsRead(ctx, d, m)
@@ -221,6 +215,11 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	logger := meta.Log("APPSEC", "resourceActivationsUpdate")
 	logger.Debug("in resourceActivationsUpdate")
 
+	if !d.HasChangesExcept(tools.PollIntervalKey, tools.PollTimeoutKey) {
+		logger.Debug("only the polling settings changed, keeping the current activation")
+		return nil
+	}
+
 	configID, err := tools.GetIntValue("config_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -287,19 +286,9 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	for activation.Status != appsec.StatusActive {
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivations(ctx, getActivationRequest)
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act
-
-		case <-ctx.Done():
-			return diag.Errorf("activation context terminated: %s", ctx.Err())
-		}
+	activation, err = waitForActivationStatus(ctx, d, client, getActivationRequest, activation, appsec.StatusActive, logger)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	return resourceActivationsRead(ctx, d, m)
@@ -383,19 +372,9 @@ func resourceActivationsDelete(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	for activation.Status != appsec.StatusDeactivated {
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivations(ctx, getActivationRequest)
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act
-
-		case <-ctx.Done():
-			return diag.Errorf("activation context terminated: %s", ctx.Err())
-		}
+	activation, err = waitForActivationStatus(ctx, d, client, getActivationRequest, activation, appsec.StatusDeactivated, logger)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("status", activation.Status); err != nil {
@@ -475,6 +454,43 @@ func lookupActivation(ctx context.Context, client appsec.APPSEC, query appsec.Ge
 	return activations, nil
 }
 
+// waitForActivationStatus polls the activation until it reaches the expected status or fails
+func waitForActivationStatus(ctx context.Context, d *schema.ResourceData, client appsec.APPSEC, query appsec.GetActivationsRequest,
+	activation *appsec.GetActivationsResponse, status appsec.StatusValue, logger log.Interface) (*appsec.GetActivationsResponse, error) {
+	checkStatus := func(activation *appsec.GetActivationsResponse) (bool, error) {
+		switch activation.Status {
+		case status:
+			return true, nil
+		case appsec.StatusFailed, appsec.StatusAborted:
+			return false, fmt.Errorf("activation %d reached status %s", activation.ActivationID, activation.Status)
+		}
+		return false, nil
+	}
+	if done, err := checkStatus(activation); done || err != nil {
+		return activation, err
+	}
+
+	poller, err := tools.NewPoller(fmt.Sprintf("appsec activation %d", activation.ActivationID), d, ActivationPollInterval, ActivationPollMinimum, logger)
+	if err != nil {
+		return nil, err
+	}
+	err = poller.Poll(ctx, func(ctx context.Context) (bool, error) {
+		act, err := client.GetActivations(ctx, query)
+		if err != nil {
+			return false, err
+		}
+		activation = act
+		return checkStatus(activation)
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return nil, fmt.Errorf("activation context terminated: %w", err)
+		}
+		return nil, err
+	}
+	return activation, nil
+}
+
 func defaultActivationNote(deactivating bool) (string, error) {
 	location, err := time.LoadLocation("UTC")
 	if err != nil {
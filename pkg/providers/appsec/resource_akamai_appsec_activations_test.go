@@ -76,4 +76,67 @@ func TestAkamaiActivations_res_basic(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
+	t.Run("poll interval change does not activate again", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		removeActivationsResponse := appsec.RemoveActivationsResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResActivations/ActivationsDelete.json"), &removeActivationsResponse)
+		require.NoError(t, err)
+
+		getActivationsResponse := appsec.GetActivationsResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResActivations/Activations.json"), &getActivationsResponse)
+		require.NoError(t, err)
+
+		createActivationsResponse := appsec.CreateActivationsResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResActivations/Activations.json"), &createActivationsResponse)
+		require.NoError(t, err)
+
+		client.On("GetActivations",
+			mock.Anything,
+			appsec.GetActivationsRequest{ActivationID: 547694},
+		).Return(&getActivationsResponse, nil)
+
+		client.On("CreateActivations",
+			mock.Anything,
+			appsec.CreateActivationsRequest{
+				Action:             "ACTIVATE",
+				Network:            "STAGING",
+				Note:               "TEST Notes",
+				NotificationEmails: []string{"martin@email.io"},
+				ActivationConfigs: []struct {
+					ConfigID      int `json:"configId"`
+					ConfigVersion int `json:"configVersion"`
+				}{{ConfigID: 43253, ConfigVersion: 7}}},
+		).Return(&createActivationsResponse, nil).Once()
+
+		client.On("RemoveActivations",
+			mock.Anything,
+			mock.AnythingOfType("appsec.RemoveActivationsRequest"),
+		).Run(func(mock.Arguments) {
+			getActivationsResponse.Status = appsec.StatusDeactivated
+		}).Return(&removeActivationsResponse, nil).Once()
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResActivations/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_activations.test", "id", "547694"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResActivations/poll_interval.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_activations.test", "id", "547694"),
+							resource.TestCheckResourceAttr("akamai_appsec_activations.test", "poll_interval", "30s"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
 }
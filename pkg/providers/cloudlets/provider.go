@@ -60,7 +60,7 @@ func Provider() *schema.Provider {
 		ResourcesMap: map[string]*schema.Resource{
 			"akamai_cloudlets_application_load_balancer":            resourceCloudletsApplicationLoadBalancer(),
 			"akamai_cloudlets_application_load_balancer_activation": resourceCloudletsApplicationLoadBalancerActivation(),
-			"akamai_cloudlets_policy":                               resourceCloudletsPolicy(),
+			"akamai_cloudlets_policy":                               akamai.WithProviderDefaults(resourceCloudletsPolicy()),
 			"akamai_cloudlets_policy_activation":                    resourceCloudletsPolicyActivation(),
 		},
 	}
@@ -362,6 +362,9 @@ func resourceALBRead(ctx context.Context, d *schema.ResourceData, m interface{})
 		ShouldValidate: true,
 	})
 	if err != nil {
+		if akamai.RemoveIfNotFound(d, err, logger) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 	attrs := make(map[string]interface{})
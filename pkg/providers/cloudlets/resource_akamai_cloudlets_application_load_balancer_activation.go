@@ -55,6 +55,8 @@ func resourceCloudletsApplicationLoadBalancerActivationSchema() map[string]*sche
 			Computed:    true,
 			Description: "Activation status for this application load balancer",
 		},
+		tools.PollIntervalKey: tools.PollIntervalSchema(ALBActivationPollInterval),
+		tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 	}
 }
 
@@ -173,7 +175,7 @@ Failed to restore previous local schema values. The schema will remain in tainte
 	}
 
 	// wait until application load balancer activation is done
-	activation, err = waitForLoadBalancerActivation(ctx, client, originID, version, activationNetwork)
+	activation, err = waitForLoadBalancerActivation(ctx, rd, logger, client, originID, version, activationNetwork)
 	if err != nil {
 		return nil, fmt.Errorf("error while waiting until load balancer activation status == 'active':\n%s", err.Error())
 	}
@@ -262,37 +264,44 @@ func getApplicationLoadBalancerActivation(ctx context.Context, client cloudlets.
 }
 
 // waitForLoadBalancerActivation polls server until the activation has active status or until context is closed (because of timeout, cancellation or context termination)
-func waitForLoadBalancerActivation(ctx context.Context, client cloudlets.Cloudlets, originID string, version int64, network cloudlets.LoadBalancerActivationNetwork) (*cloudlets.LoadBalancerActivation, error) {
-	activation, err := getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
+func waitForLoadBalancerActivation(ctx context.Context, rd *schema.ResourceData, logger log.Interface, client cloudlets.Cloudlets, originID string, version int64, network cloudlets.LoadBalancerActivationNetwork) (*cloudlets.LoadBalancerActivation, error) {
+	var activation *cloudlets.LoadBalancerActivation
+	checkStatus := func(ctx context.Context) (bool, error) {
+		act, err := getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
+		if err != nil {
+			return false, err
+		}
+		activation = act
+		switch activation.Status {
+		case cloudlets.LoadBalancerActivationStatusActive:
+			return true, nil
+		case cloudlets.LoadBalancerActivationStatusPending:
+			return false, nil
+		}
+		return false, fmt.Errorf("%v: originID: %s, status: %s", ErrApplicationLoadBalancerActivation, activation.OriginID, activation.Status)
+	}
+
+	done, err := checkStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if done {
+		return activation, nil
+	}
+	poller, err := tools.NewPoller(fmt.Sprintf("application load balancer %s activation", originID), rd, ALBActivationPollInterval, ALBActivationPollMinimum, logger)
 	if err != nil {
 		return nil, err
 	}
-	for activation.Status != cloudlets.LoadBalancerActivationStatusActive {
-		if activation.Status != cloudlets.LoadBalancerActivationStatusPending {
-			return nil, fmt.Errorf("%v: originID: %s, status: %s", ErrApplicationLoadBalancerActivation, activation.OriginID, activation.Status)
+	if err := poller.Poll(ctx, checkStatus); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, ErrApplicationLoadBalancerActivationTimeout
 		}
-		select {
-		case <-time.After(tools.MaxDuration(ALBActivationPollInterval, ALBActivationPollMinimum)):
-			activation, err = getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
-			if err != nil {
-				return nil, err
-			}
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrApplicationLoadBalancerActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrApplicationLoadBalancerActivationCanceled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrApplicationLoadBalancerActivationContextTerminated, ctx.Err())
+		if errors.Is(err, context.Canceled) {
+			return nil, ErrApplicationLoadBalancerActivationCanceled
 		}
+		return nil, err
 	}
-	if activation.Status == cloudlets.LoadBalancerActivationStatusActive {
-		return activation, nil
-	}
-	// should not reach here
-	return nil, ErrApplicationLoadBalancerActivation
+	return activation, nil
 }
 
 func getALBActivationNetwork(net string) (cloudlets.LoadBalancerActivationNetwork, error) {
@@ -223,6 +223,9 @@ func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, m interface
 	}
 	policy, err := client.GetPolicy(ctx, cloudlets.GetPolicyRequest{PolicyID: policyID})
 	if err != nil {
+		if akamai.RemoveIfNotFound(d, err, logger) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 	version, err := tools.GetIntValue("version", d)
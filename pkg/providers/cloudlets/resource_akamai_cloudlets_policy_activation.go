@@ -64,6 +64,8 @@ func resourceCloudletsPolicyActivationSchema() map[string]*schema.Schema {
 			MinItems:    1,
 			Description: "Set of property IDs to link to this Cloudlets policy",
 		},
+		tools.PollIntervalKey: tools.PollIntervalSchema(ActivationPollInterval),
+		tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 	}
 }
 
@@ -125,7 +127,7 @@ func resourcePolicyActivationDelete(ctx context.Context, rd *schema.ResourceData
 			continue
 		}
 		// wait for removal until there aren't any pending activations
-		if err = waitForNotPendingPolicyActivation(ctx, logger, client, policyID, network); err != nil {
+		if err = waitForNotPendingPolicyActivation(ctx, rd, logger, client, policyID, network); err != nil {
 			return diag.FromErr(err)
 		}
 
@@ -241,13 +243,13 @@ func resourcePolicyActivationUpdate(ctx context.Context, rd *schema.ResourceData
 	}
 
 	// 6. remove from the server all unnecessary policy associated_properties
-	removedProperties, err := syncToServerRemovedProperties(ctx, logger, client, int64(policyID), activationNetwork, activeProps, newPolicyProperties)
+	removedProperties, err := syncToServerRemovedProperties(ctx, rd, logger, client, int64(policyID), activationNetwork, activeProps, newPolicyProperties)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	// 7. poll until active
-	_, err = waitForPolicyActivation(ctx, client, int64(policyID), version, activationNetwork, newPolicyProperties, removedProperties)
+	_, err = waitForPolicyActivation(ctx, rd, logger, client, int64(policyID), version, activationNetwork, newPolicyProperties, removedProperties)
 	if err != nil {
 		return diag.Errorf("%v update: %s", ErrPolicyActivation, err.Error())
 	}
@@ -335,7 +337,7 @@ func resourcePolicyActivationCreate(ctx context.Context, rd *schema.ResourceData
 	}
 
 	// wait until policy activation is done
-	act, err := waitForPolicyActivation(ctx, client, int64(policyID), version, versionActivationNetwork, associatedProperties, nil)
+	act, err := waitForPolicyActivation(ctx, rd, logger, client, int64(policyID), version, versionActivationNetwork, associatedProperties, nil)
 	if err != nil {
 		return diag.Errorf("%v create: %s", ErrPolicyActivation, err.Error())
 	}
@@ -411,23 +413,32 @@ func getActiveProperties(policyActivations []cloudlets.PolicyActivation) []strin
 }
 
 // waitForPolicyActivation polls server until the activation has active status or until context is closed (because of timeout, cancellation or context termination)
-func waitForPolicyActivation(ctx context.Context, client cloudlets.Cloudlets, policyID, version int64, network cloudlets.PolicyActivationNetwork, additionalProps, removedProperties []string) ([]cloudlets.PolicyActivation, error) {
-	activations, err := client.ListPolicyActivations(ctx, cloudlets.ListPolicyActivationsRequest{
-		PolicyID: policyID,
-		Network:  network,
-	})
-	if err != nil {
-		return nil, err
-	}
-	activations = filterActivations(activations, version, additionalProps)
-	for len(activations) > 0 {
+func waitForPolicyActivation(ctx context.Context, rd *schema.ResourceData, logger log.Interface, client cloudlets.Cloudlets, policyID, version int64, network cloudlets.PolicyActivationNetwork, additionalProps, removedProperties []string) ([]cloudlets.PolicyActivation, error) {
+	var activations []cloudlets.PolicyActivation
+	checkStatus := func(ctx context.Context) (bool, error) {
+		var list []cloudlets.PolicyActivation
+		err := akamai.RetryTransient(ctx, akamai.PollRetryAttempts, akamai.PollRetryInterval, func() error {
+			var err error
+			list, err = client.ListPolicyActivations(ctx, cloudlets.ListPolicyActivationsRequest{
+				PolicyID: policyID,
+				Network:  network,
+			})
+			return err
+		})
+		if err != nil {
+			return false, err
+		}
+		activations = filterActivations(list, version, additionalProps)
+		if len(activations) == 0 {
+			return false, fmt.Errorf("%v: policyID %d: not all properties are active", ErrPolicyActivation, policyID)
+		}
 		allActive, allRemoved := true, true
 	activations:
 		for _, act := range activations {
 			if act.PolicyInfo.Version == version {
 				if act.PolicyInfo.Status == cloudlets.PolicyActivationStatusFailed ||
 					strings.Contains(act.PolicyInfo.StatusDetail, "fail") {
-					return nil, fmt.Errorf("%v: policyID %d activation failure: %s", ErrPolicyActivation, act.PolicyInfo.PolicyID, act.PolicyInfo.StatusDetail)
+					return false, fmt.Errorf("%v: policyID %d activation failure: %s", ErrPolicyActivation, act.PolicyInfo.PolicyID, act.PolicyInfo.StatusDetail)
 				}
 				if act.PolicyInfo.Status != cloudlets.PolicyActivationStatusActive {
 					allActive = false
@@ -441,35 +452,23 @@ func waitForPolicyActivation(ctx context.Context, client cloudlets.Cloudlets, po
 				}
 			}
 		}
-		if allActive && allRemoved {
-			return activations, nil
-		}
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			activations, err = client.ListPolicyActivations(ctx, cloudlets.ListPolicyActivationsRequest{
-				PolicyID: policyID,
-				Network:  network,
-			})
-			if err != nil {
-				return nil, err
-			}
-			activations = filterActivations(activations, version, additionalProps)
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrPolicyActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrPolicyActivationCanceled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, ctx.Err())
-		}
+		return allActive && allRemoved, nil
 	}
 
-	if len(activations) == 0 {
-		return nil, fmt.Errorf("%v: policyID %d: not all properties are active", ErrPolicyActivation, policyID)
+	done, err := checkStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if done {
+		return activations, nil
+	}
+	poller, err := tools.NewPoller(fmt.Sprintf("cloudlets policy %d activation", policyID), rd, ActivationPollInterval, ActivationPollMinimum, logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := poller.Poll(ctx, checkStatus); err != nil {
+		return nil, policyActivationPollError(err)
 	}
-
 	return activations, nil
 }
 
@@ -547,7 +546,7 @@ func statePolicyActivationNetwork(i interface{}) string {
 	return net
 }
 
-func syncToServerRemovedProperties(ctx context.Context, logger log.Interface, client cloudlets.Cloudlets, policyID int64, network cloudlets.PolicyActivationNetwork, activeProps, newPolicyProperties []string) ([]string, error) {
+func syncToServerRemovedProperties(ctx context.Context, rd *schema.ResourceData, logger log.Interface, client cloudlets.Cloudlets, policyID int64, network cloudlets.PolicyActivationNetwork, activeProps, newPolicyProperties []string) ([]string, error) {
 	policyProperties, err := client.GetPolicyProperties(ctx, cloudlets.GetPolicyPropertiesRequest{PolicyID: policyID})
 	if err != nil {
 		return nil, fmt.Errorf("%w: cannot find policy %d properties: %s", ErrPolicyActivation, policyID, err.Error())
@@ -569,7 +568,7 @@ activePropertiesLoop:
 		propertyID := associateProperty.ID
 
 		// wait for removal until there aren't any pending activations
-		if err = waitForNotPendingPolicyActivation(ctx, logger, client, policyID, network); err != nil {
+		if err = waitForNotPendingPolicyActivation(ctx, rd, logger, client, policyID, network); err != nil {
 			return nil, err
 		}
 
@@ -582,7 +581,7 @@ activePropertiesLoop:
 	}
 
 	// wait for removal until there aren't any pending activations
-	if err = waitForNotPendingPolicyActivation(ctx, logger, client, policyID, network); err != nil {
+	if err = waitForNotPendingPolicyActivation(ctx, rd, logger, client, policyID, network); err != nil {
 		return nil, err
 	}
 
@@ -590,46 +589,46 @@ activePropertiesLoop:
 	return removedProperties, nil
 }
 
-func waitForNotPendingPolicyActivation(ctx context.Context, logger log.Interface, client cloudlets.Cloudlets, policyID int64, network cloudlets.PolicyActivationNetwork) error {
+func waitForNotPendingPolicyActivation(ctx context.Context, rd *schema.ResourceData, logger log.Interface, client cloudlets.Cloudlets, policyID int64, network cloudlets.PolicyActivationNetwork) error {
 	logger.Debugf("waiting until there none of the policy (ID=%d) activations are in pending state", policyID)
-	activations, err := client.ListPolicyActivations(ctx, cloudlets.ListPolicyActivationsRequest{PolicyID: policyID})
-	if err != nil {
-		return fmt.Errorf("%w: failed to list policy activations for policy %d: %s", ErrPolicyActivation, policyID, err.Error())
-	}
-	for len(activations) > 0 {
-		pending := false
+	request := cloudlets.ListPolicyActivationsRequest{PolicyID: policyID}
+	checkStatus := func(ctx context.Context) (bool, error) {
+		activations, err := client.ListPolicyActivations(ctx, request)
+		if err != nil {
+			return false, fmt.Errorf("%w: failed to list policy activations for policy %d: %s", ErrPolicyActivation, policyID, err.Error())
+		}
 		for _, act := range activations {
 			if act.PolicyInfo.Status == cloudlets.PolicyActivationStatusFailed {
-				return fmt.Errorf("%v: policyID %d: %s", ErrPolicyActivation, act.PolicyInfo.PolicyID, act.PolicyInfo.StatusDetail)
+				return false, fmt.Errorf("%v: policyID %d: %s", ErrPolicyActivation, act.PolicyInfo.PolicyID, act.PolicyInfo.StatusDetail)
 			}
 			if act.PolicyInfo.Status == cloudlets.PolicyActivationStatusPending {
-				pending = true
-				break
+				return false, nil
 			}
 		}
-		if !pending {
-			break
-		}
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			activations, err = client.ListPolicyActivations(ctx, cloudlets.ListPolicyActivationsRequest{
-				PolicyID: policyID,
-				Network:  network,
-			})
-			if err != nil {
-				return fmt.Errorf("%w: failed to list policy activations for policy %d: %s", ErrPolicyActivation, policyID, err.Error())
-			}
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return ErrPolicyActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return ErrPolicyActivationCanceled
-			}
-			return fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, ctx.Err())
-		}
+		return true, nil
 	}
 
+	done, err := checkStatus(ctx)
+	if err != nil || done {
+		return err
+	}
+	request.Network = network
+	poller, err := tools.NewPoller(fmt.Sprintf("cloudlets policy %d pending activations", policyID), rd, ActivationPollInterval, ActivationPollMinimum, logger)
+	if err != nil {
+		return err
+	}
+	if err := poller.Poll(ctx, checkStatus); err != nil {
+		return policyActivationPollError(err)
+	}
 	return nil
 }
+
+func policyActivationPollError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrPolicyActivationTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrPolicyActivationCanceled
+	}
+	return err
+}
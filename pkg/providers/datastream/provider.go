@@ -50,7 +50,7 @@ func Provider() *schema.Provider {
 			"akamai_datastreams":                   dataAkamaiDatastreamStreams(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"akamai_datastream": resourceDatastream(),
+			"akamai_datastream": akamai.WithProviderDefaults(resourceDatastream()),
 		},
 	}
 	return provider
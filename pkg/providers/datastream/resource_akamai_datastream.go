@@ -23,6 +23,9 @@ var (
 	// PollForActivationStatusChangeInterval defines retry interval for getting status of a pending change
 	PollForActivationStatusChangeInterval = 10 * time.Minute
 
+	// PollForActivationStatusChangeMinimum defines the minimum retry interval for getting status of a pending change
+	PollForActivationStatusChangeMinimum = time.Minute
+
 	// ExactlyOneConnectorRule defines connector fields names
 	ExactlyOneConnectorRule = []string{
 		"azure_connector",
@@ -850,6 +853,8 @@ var datastreamResourceSchema = map[string]*schema.Schema{
 			},
 		},
 	},
+	tools.PollIntervalKey: tools.PollIntervalSchema(PollForActivationStatusChangeInterval),
+	tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 }
 
 var configResource = &schema.Resource{
@@ -1006,7 +1011,7 @@ func resourceDatastreamCreate(ctx context.Context, d *schema.ResourceData, m int
 	d.SetId(strconv.FormatInt(streamID, 10))
 
 	if active {
-		_, err = waitForStreamStatusChange(ctx, client, streamID, datastream.ActivationStatusActivated)
+		_, err = waitForStreamStatusChange(ctx, d, logger, client, streamID, datastream.ActivationStatusActivated)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -1116,7 +1121,7 @@ func resourceDatastreamUpdate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	// it is not possible to edit stream while it is (de)activating
-	currentStreamStatus, err := waitForStreamStatusChange(ctx, client, streamID,
+	currentStreamStatus, err := waitForStreamStatusChange(ctx, d, logger, client, streamID,
 		datastream.ActivationStatusDeactivated,
 		datastream.ActivationStatusActivated,
 		datastream.ActivationStatusInactive,
@@ -1150,7 +1155,7 @@ func resourceDatastreamUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 			// wait until stream is activated because updating active stream causes its reactivation
 			logger.Debugf("waiting for stream #%d activation", streamID)
-			_, err = waitForStreamStatusChange(ctx, client, streamID, datastream.ActivationStatusActivated)
+			_, err = waitForStreamStatusChange(ctx, d, logger, client, streamID, datastream.ActivationStatusActivated)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -1158,14 +1163,14 @@ func resourceDatastreamUpdate(ctx context.Context, d *schema.ResourceData, m int
 			// stream is active and should be deactivated
 
 			// deactivate stream first
-			err = deactivateStream(ctx, client, logger, streamID)
+			err = deactivateStream(ctx, d, client, logger, streamID)
 			if err != nil {
 				return diag.FromErr(err)
 			}
 
 			// wait until stream is deactivated
 			logger.Debugf("waiting for stream #%d deactivation", streamID)
-			_, err = waitForStreamStatusChange(ctx, client, streamID, datastream.ActivationStatusDeactivated)
+			_, err = waitForStreamStatusChange(ctx, d, logger, client, streamID, datastream.ActivationStatusDeactivated)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -1187,14 +1192,14 @@ func resourceDatastreamUpdate(ctx context.Context, d *schema.ResourceData, m int
 			//stream is inactive and should be activated
 
 			// activate stream first
-			err = activateStream(ctx, client, logger, streamID)
+			err = activateStream(ctx, d, client, logger, streamID)
 			if err != nil {
 				return diag.FromErr(err)
 			}
 
 			// wait until stream is deactivated
 			logger.Debugf("waiting for stream #%d activation", streamID)
-			_, err = waitForStreamStatusChange(ctx, client, streamID, datastream.ActivationStatusActivated)
+			_, err = waitForStreamStatusChange(ctx, d, logger, client, streamID, datastream.ActivationStatusActivated)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -1206,7 +1211,7 @@ func resourceDatastreamUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 func updateStream(ctx context.Context, client datastream.DS, logger log.Interface, streamID int64, d *schema.ResourceData) error {
 	// if some configuration details changed
-	if d.HasChangeExcept("active") {
+	if d.HasChangesExcept("active", tools.PollIntervalKey, tools.PollTimeoutKey) {
 		configSet, err := tools.GetSetValue("config", d)
 		if err != nil {
 			return err
@@ -1291,7 +1296,7 @@ func updateStream(ctx context.Context, client datastream.DS, logger log.Interfac
 	return nil
 }
 
-func deactivateStream(ctx context.Context, client datastream.DS, logger log.Interface, streamID int64) error {
+func deactivateStream(ctx context.Context, d *schema.ResourceData, client datastream.DS, logger log.Interface, streamID int64) error {
 	logger.Debug("deactivating stream")
 	_, err := client.DeactivateStream(ctx, datastream.DeactivateStreamRequest{
 		StreamID: streamID,
@@ -1301,11 +1306,11 @@ func deactivateStream(ctx context.Context, client datastream.DS, logger log.Inte
 	}
 
 	logger.Debugf("waiting for the stream #%d to be deactivated", streamID)
-	_, err = waitForStreamStatusChange(ctx, client, streamID, datastream.ActivationStatusDeactivated)
+	_, err = waitForStreamStatusChange(ctx, d, logger, client, streamID, datastream.ActivationStatusDeactivated)
 	return err
 }
 
-func activateStream(ctx context.Context, client datastream.DS, logger log.Interface, streamID int64) error {
+func activateStream(ctx context.Context, d *schema.ResourceData, client datastream.DS, logger log.Interface, streamID int64) error {
 	logger.Debug("activating stream")
 	_, err := client.ActivateStream(ctx, datastream.ActivateStreamRequest{
 		StreamID: streamID,
@@ -1315,7 +1320,7 @@ func activateStream(ctx context.Context, client datastream.DS, logger log.Interf
 	}
 
 	logger.Debugf("waiting for the stream #%d to be activated", streamID)
-	_, err = waitForStreamStatusChange(ctx, client, streamID, datastream.ActivationStatusActivated)
+	_, err = waitForStreamStatusChange(ctx, d, logger, client, streamID, datastream.ActivationStatusActivated)
 	return err
 }
 
@@ -1352,7 +1357,7 @@ func resourceDatastreamDelete(ctx context.Context, d *schema.ResourceData, m int
 
 	// if stream is activating we have to wait until activation finishes
 	if activationStatus == datastream.ActivationStatusActivating {
-		_, err := waitForStreamStatusChange(ctx, client, streamID, datastream.ActivationStatusActivated)
+		_, err := waitForStreamStatusChange(ctx, d, logger, client, streamID, datastream.ActivationStatusActivated)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -1374,7 +1379,7 @@ func resourceDatastreamDelete(ctx context.Context, d *schema.ResourceData, m int
 
 	// if stream is deactivating phase - wait until it completes
 	if activationStatus == datastream.ActivationStatusDeactivating {
-		_, err := waitForStreamStatusChange(ctx, client, streamID, datastream.ActivationStatusDeactivated)
+		_, err := waitForStreamStatusChange(ctx, d, logger, client, streamID, datastream.ActivationStatusDeactivated)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -1397,7 +1402,7 @@ func resourceDatastreamDelete(ctx context.Context, d *schema.ResourceData, m int
 	return nil
 }
 
-func waitForStreamStatusChange(ctx context.Context, client datastream.DS, streamID int64, expectedStatuses ...datastream.ActivationStatus) (*datastream.ActivationStatus, error) {
+func waitForStreamStatusChange(ctx context.Context, d *schema.ResourceData, logger log.Interface, client datastream.DS, streamID int64, expectedStatuses ...datastream.ActivationStatus) (*datastream.ActivationStatus, error) {
 	expectedStatusesMap := map[datastream.ActivationStatus]bool{}
 	for _, status := range expectedStatuses {
 		expectedStatusesMap[status] = true
@@ -1407,22 +1412,30 @@ func waitForStreamStatusChange(ctx context.Context, client datastream.DS, stream
 		StreamID: streamID,
 	}
 
-	streamDetails, err := client.GetStream(ctx, getStreamReq)
+	var streamDetails *datastream.DetailedStreamVersion
+	checkStatus := func(ctx context.Context) (bool, error) {
+		details, err := client.GetStream(ctx, getStreamReq)
+		if err != nil {
+			return false, err
+		}
+		streamDetails = details
+		return expectedStatusesMap[streamDetails.ActivationStatus], nil
+	}
+
+	done, err := checkStatus(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	_, ok := expectedStatusesMap[streamDetails.ActivationStatus]
-	for ; !ok; _, ok = expectedStatusesMap[streamDetails.ActivationStatus] {
-		select {
-		case <-time.After(PollForActivationStatusChangeInterval):
-			streamDetails, err = client.GetStream(ctx, getStreamReq)
-			if err != nil {
-				return nil, err
+	if !done {
+		poller, err := tools.NewPoller(fmt.Sprintf("stream #%d status change", streamID), d, PollForActivationStatusChangeInterval, PollForActivationStatusChangeMinimum, logger)
+		if err != nil {
+			return nil, err
+		}
+		if err = poller.Poll(ctx, checkStatus); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+				return nil, fmt.Errorf("change status context terminated: %w", err)
 			}
-
-		case <-ctx.Done():
-			return nil, fmt.Errorf("change status context terminated: %w", ctx.Err())
+			return nil, err
 		}
 	}
 
@@ -22,7 +22,7 @@ func TestResourceStream(t *testing.T) {
 	t.Run("lifecycle test", func(t *testing.T) {
 		client := &datastream.Mock{}
 
-		PollForActivationStatusChangeInterval = 1 * time.Millisecond
+		PollForActivationStatusChangeMinimum, PollForActivationStatusChangeInterval = time.Millisecond, time.Millisecond
 
 		streamConfiguration := datastream.StreamConfiguration{
 			ActivateNow: true,
@@ -403,7 +403,7 @@ func TestResourceStream(t *testing.T) {
 }
 
 func TestResourceUpdate(t *testing.T) {
-	PollForActivationStatusChangeInterval = 1 * time.Millisecond
+	PollForActivationStatusChangeMinimum, PollForActivationStatusChangeInterval = time.Millisecond, time.Millisecond
 	tests := map[string]struct {
 		CreateStreamActive bool
 		UpdateStreamActive bool
@@ -64,7 +64,7 @@ func Provider() *schema.Provider {
 			"akamai_dns_record_set":  dataSourceDNSRecordSet(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"akamai_dns_zone":   resourceDNSv2Zone(),
+			"akamai_dns_zone":   akamai.WithProviderDefaults(resourceDNSv2Zone()),
 			"akamai_dns_record": resourceDNSv2Record(),
 		},
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Retry count for save, update and delete
@@ -583,6 +584,15 @@ func getRecordLock(recordType string) *sync.Mutex {
 	return recordCreateLock[recordType]
 }
 
+// lockRecordType locks the record lock of the record type and returns the unlock function, tracing the wait for the lock
+func lockRecordType(ctx context.Context, recordType string) func() {
+	_, span := akamai.StartSpan(ctx, "DNS record lock", attribute.String("dns.record_type", recordType))
+	lock := getRecordLock(recordType)
+	lock.Lock()
+	span.End()
+	return lock.Unlock
+}
+
 func bumpSoaSerial(ctx context.Context, d *schema.ResourceData, meta akamai.OperationMeta, zone, host string, logger log.Interface) (*dns.RecordBody, error) {
 	// Get SOA Record
 	recordset, err := inst.Client(meta).GetRecord(ctx, zone, host, "SOA")
@@ -607,6 +617,9 @@ func bumpSoaSerial(ctx context.Context, d *schema.ResourceData, meta akamai.Oper
 
 // Record op function
 func execFunc(ctx context.Context, meta akamai.OperationMeta, fn string, rec *dns.RecordBody, zone string, rlock bool) error {
+	// the span includes the wait for the zone changelist lock of the client
+	ctx, span := akamai.StartSpan(ctx, fmt.Sprintf("DNS record %s", fn), attribute.String("dns.zone", zone), attribute.Bool("dns.zone_lock", rlock))
+	defer span.End()
 
 	var e error
 	switch fn {
@@ -713,8 +726,7 @@ func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, m inte
 	}
 
 	// serialize record creates of same type
-	getRecordLock(recordType).Lock()
-	defer getRecordLock(recordType).Unlock()
+	defer lockRecordType(ctx, recordType)()
 
 	if recordType == "SOA" {
 		logger.Debug("Attempting to create a SOA record")
@@ -729,8 +741,7 @@ func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, m inte
 				return diag.Errorf("%v: %s", tools.ErrValueSet, err.Error())
 			}
 		} else {
-			apiError, ok := err.(*dns.Error)
-			if ok && apiError.StatusCode == http.StatusNotFound {
+			if akamai.IsAPINotFound(err) {
 				logger.Debug("SOA Record not found. Initialize serial")
 				if err := d.Set("serial", 1); err != nil {
 					return diag.Errorf("%v: %s", tools.ErrValueSet, err.Error())
@@ -759,8 +770,7 @@ func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, m inte
 	rdata := make([]string, 0)
 	recordSet, e := inst.Client(meta).GetRecord(ctx, zone, host, recordType)
 	if e != nil {
-		apiError, ok := e.(*dns.Error)
-		if !ok || apiError.StatusCode != http.StatusNotFound {
+		if !akamai.IsAPINotFound(e) {
 			return append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  fmt.Sprintf("failed looking up %s records for %s", recordType, host),
@@ -884,15 +894,13 @@ func resourceDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	}
 
 	// serialize record updates of same type
-	getRecordLock(recordType).Lock()
-	defer getRecordLock(recordType).Unlock()
+	defer lockRecordType(ctx, recordType)()
 
 	if recordType == "SOA" {
 		// need to get current serial and increment as part of update
 		record, e := inst.Client(meta).GetRecord(ctx, zone, host, recordType)
 		if e != nil {
-			apiError, ok := e.(*dns.Error)
-			if !ok || apiError.StatusCode != http.StatusNotFound {
+			if !akamai.IsAPINotFound(e) {
 				logger.Error(fmt.Sprintf("UPDATE Read [ERROR] %s", e.Error()))
 				return diag.FromErr(e)
 			}
@@ -930,8 +938,7 @@ func resourceDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	rdata := make([]string, 0, 0)
 	recordset, e := inst.Client(meta).GetRecord(ctx, zone, host, recordType)
 	if e != nil {
-		apiError, ok := e.(*dns.Error)
-		if !ok || apiError.StatusCode != http.StatusNotFound {
+		if !akamai.IsAPINotFound(e) {
 			return append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  fmt.Sprintf("Error looking up recordset %s", host),
@@ -1048,22 +1055,17 @@ func resourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, m interf
 	}).Info("READ Searching for zone records")
 
 	record, e := inst.Client(meta).GetRecord(ctx, zone, host, recordType)
-	if e != nil {
-		apiError, ok := e.(*dns.Error)
-		if !ok || apiError.StatusCode != http.StatusNotFound {
-			logger.Errorf("RECORD READ. error looking up %s records for %q: %s", recordType, host, e.Error())
-			return append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Recordset read failure",
-				Detail:   e.Error(),
-			})
-		}
+	if akamai.RemoveIfNotFound(d, e, logger) {
+		// record doesn't exist
+		return nil
 	}
 	if e != nil {
-		// record doesn't exist
-		logger.Errorf("READ Record Not Found: %s", e.Error())
-		d.SetId("")
-		return diag.Errorf("Record not found")
+		logger.Errorf("RECORD READ. error looking up %s records for %q: %s", recordType, host, e.Error())
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Recordset read failure",
+			Detail:   e.Error(),
+		})
 	}
 
 	logger.Debugf("RECORD READ [%v] [%s] [%s] [%s] ", record, zone, host, recordType)
@@ -1231,8 +1233,7 @@ func resourceDNSRecordImport(d *schema.ResourceData, m interface{}) ([]*schema.R
 
 	recordset, e := inst.Client(meta).GetRecord(ctx, zone, recordName, recordType)
 	if e != nil {
-		apiError, ok := e.(*dns.Error)
-		if !ok || apiError.StatusCode != http.StatusNotFound {
+		if !akamai.IsAPINotFound(e) {
 			logger.Debugf("IMPORT Record read failed for record [%s] [%s] [%s] ", zone, recordName, recordType)
 			d.SetId("")
 			return []*schema.ResourceData{d}, e
@@ -1317,8 +1318,7 @@ func resourceDNSRecordDelete(ctx context.Context, d *schema.ResourceData, m inte
 	logger.Infof("Record Delete. zone: %s, host: %s, recordtype: %s", zone, host, recordType)
 	logger.Info("Record Delete.")
 	// serialize record updates of same type
-	getRecordLock(recordType).Lock()
-	defer getRecordLock(recordType).Unlock()
+	defer lockRecordType(ctx, recordType)()
 
 	target, err := tools.GetListValue("target", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -1531,8 +1531,7 @@ func newRecordCreate(ctx context.Context, meta akamai.OperationMeta, d *schema.R
 		if e != nil {
 			logger.Debugf("MX Get Error Type: %T", e)
 			logger.Debugf("BIND MX Error: %v", e)
-			apiError, ok := e.(*dns.Error)
-			if !ok || apiError.StatusCode != http.StatusNotFound {
+			if !akamai.IsAPINotFound(e) {
 				// failure other than not found
 				return dns.RecordBody{}, fmt.Errorf(e.Error())
 			}
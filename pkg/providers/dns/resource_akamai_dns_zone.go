@@ -176,8 +176,7 @@ func resourceDNSv2ZoneCreate(ctx context.Context, d *schema.ResourceData, m inte
 			Detail:   fmt.Sprintf("Zone create failure. Zone %s exists", hostname),
 		})
 	}
-	apiError, ok := e.(*dns.Error)
-	if !ok || apiError.StatusCode != http.StatusNotFound {
+	if !akamai.IsAPINotFound(e) {
 		logger.Errorf("Create[ERROR] %w", e)
 		return append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -264,12 +263,10 @@ func resourceDNSv2ZoneRead(ctx context.Context, d *schema.ResourceData, m interf
 	// find the zone first
 	logger.Debugf("Searching for zone [%s]", hostname)
 	zone, e := inst.Client(meta).GetZone(ctx, hostname)
+	if akamai.RemoveIfNotFound(d, e, logger) {
+		return nil
+	}
 	if e != nil {
-		apiError, ok := e.(*dns.Error)
-		if ok && apiError.StatusCode == http.StatusNotFound {
-			d.SetId("")
-			return diag.FromErr(e)
-		}
 		return append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Zone read failure",
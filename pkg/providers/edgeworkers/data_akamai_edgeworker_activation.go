@@ -68,7 +68,7 @@ func dataEdgeWorkerActivationRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("could not get network: %s", err)
 	}
 
-	activation, err := getCurrentActivation(ctx, client, edgeworkerID, network, false)
+	activation, err := getCurrentActivation(ctx, d, logger, client, edgeworkerID, network, false)
 	if err != nil {
 		return diag.Errorf("could not get current activation: %s", err)
 	}
@@ -51,9 +51,9 @@ func Provider() *schema.Provider {
 			"akamai_edgeworker_activation":      dataSourceEdgeWorkerActivation(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"akamai_edgekv":                 resourceEdgeKV(),
+			"akamai_edgekv":                 akamai.WithProviderDefaults(resourceEdgeKV()),
 			"akamai_edgeworkers_activation": resourceEdgeworkersActivation(),
-			"akamai_edgeworker":             resourceEdgeWorker(),
+			"akamai_edgeworker":             akamai.WithProviderDefaults(resourceEdgeWorker()),
 		},
 	}
 	return provider
@@ -208,6 +208,9 @@ func resourceEdgeKVRead(ctx context.Context, rd *schema.ResourceData, m interfac
 		Name:    name,
 	})
 	if err != nil {
+		if akamai.RemoveIfNotFound(rd, err, logger) {
+			return nil
+		}
 		logger.Errorf("EdgeKV namespace '%s' not found in network '%s': %s", name, network, err.Error())
 		return diag.FromErr(err)
 	}
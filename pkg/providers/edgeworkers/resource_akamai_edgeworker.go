@@ -183,6 +183,9 @@ func resourceEdgeWorkerRead(ctx context.Context, d *schema.ResourceData, m inter
 		EdgeWorkerID: edgeWorkerID,
 	})
 	if err != nil {
+		if akamai.RemoveIfNotFound(d, err, logger) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 	versions, err := client.ListEdgeWorkerVersions(ctx, edgeworkers.ListEdgeWorkerVersionsRequest{
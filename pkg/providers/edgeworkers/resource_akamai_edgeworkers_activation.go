@@ -13,6 +13,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -59,6 +60,8 @@ func resourceEdgeworkersActivationSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "A unique identifier of the activation",
 		},
+		tools.PollIntervalKey: tools.PollIntervalSchema(activationPollInterval),
+		tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 	}
 }
 
@@ -105,7 +108,7 @@ func resourceEdgeworkersActivationRead(ctx context.Context, rd *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	activation, err := getCurrentActivation(ctx, client, edgeworkerID, network, false)
+	activation, err := getCurrentActivation(ctx, rd, logger, client, edgeworkerID, network, false)
 	if err != nil {
 		return diag.Errorf("%s read: %s", ErrEdgeworkerActivation, err)
 	}
@@ -188,7 +191,7 @@ func resourceEdgeworkersActivationDelete(ctx context.Context, rd *schema.Resourc
 		}
 	}
 
-	if _, err := waitForEdgeworkerDeactivation(ctx, client, edgeworkerID, deactivation.DeactivationID); err != nil {
+	if _, err := waitForEdgeworkerDeactivation(ctx, rd, logger, client, edgeworkerID, deactivation.DeactivationID); err != nil {
 		if errors.Is(err, ErrEdgeworkerDeactivationTimeout) {
 			rd.SetId("")
 			return append(tools.DiagWarningf("%s: %s", ErrEdgeworkerDeactivation, err), tools.DiagWarningf("Resource has been removed from the state, but deactivation is still ongoing on the server")...)
@@ -233,6 +236,7 @@ func resourceEdgeworkersActivationImport(_ context.Context, rd *schema.ResourceD
 }
 
 func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{}, client edgeworkers.Edgeworkers) diag.Diagnostics {
+	logger := akamai.Meta(m).Log("Edgeworkers", "upsertActivation")
 	edgeworkerID, err := tools.GetIntValue("edgeworker_id", rd)
 	if err != nil {
 		return diag.FromErr(err)
@@ -258,7 +262,7 @@ func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{
 		return diag.Errorf(`%s: version '%s' is not valid for edgeworker with id=%d`, ErrEdgeworkerActivation, version, edgeworkerID)
 	}
 
-	currentActivation, err := getCurrentActivation(ctx, client, edgeworkerID, network, true)
+	currentActivation, err := getCurrentActivation(ctx, rd, logger, client, edgeworkerID, network, true)
 	if err != nil {
 		return diag.Errorf("%s: %s", ErrEdgeworkerActivation, err.Error())
 	}
@@ -280,7 +284,7 @@ func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{
 		return diag.Errorf("%s: %s", ErrEdgeworkerActivation, err.Error())
 	}
 
-	if _, err := waitForEdgeworkerActivation(ctx, client, edgeworkerID, activation.ActivationID); err != nil {
+	if _, err := waitForEdgeworkerActivation(ctx, rd, logger, client, edgeworkerID, activation.ActivationID); err != nil {
 		return diag.Errorf("%s: %s", ErrEdgeworkerActivation, err.Error())
 	}
 
@@ -288,7 +292,7 @@ func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{
 	return resourceEdgeworkersActivationRead(ctx, rd, m)
 }
 
-func getCurrentActivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID int, network string, waitForDeactivation bool) (*edgeworkers.Activation, error) {
+func getCurrentActivation(ctx context.Context, rd tools.ResourceDataFetcher, logger log.Interface, client edgeworkers.Edgeworkers, edgeworkerID int, network string, waitForDeactivation bool) (*edgeworkers.Activation, error) {
 	activationsResp, err := client.ListActivations(ctx, edgeworkers.ListActivationsRequest{
 		EdgeWorkerID: edgeworkerID,
 	})
@@ -306,7 +310,7 @@ func getCurrentActivation(ctx context.Context, client edgeworkers.Edgeworkers, e
 	case activationStatusComplete:
 		// do nothing
 	case activationStatusPresubmit, activationStatusPending, activationStatusInProgress:
-		latestActivation, err = waitForEdgeworkerActivation(ctx, client, edgeworkerID, latestActivation.ActivationID)
+		latestActivation, err = waitForEdgeworkerActivation(ctx, rd, logger, client, edgeworkerID, latestActivation.ActivationID)
 		if err != nil {
 			return nil, err
 		}
@@ -315,7 +319,7 @@ func getCurrentActivation(ctx context.Context, client edgeworkers.Edgeworkers, e
 		return nil, nil
 	}
 
-	latestDeactivation, err := getLatestCompletedDeactivation(ctx, client, edgeworkerID, latestActivation.Version, network, waitForDeactivation)
+	latestDeactivation, err := getLatestCompletedDeactivation(ctx, rd, logger, client, edgeworkerID, latestActivation.Version, network, waitForDeactivation)
 	if err != nil {
 		return nil, err
 	}
@@ -352,7 +356,7 @@ func getDeactivationsByVersionAndNetwork(ctx context.Context, client edgeworkers
 	return sortDeactivationsByDate(filterDeactivationsByNetwork(deactivationsResp.Deactivations, network)), nil
 }
 
-func getLatestCompletedDeactivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID int, version, network string, wait bool) (*edgeworkers.Deactivation, error) {
+func getLatestCompletedDeactivation(ctx context.Context, rd tools.ResourceDataFetcher, logger log.Interface, client edgeworkers.Edgeworkers, edgeworkerID int, version, network string, wait bool) (*edgeworkers.Deactivation, error) {
 	deactivations, err := getDeactivationsByVersionAndNetwork(ctx, client, edgeworkerID, version, network)
 	if err != nil {
 		return nil, err
@@ -364,7 +368,7 @@ func getLatestCompletedDeactivation(ctx context.Context, client edgeworkers.Edge
 	for i := range deactivations {
 		d := &deactivations[i]
 		if wait && (d.Status == activationStatusPresubmit || d.Status == activationStatusPending || d.Status == activationStatusInProgress) {
-			d, err = waitForEdgeworkerDeactivation(ctx, client, edgeworkerID, d.DeactivationID)
+			d, err = waitForEdgeworkerDeactivation(ctx, rd, logger, client, edgeworkerID, d.DeactivationID)
 			if err != nil {
 				return nil, err
 			}
@@ -385,72 +389,95 @@ func versionExists(version string, versions []edgeworkers.EdgeWorkerVersion) boo
 	return false
 }
 
-func waitForEdgeworkerActivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID, activationID int) (*edgeworkers.Activation, error) {
-	activation, err := client.GetActivation(ctx, edgeworkers.GetActivationRequest{
-		EdgeWorkerID: edgeworkerID,
-		ActivationID: activationID,
-	})
-	if err != nil {
+func waitForEdgeworkerActivation(ctx context.Context, rd tools.ResourceDataFetcher, logger log.Interface, client edgeworkers.Edgeworkers, edgeworkerID, activationID int) (*edgeworkers.Activation, error) {
+	var activation *edgeworkers.Activation
+	checkStatus := func(ctx context.Context) (bool, error) {
+		act, err := client.GetActivation(ctx, edgeworkers.GetActivationRequest{
+			EdgeWorkerID: edgeworkerID,
+			ActivationID: activationID,
+		})
+		if err != nil {
+			return false, err
+		}
+		activation = act
+		return isOperationDone(activation.Status, ErrEdgeworkerActivationFailure)
+	}
+
+	err := pollEdgeworkerOperation(ctx, rd, logger, fmt.Sprintf("edgeworker %d activation %d", edgeworkerID, activationID), checkStatus)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, ErrEdgeworkerActivationTimeout
+	case errors.Is(err, context.Canceled):
+		return nil, ErrEdgeworkerActivationCancelled
+	case err != nil:
 		return nil, err
 	}
-	for activation != nil && activation.Status != activationStatusComplete {
-		if activation.Status != activationStatusPresubmit && activation.Status != activationStatusPending && activation.Status != activationStatusInProgress {
-			return nil, ErrEdgeworkerActivationFailure
-		}
-		select {
-		case <-time.After(tools.MaxDuration(activationPollInterval, activationPollMinimum)):
-			activation, err = client.GetActivation(ctx, edgeworkers.GetActivationRequest{
-				EdgeWorkerID: edgeworkerID,
-				ActivationID: activationID,
-			})
-			if err != nil {
-				return nil, err
-			}
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrEdgeworkerActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrEdgeworkerActivationCancelled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrEdgeworkerActivationContextTerminated, ctx.Err())
+	return activation, nil
+}
+
+func waitForEdgeworkerDeactivation(ctx context.Context, rd tools.ResourceDataFetcher, logger log.Interface, client edgeworkers.Edgeworkers, edgeworkerID, deactivationID int) (*edgeworkers.Deactivation, error) {
+	var deactivation *edgeworkers.Deactivation
+	checkStatus := func(ctx context.Context) (bool, error) {
+		deact, err := client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
+			EdgeWorkerID:   edgeworkerID,
+			DeactivationID: deactivationID,
+		})
+		if err != nil {
+			return false, err
 		}
+		deactivation = deact
+		return isOperationDone(deactivation.Status, ErrEdgeworkerDeactivationFailure)
 	}
-	return activation, nil
+
+	err := pollEdgeworkerOperation(ctx, rd, logger, fmt.Sprintf("edgeworker %d deactivation %d", edgeworkerID, deactivationID), checkStatus)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, ErrEdgeworkerDeactivationTimeout
+	case errors.Is(err, context.Canceled):
+		return nil, ErrEdgeworkerDeactivationCancelled
+	case err != nil:
+		return nil, err
+	}
+	return deactivation, nil
 }
 
-func waitForEdgeworkerDeactivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID, deactivationID int) (*edgeworkers.Deactivation, error) {
-	deactivation, err := client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
-		EdgeWorkerID:   edgeworkerID,
-		DeactivationID: deactivationID,
-	})
+// pollEdgeworkerOperation checks the status of the operation once and polls it until it is done, if it is still in progress
+//
+// status checks failing with transient errors are retried, so that a single failed request does not abort a long operation
+func pollEdgeworkerOperation(ctx context.Context, rd tools.ResourceDataFetcher, logger log.Interface, name string, checkStatus tools.PollFunc) error {
+	checkStatus = retryTransientPollFunc(checkStatus)
+	done, err := checkStatus(ctx)
+	if err != nil || done {
+		return err
+	}
+	poller, err := tools.NewPoller(name, rd, activationPollInterval, activationPollMinimum, logger)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	for deactivation != nil && deactivation.Status != activationStatusComplete {
-		if deactivation.Status != activationStatusPresubmit && deactivation.Status != activationStatusPending && deactivation.Status != activationStatusInProgress {
-			return nil, ErrEdgeworkerDeactivationFailure
-		}
-		select {
-		case <-time.After(tools.MaxDuration(activationPollInterval, activationPollMinimum)):
-			deactivation, err = client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
-				EdgeWorkerID:   edgeworkerID,
-				DeactivationID: deactivationID,
-			})
-			if err != nil {
-				return nil, err
-			}
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrEdgeworkerDeactivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrEdgeworkerDeactivationCancelled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrEdgeworkerDeactivationContextTerminated, ctx.Err())
-		}
+	return poller.Poll(ctx, checkStatus)
+}
+
+func retryTransientPollFunc(checkStatus tools.PollFunc) tools.PollFunc {
+	return func(ctx context.Context) (bool, error) {
+		var done bool
+		err := akamai.RetryTransient(ctx, akamai.PollRetryAttempts, akamai.PollRetryInterval, func() error {
+			var err error
+			done, err = checkStatus(ctx)
+			return err
+		})
+		return done, err
 	}
-	return deactivation, nil
+}
+
+// isOperationDone returns true once the activation or deactivation is complete and failure once it is neither complete nor in progress
+func isOperationDone(status string, failure error) (bool, error) {
+	switch status {
+	case activationStatusComplete:
+		return true, nil
+	case activationStatusPresubmit, activationStatusPending, activationStatusInProgress:
+		return false, nil
+	}
+	return false, failure
 }
 
 func filterActivationsByNetwork(acts []edgeworkers.Activation, net string) (activations []edgeworkers.Activation) {
@@ -2,6 +2,7 @@ package edgeworkers
 
 import (
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
 	"time"
@@ -183,6 +184,43 @@ func TestResourceEdgeworkersActivation(t *testing.T) {
 				},
 			},
 		},
+		"create and read activation - transient polling error is retried": {
+			init: func(m *edgeworkers.Mock) {
+				net := edgeworkers.ActivationNetworkStaging
+				version := "test"
+				activationID := 1
+
+				// version verification
+				expectListEdgeWorkerVersions(m, edgeworkerID, []edgeworkers.EdgeWorkerVersion{
+					*createStubEdgeworkerVersion(edgeworkerID, version),
+				}, nil)
+
+				// get current activation
+				expectListActivations(m, edgeworkerID, "", []edgeworkers.Activation{}, nil).Once()
+
+				// activate
+				expectActivateVersion(m, edgeworkerID, activationID, net, version, nil).Once()
+				expectGetActivation(m, edgeworkerID, activationID, net, version, "", &edgeworkers.Error{Status: http.StatusServiceUnavailable}).Once()
+				expectGetActivation(m, edgeworkerID, activationID, net, version, activationStatusComplete, nil).Once()
+
+				// read
+				expectFullRead(m, edgeworkerID, version, []edgeworkers.Activation{
+					*createStubActivation(edgeworkerID, activationID, net, version, activationStatusComplete, ""),
+				}, []edgeworkers.Deactivation{}, 2)
+
+				// test cleanup - destroy
+				expectFullDeactivation(m, edgeworkerID, 1, net, version)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString(fmt.Sprintf("%s/edgeworkers_activation_version_test_stag.tf", workdir)),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_edgeworkers_activation.test", "activation_id", "1"),
+						resource.TestCheckResourceAttr("akamai_edgeworkers_activation.test", "version", "test"),
+					),
+				},
+			},
+		},
 		"create and read activation - version is already being activated, wait for activation": {
 			init: func(m *edgeworkers.Mock) {
 				net := edgeworkers.ActivationNetworkStaging
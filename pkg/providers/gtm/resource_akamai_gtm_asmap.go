@@ -33,6 +33,8 @@ func resourceGTMv1ASmap() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(PropagationPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -182,12 +184,13 @@ func resourceGTMv1ASmapCreate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("asMap Create completed")
 		} else {
 			if err == nil {
-				logger.Infof("asMap Create pending")
+				logger.Warnf("asMap Create pending")
+				diags = append(diags, propagationPendingWarning("asMap Create"))
 			} else {
 				logger.Errorf("asMap Create failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -203,7 +206,7 @@ func resourceGTMv1ASmapCreate(ctx context.Context, d *schema.ResourceData, m int
 	asMapID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
 	logger.Debugf("Generated asMap Id: %s", asMapID)
 	d.SetId(asMapID)
-	return resourceGTMv1ASmapRead(ctx, d, m)
+	return append(diags, resourceGTMv1ASmapRead(ctx, d, m)...)
 
 }
 
@@ -225,6 +228,9 @@ func resourceGTMv1ASmapRead(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 	as, err := inst.Client(meta).GetAsMap(ctx, asMap, domain)
+	if akamai.RemoveIfNotFound(d, err, logger) {
+		return nil
+	}
 	if err != nil {
 		logger.Errorf("asMap Read error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -291,12 +297,13 @@ func resourceGTMv1ASmapUpdate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("ASmap Update completed")
 		} else {
 			if err == nil {
-				logger.Infof("ASmap Update pending")
+				logger.Warnf("ASmap Update pending")
+				diags = append(diags, propagationPendingWarning("ASmap Update"))
 			} else {
 				logger.Errorf("ASmap Update failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -308,7 +315,7 @@ func resourceGTMv1ASmapUpdate(ctx context.Context, d *schema.ResourceData, m int
 		}
 	}
 
-	return resourceGTMv1ASmapRead(ctx, d, m)
+	return append(diags, resourceGTMv1ASmapRead(ctx, d, m)...)
 }
 
 // Import GTM ASmap.
@@ -394,12 +401,13 @@ func resourceGTMv1ASmapDelete(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("asMap Delete completed")
 		} else {
 			if err == nil {
-				logger.Infof("asMap Delete pending")
+				logger.Warnf("asMap Delete pending")
+				diags = append(diags, propagationPendingWarning("asMap Delete"))
 			} else {
 				logger.Errorf("asMap Delete failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -413,7 +421,7 @@ func resourceGTMv1ASmapDelete(ctx context.Context, d *schema.ResourceData, m int
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new asMap object from asMap data
@@ -32,6 +32,8 @@ func resourceGTMv1Cidrmap() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(PropagationPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -137,12 +139,13 @@ func resourceGTMv1CidrMapCreate(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("cidrMap Create completed")
 		} else {
 			if err == nil {
-				logger.Infof("cidrMap Create pending")
+				logger.Warnf("cidrMap Create pending")
+				diags = append(diags, propagationPendingWarning("cidrMap Create"))
 			} else {
 				logger.Errorf("cidrMap Create failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -158,7 +161,7 @@ func resourceGTMv1CidrMapCreate(ctx context.Context, d *schema.ResourceData, m i
 	cidrMapID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
 	logger.Debugf("Generated cidrMap resource Id: %s", cidrMapID)
 	d.SetId(cidrMapID)
-	return resourceGTMv1CidrMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1CidrMapRead(ctx, d, m)...)
 
 }
 
@@ -181,6 +184,9 @@ func resourceGTMv1CidrMapRead(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 	cidr, err := inst.Client(meta).GetCidrMap(ctx, cidrMap, domain)
+	if akamai.RemoveIfNotFound(d, err, logger) {
+		return nil
+	}
 	if err != nil {
 		logger.Errorf("cidrMap Read error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -246,12 +252,13 @@ func resourceGTMv1CidrMapUpdate(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("cidrMap Update completed")
 		} else {
 			if err == nil {
-				logger.Infof("cidrMap Update pending")
+				logger.Warnf("cidrMap Update pending")
+				diags = append(diags, propagationPendingWarning("cidrMap Update"))
 			} else {
 				logger.Errorf("cidrMap Update failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -263,7 +270,7 @@ func resourceGTMv1CidrMapUpdate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
-	return resourceGTMv1CidrMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1CidrMapRead(ctx, d, m)...)
 }
 
 // Import GTM CidrMap.
@@ -349,12 +356,13 @@ func resourceGTMv1CidrMapDelete(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("CidrMap Delete completed")
 		} else {
 			if err == nil {
-				logger.Infof("cidrMap Delete pending")
+				logger.Warnf("cidrMap Delete pending")
+				diags = append(diags, propagationPendingWarning("cidrMap Delete"))
 			} else {
 				logger.Errorf("cidrMap Delete failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -368,7 +376,7 @@ func resourceGTMv1CidrMapDelete(ctx context.Context, d *schema.ResourceData, m i
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new cidrMap object from cidrMap data
@@ -37,6 +37,8 @@ func resourceGTMv1Datacenter() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(PropagationPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 			"nickname": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -212,12 +214,13 @@ func resourceGTMv1DatacenterCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("Datacenter Create completed")
 		} else {
 			if err == nil {
-				logger.Infof("Datacenter Create pending")
+				logger.Warnf("Datacenter Create pending")
+				diags = append(diags, propagationPendingWarning("Datacenter Create"))
 			} else {
 				logger.Errorf("Datacenter Create failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -233,7 +236,7 @@ func resourceGTMv1DatacenterCreate(ctx context.Context, d *schema.ResourceData,
 	datacenterID := fmt.Sprintf("%s:%d", domain, cStatus.Resource.DatacenterId)
 	logger.Debugf("Generated DC resource ID: %s", datacenterID)
 	d.SetId(datacenterID)
-	return resourceGTMv1DatacenterRead(ctx, d, m)
+	return append(diags, resourceGTMv1DatacenterRead(ctx, d, m)...)
 
 }
 
@@ -261,6 +264,9 @@ func resourceGTMv1DatacenterRead(ctx context.Context, d *schema.ResourceData, m
 		})
 	}
 	dc, err := inst.Client(meta).GetDatacenter(ctx, dcID, domain)
+	if akamai.RemoveIfNotFound(d, err, logger) {
+		return nil
+	}
 	if err != nil {
 		logger.Errorf("Datacenter Read failed: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -332,12 +338,13 @@ func resourceGTMv1DatacenterUpdate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("Datacenter Update completed")
 		} else {
 			if err == nil {
-				logger.Infof("Datacenter Update pending")
+				logger.Warnf("Datacenter Update pending")
+				diags = append(diags, propagationPendingWarning("Datacenter Update"))
 			} else {
 				logger.Errorf("Datacenter Update failed [%s]", err.Error())
 				return diag.FromErr(fmt.Errorf("Datacenter Update failed [%s]", err.Error()))
@@ -345,7 +352,7 @@ func resourceGTMv1DatacenterUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	return resourceGTMv1DatacenterRead(ctx, d, m)
+	return append(diags, resourceGTMv1DatacenterRead(ctx, d, m)...)
 }
 
 func resourceGTMv1DatacenterImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
@@ -437,12 +444,13 @@ func resourceGTMv1DatacenterDelete(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("Datacenter Delete completed")
 		} else {
 			if err == nil {
-				logger.Infof("Datacenter Delete pending")
+				logger.Warnf("Datacenter Delete pending")
+				diags = append(diags, propagationPendingWarning("Datacenter Delete"))
 			} else {
 				logger.Errorf("Datacenter Delete failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -456,7 +464,7 @@ func resourceGTMv1DatacenterDelete(ctx context.Context, d *schema.ResourceData,
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new datacenter object from resource data
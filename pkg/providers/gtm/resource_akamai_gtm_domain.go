@@ -23,6 +23,15 @@ import (
 // HashiAcc is Hack for Hashicorp Acceptance Tests
 var HashiAcc = false
 
+var (
+	// PropagationPollInterval is the default interval for polling the propagation status of a domain change
+	PropagationPollInterval = 5 * time.Second
+	// PropagationPollMinimum is the minimum interval for polling the propagation status of a domain change
+	PropagationPollMinimum = time.Second
+	// PropagationPollTimeout is the default time to wait for the propagation of a domain change
+	PropagationPollTimeout = 300 * time.Second
+)
+
 func resourceGTMv1Domain() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceGTMv1DomainCreate,
@@ -50,6 +59,8 @@ func resourceGTMv1Domain() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(PropagationPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -285,12 +296,13 @@ func resourceGTMv1DomainCreate(ctx context.Context, d *schema.ResourceData, m in
 		}
 
 		if waitOnComplete {
-			done, err := waitForCompletion(ctx, dname, m)
+			done, err := waitForCompletion(ctx, d, dname, m)
 			if done {
 				logger.Infof("Domain Create completed")
 			} else {
 				if err == nil {
-					logger.Infof("Domain Create pending")
+					logger.Warnf("Domain Create pending")
+					diags = append(diags, propagationPendingWarning("Domain Create"))
 				} else {
 					logger.Errorf("Domain Create failed [%s]", err.Error())
 					return append(diags, diag.Diagnostic{
@@ -304,7 +316,7 @@ func resourceGTMv1DomainCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	// Give terraform the ID
 	d.SetId(dname)
-	return resourceGTMv1DomainRead(ctx, d, m)
+	return append(diags, resourceGTMv1DomainRead(ctx, d, m)...)
 
 }
 
@@ -323,6 +335,9 @@ func resourceGTMv1DomainRead(ctx context.Context, d *schema.ResourceData, m inte
 	var diags diag.Diagnostics
 	// retrieve the domain
 	dom, err := inst.Client(meta).GetDomain(ctx, d.Id())
+	if akamai.RemoveIfNotFound(d, err, logger) {
+		return nil
+	}
 	if err != nil {
 		logger.Errorf("Domain Read error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -398,12 +413,13 @@ func resourceGTMv1DomainUpdate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, d.Id(), m)
+		done, err := waitForCompletion(ctx, d, d.Id(), m)
 		if done {
 			logger.Infof("Domain Update completed")
 		} else {
 			if err == nil {
-				logger.Infof("Domain Update pending")
+				logger.Warnf("Domain Update pending")
+				diags = append(diags, propagationPendingWarning("Domain Update"))
 			} else {
 				logger.Errorf("Domain Update failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -416,7 +432,7 @@ func resourceGTMv1DomainUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	}
 
-	return resourceGTMv1DomainRead(ctx, d, m)
+	return append(diags, resourceGTMv1DomainRead(ctx, d, m)...)
 
 }
 
@@ -488,12 +504,13 @@ func resourceGTMv1DomainDelete(ctx context.Context, d *schema.ResourceData, m in
 		}
 
 		if waitOnComplete {
-			done, err := waitForCompletion(ctx, d.Id(), m)
+			done, err := waitForCompletion(ctx, d, d.Id(), m)
 			if done {
 				logger.Infof("Domain Delete completed")
 			} else {
 				if err == nil {
-					logger.Infof("Domain Delete pending")
+					logger.Warnf("Domain Delete pending")
+					diags = append(diags, propagationPendingWarning("Domain Delete"))
 				} else {
 					logger.Errorf("Domain Delete failed [%s]", err.Error())
 					return append(diags, diag.Diagnostic{
@@ -506,7 +523,7 @@ func resourceGTMv1DomainDelete(ctx context.Context, d *schema.ResourceData, m in
 		}
 	}
 	d.SetId("")
-	return nil
+	return diags
 
 }
 
@@ -766,22 +783,12 @@ func populateTerraformState(d *schema.ResourceData, dom *gtm.Domain, m interface
 	}
 }
 
-// Util function to wait for change deployment. return true if complete. false if not - error or nil (timeout)
-func waitForCompletion(ctx context.Context, domain string, m interface{}) (bool, error) {
+// waitForCompletion waits for change deployment. return true if complete. false if not - error or nil (poll timeout)
+func waitForCompletion(ctx context.Context, d *schema.ResourceData, domain string, m interface{}) (bool, error) {
 	meta := akamai.Meta(m)
 	logger := meta.Log("Akamai GTMv1", "waitForCompletion")
 
-	var defaultInterval = 5 * time.Second
-	var defaultTimeout = 300 * time.Second
-	var sleepInterval = defaultInterval // seconds. TODO:Should be configurable by user ...
-	var sleepTimeout = defaultTimeout   // seconds. TODO: Should be configurable by user ...
-	if HashiAcc {
-		// Override for ACC tests
-		sleepTimeout = sleepInterval
-	}
-	logger.Debugf("WAIT: Sleep Interval [%v]", sleepInterval/time.Second)
-	logger.Debugf("WAIT: Sleep Timeout [%v]", sleepTimeout/time.Second)
-	for {
+	checkStatus := func(ctx context.Context) (bool, error) {
 		propStat, err := inst.Client(meta).GetDomainStatus(ctx, domain)
 		if err != nil {
 			return false, err
@@ -795,15 +802,45 @@ func waitForCompletion(ctx context.Context, domain string, m interface{}) (bool,
 			logger.Debugf("WAIT: Return DENIED")
 			return false, fmt.Errorf(propStat.Message)
 		case "PENDING":
-			if sleepTimeout <= 0 {
-				logger.Debugf("WAIT: Return TIMED OUT")
-				return false, nil
-			}
-			time.Sleep(sleepInterval)
-			sleepTimeout -= sleepInterval
-			logger.Debugf("WAIT: Sleep Time Remaining [%v]", sleepTimeout/time.Second)
+			return false, nil
 		default:
 			return false, fmt.Errorf("unknown propagationStatus while waiting for change completion") // don't know how/why we would have broken out.
 		}
 	}
+
+	done, err := checkStatus(ctx)
+	if err != nil || done {
+		return done, err
+	}
+
+	poller, err := tools.NewPoller(fmt.Sprintf("GTM domain %s propagation", domain), d, PropagationPollInterval, PropagationPollMinimum, logger)
+	if err != nil {
+		return false, err
+	}
+	if poller.Timeout == 0 {
+		poller.Timeout = PropagationPollTimeout
+	}
+	if HashiAcc {
+		// Override for ACC tests
+		poller.Timeout = poller.Interval
+	}
+	logger.Debugf("WAIT: Sleep Interval [%v]", poller.Interval)
+	logger.Debugf("WAIT: Sleep Timeout [%v]", poller.Timeout)
+	if err := poller.Poll(ctx, checkStatus); err != nil {
+		if errors.Is(err, tools.ErrPollTimeout) {
+			logger.Debugf("WAIT: Return TIMED OUT")
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// propagationPendingWarning reports the change which did not propagate within the poll timeout
+func propagationPendingWarning(operation string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s pending", operation),
+		Detail:   "The change was not propagated to the GTM domain within the poll timeout, its propagation is still pending. Increase poll_timeout to wait longer",
+	}
 }
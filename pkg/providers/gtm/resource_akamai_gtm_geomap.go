@@ -32,6 +32,8 @@ func resourceGTMv1Geomap() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(PropagationPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -141,12 +143,13 @@ func resourceGTMv1GeomapCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("geoMap Create completed")
 		} else {
 			if err == nil {
-				logger.Infof("geoMap Create pending")
+				logger.Warnf("geoMap Create pending")
+				diags = append(diags, propagationPendingWarning("geoMap Create"))
 			} else {
 				logger.Errorf("geoMap Create failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -163,7 +166,7 @@ func resourceGTMv1GeomapCreate(ctx context.Context, d *schema.ResourceData, m in
 	geoMapID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
 	logger.Debugf("Generated geoMap resource ID: %s", geoMapID)
 	d.SetId(geoMapID)
-	return resourceGTMv1GeomapRead(ctx, d, m)
+	return append(diags, resourceGTMv1GeomapRead(ctx, d, m)...)
 
 }
 
@@ -186,6 +189,9 @@ func resourceGTMv1GeomapRead(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(err)
 	}
 	geo, err := inst.Client(meta).GetGeoMap(ctx, geoMap, domain)
+	if akamai.RemoveIfNotFound(d, err, logger) {
+		return nil
+	}
 	if err != nil {
 		logger.Errorf("geoMap Read error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -254,12 +260,13 @@ func resourceGTMv1GeomapUpdate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("geoMap Update completed")
 		} else {
 			if err == nil {
-				logger.Infof("geoMap Update pending")
+				logger.Warnf("geoMap Update pending")
+				diags = append(diags, propagationPendingWarning("geoMap Update"))
 			} else {
 				logger.Errorf("geoMap Update failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -272,7 +279,7 @@ func resourceGTMv1GeomapUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	}
 
-	return resourceGTMv1GeomapRead(ctx, d, m)
+	return append(diags, resourceGTMv1GeomapRead(ctx, d, m)...)
 }
 
 // Import GTM GeoMap.
@@ -362,12 +369,13 @@ func resourceGTMv1GeomapDelete(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("geoMap Delete completed")
 		} else {
 			if err == nil {
-				logger.Infof("geoMap Delete pending")
+				logger.Warnf("geoMap Delete pending")
+				diags = append(diags, propagationPendingWarning("geoMap Delete"))
 			} else {
 				logger.Errorf("geoMap Delete failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -381,7 +389,7 @@ func resourceGTMv1GeomapDelete(ctx context.Context, d *schema.ResourceData, m in
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new geoMap object from geoMap data
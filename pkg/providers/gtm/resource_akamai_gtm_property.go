@@ -35,6 +35,8 @@ func resourceGTMv1Property() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(PropagationPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -428,18 +430,20 @@ func resourceGTMv1PropertyCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(fmt.Errorf(cStatus.Status.Message))
 	}
 
+	var diags diag.Diagnostics
 	waitOnComplete, err := tools.GetBoolValue("wait_on_complete", d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("Property Create completed")
 		} else {
 			if err == nil {
-				logger.Infof("Property Create pending")
+				logger.Warnf("Property Create pending")
+				diags = append(diags, propagationPendingWarning("Property Create"))
 			} else {
 				logger.Errorf("Property Create failed [%s]", err.Error())
 				return diag.Errorf("property Create failed [%s]", err.Error())
@@ -451,7 +455,7 @@ func resourceGTMv1PropertyCreate(ctx context.Context, d *schema.ResourceData, m
 	propertyID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
 	logger.Debugf("Generated Property resource ID: %s", propertyID)
 	d.SetId(propertyID)
-	return resourceGTMv1PropertyRead(ctx, d, m)
+	return append(diags, resourceGTMv1PropertyRead(ctx, d, m)...)
 
 }
 
@@ -473,6 +477,9 @@ func resourceGTMv1PropertyRead(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 	prop, err := inst.Client(meta).GetProperty(ctx, property, domain)
+	if akamai.RemoveIfNotFound(d, err, logger) {
+		return nil
+	}
 	if err != nil {
 		logger.Errorf("Property Read failed: %s", err.Error())
 		return diag.Errorf("property Read failed: %s", err.Error())
@@ -521,18 +528,20 @@ func resourceGTMv1PropertyUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(fmt.Errorf(uStat.Message))
 	}
 
+	var diags diag.Diagnostics
 	waitOnComplete, err := tools.GetBoolValue("wait_on_complete", d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("Property Update completed")
 		} else {
 			if err == nil {
-				logger.Infof("Property Update pending")
+				logger.Warnf("Property Update pending")
+				diags = append(diags, propagationPendingWarning("Property Update"))
 			} else {
 				logger.Errorf("Property Update failed [%s]", err.Error())
 				return diag.Errorf("property Update failed [%s]", err.Error())
@@ -540,7 +549,7 @@ func resourceGTMv1PropertyUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	return resourceGTMv1PropertyRead(ctx, d, m)
+	return append(diags, resourceGTMv1PropertyRead(ctx, d, m)...)
 }
 
 // Import GTM Property.
@@ -609,18 +618,20 @@ func resourceGTMv1PropertyDelete(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(fmt.Errorf(uStat.Message))
 	}
 
+	var diags diag.Diagnostics
 	waitOnComplete, err := tools.GetBoolValue("wait_on_complete", d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("Property Delete completed")
 		} else {
 			if err == nil {
-				logger.Infof("Property Delete pending")
+				logger.Warnf("Property Delete pending")
+				diags = append(diags, propagationPendingWarning("Property Delete"))
 			} else {
 				logger.Errorf("Property Delete failed [%s]", err.Error())
 				return diag.Errorf("property Delete failed [%s]", err.Error())
@@ -630,7 +641,7 @@ func resourceGTMv1PropertyDelete(ctx context.Context, d *schema.ResourceData, m
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 //nolint:gocyclo
@@ -33,6 +33,8 @@ func resourceGTMv1Resource() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(PropagationPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -164,12 +166,13 @@ func resourceGTMv1ResourceCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("Resource Create completed")
 		} else {
 			if err == nil {
-				logger.Infof("Resource Create pending")
+				logger.Warnf("Resource Create pending")
+				diags = append(diags, propagationPendingWarning("Resource Create"))
 			} else {
 				logger.Errorf("Resource Create failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -185,7 +188,7 @@ func resourceGTMv1ResourceCreate(ctx context.Context, d *schema.ResourceData, m
 	resourceID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
 	logger.Debugf("Generated Resource. Resource ID: %s", resourceID)
 	d.SetId(resourceID)
-	return resourceGTMv1ResourceRead(ctx, d, m)
+	return append(diags, resourceGTMv1ResourceRead(ctx, d, m)...)
 
 }
 
@@ -208,6 +211,9 @@ func resourceGTMv1ResourceRead(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 	rsrc, err := inst.Client(meta).GetResource(ctx, resource, domain)
+	if akamai.RemoveIfNotFound(d, err, logger) {
+		return nil
+	}
 	if err != nil {
 		logger.Errorf("Resource Read failed: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -278,12 +284,13 @@ func resourceGTMv1ResourceUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("Resource update completed")
 		} else {
 			if err == nil {
-				logger.Infof("Resource update pending")
+				logger.Warnf("Resource update pending")
+				diags = append(diags, propagationPendingWarning("Resource update"))
 			} else {
 				logger.Errorf("Resource update failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -295,7 +302,7 @@ func resourceGTMv1ResourceUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	return resourceGTMv1ResourceRead(ctx, d, m)
+	return append(diags, resourceGTMv1ResourceRead(ctx, d, m)...)
 }
 
 // Import GTM Resource.
@@ -381,12 +388,13 @@ func resourceGTMv1ResourceDelete(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
+		done, err := waitForCompletion(ctx, d, domain, m)
 		if done {
 			logger.Infof("Resource Delete completed")
 		} else {
 			if err == nil {
-				logger.Infof("Resource Delete pending")
+				logger.Warnf("Resource Delete pending")
+				diags = append(diags, propagationPendingWarning("Resource Delete"))
 			} else {
 				logger.Errorf("Resource Delete failed [%s]", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -400,7 +408,7 @@ func resourceGTMv1ResourceDelete(ctx context.Context, d *schema.ResourceData, m
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new resource object from resource data
@@ -49,9 +49,9 @@ func Provider() *schema.Provider {
 			"akamai_imaging_policy_video": dataImagingPolicyVideo(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"akamai_imaging_policy_image": resourceImagingPolicyImage(),
-			"akamai_imaging_policy_set":   resourceImagingPolicySet(),
-			"akamai_imaging_policy_video": resourceImagingPolicyVideo(),
+			"akamai_imaging_policy_image": akamai.WithProviderDefaults(resourceImagingPolicyImage()),
+			"akamai_imaging_policy_set":   akamai.WithProviderDefaults(resourceImagingPolicySet()),
+			"akamai_imaging_policy_video": akamai.WithProviderDefaults(resourceImagingPolicyVideo()),
 		},
 	}
 	return provider
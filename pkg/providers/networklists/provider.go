@@ -62,10 +62,10 @@ func Provider() *schema.Provider {
 			"akamai_networklist_network_lists": dataSourceNetworkList(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"akamai_networklist_activations":  resourceActivations(),
+			"akamai_networklist_activations":  akamai.WithProviderDefaults(resourceActivations()),
 			"akamai_networklist_description":  resourceNetworkListDescription(),
 			"akamai_networklist_subscription": resourceNetworkListSubscription(),
-			"akamai_networklist_network_list": resourceNetworkList(),
+			"akamai_networklist_network_list": akamai.WithProviderDefaults(resourceNetworkList()),
 		},
 	}
 	return provider
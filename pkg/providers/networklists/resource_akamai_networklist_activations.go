@@ -3,6 +3,7 @@ package networklists
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"sync"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/networklists"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -69,6 +71,8 @@ func resourceActivations() *schema.Resource {
 				Computed:    true,
 				Description: `This network list's current activation status in the environment specified by the "network" attribute`,
 			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(ActivationPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 		},
 	}
 }
@@ -126,19 +130,8 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
-	for lookupResponse.ActivationStatus != "ACTIVATED" {
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, networklists.GetActivationRequest{ActivationID: createResponse.ActivationID})
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			lookupResponse = act
-
-		case <-ctx.Done():
-			return diag.Errorf("activation context terminated: %s", ctx.Err())
-		}
+	if _, err := waitForActivation(ctx, d, client, networklists.GetActivationRequest{ActivationID: createResponse.ActivationID}, lookupResponse, logger); err != nil {
+		return diag.FromErr(err)
 	}
 
 	return resourceActivationsRead(ctx, d, m)
@@ -195,6 +188,11 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	logger := meta.Log("NETWORKLIST", "resourceActivationsUpdate")
 	logger.Debug("Updating resource activation")
 
+	if !d.HasChangesExcept(tools.PollIntervalKey, tools.PollTimeoutKey) {
+		logger.Debug("Only the polling settings changed, keeping the current activation")
+		return nil
+	}
+
 	networkListID, err := tools.GetStringValue("network_list_id", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return diag.FromErr(err)
@@ -233,19 +231,8 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
-	for lookupResponse.ActivationStatus != "ACTIVATED" {
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, lookupRequest)
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			lookupResponse = act
-
-		case <-ctx.Done():
-			return diag.Errorf("activation context terminated: %s", ctx.Err())
-		}
+	if _, err := waitForActivation(ctx, d, client, lookupRequest, lookupResponse, logger); err != nil {
+		return diag.FromErr(err)
 	}
 	return resourceActivationsRead(ctx, d, m)
 }
@@ -269,3 +256,40 @@ func lookupActivation(ctx context.Context, client networklists.NTWRKLISTS, query
 	}
 	return activation, nil
 }
+
+// waitForActivation polls the network list activation until it is active or fails
+func waitForActivation(ctx context.Context, d *schema.ResourceData, client networklists.NTWRKLISTS, query networklists.GetActivationRequest,
+	activation *networklists.GetActivationResponse, logger log.Interface) (*networklists.GetActivationResponse, error) {
+	checkStatus := func(activation *networklists.GetActivationResponse) (bool, error) {
+		switch networklists.StatusValue(activation.ActivationStatus) {
+		case networklists.StatusActive:
+			return true, nil
+		case networklists.StatusFailed, networklists.StatusAborted:
+			return false, fmt.Errorf("network list activation %d reached status %s", query.ActivationID, activation.ActivationStatus)
+		}
+		return false, nil
+	}
+	if done, err := checkStatus(activation); done || err != nil {
+		return activation, err
+	}
+
+	poller, err := tools.NewPoller(fmt.Sprintf("network list activation %d", query.ActivationID), d, ActivationPollInterval, ActivationPollMinimum, logger)
+	if err != nil {
+		return nil, err
+	}
+	err = poller.Poll(ctx, func(ctx context.Context) (bool, error) {
+		act, err := client.GetActivation(ctx, query)
+		if err != nil {
+			return false, err
+		}
+		activation = act
+		return checkStatus(activation)
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return nil, fmt.Errorf("activation context terminated: %w", err)
+		}
+		return nil, err
+	}
+	return activation, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/networklists"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccAkamaiActivations_res_basic(t *testing.T) {
@@ -69,4 +70,54 @@ func TestAccAkamaiActivations_res_basic(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
+	t.Run("poll interval change does not activate again", func(t *testing.T) {
+		client := &networklists.Mock{}
+
+		ga := networklists.GetActivationResponse{}
+		err := json.Unmarshal(loadFixtureBytes("testdata/TestResActivations/GetActivation.json"), &ga)
+		require.NoError(t, err)
+
+		cr := networklists.CreateActivationsResponse{}
+		err = json.Unmarshal(loadFixtureBytes("testdata/TestResActivations/CreateActivations.json"), &cr)
+		require.NoError(t, err)
+
+		client.On("CreateActivations",
+			mock.Anything,
+			networklists.CreateActivationsRequest{UniqueID: "86093_AGEOLIST", Action: "ACTIVATE", Network: "STAGING", Comments: "TEST Notes", NotificationRecipients: []string{"user@example.com"}},
+		).Return(&cr, nil).Once()
+
+		client.On("GetActivation",
+			mock.Anything,
+			networklists.GetActivationRequest{ActivationID: 547694},
+		).Return(&ga, nil)
+
+		client.On("GetNetworkList",
+			mock.Anything,
+			networklists.GetNetworkListRequest{UniqueID: "86093_AGEOLIST"},
+		).Return(&networklists.GetNetworkListResponse{UniqueID: "86093_AGEOLIST", SyncPoint: 5}, nil)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResActivations/activation.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "id", "547694"),
+							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "status", "ACTIVATED"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResActivations/activation_poll_interval.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "id", "547694"),
+							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "poll_interval", "30s"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
 }
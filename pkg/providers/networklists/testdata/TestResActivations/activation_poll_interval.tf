@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_networklist_activations" "test" {
+  network_list_id     = "86093_AGEOLIST"
+  network             = "STAGING"
+  notes               = "TEST Notes"
+  notification_emails = ["user@example.com"]
+  sync_point          = 5
+  poll_interval       = "30s"
+}
@@ -0,0 +1,246 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
+)
+
+const (
+	// bulkStatusComplete is the status of a finished bulk operation and of its successful items
+	bulkStatusComplete = "COMPLETE"
+
+	// bulkPollInterval is the default interval between the status checks of bulk operations
+	bulkPollInterval = 10 * time.Second
+
+	// bulkPollMinimum is the minimum interval between the status checks of bulk operations
+	bulkPollMinimum = time.Second
+)
+
+var (
+	// ErrBulkOperation is returned when a PAPI bulk operation cannot be submitted or read
+	ErrBulkOperation = errors.New("bulk operation")
+)
+
+type (
+	// bulkSearchResult is a property version matching the bulk search
+	bulkSearchResult struct {
+		PropertyID       string   `json:"propertyId"`
+		PropertyName     string   `json:"propertyName"`
+		PropertyVersion  int      `json:"propertyVersion"`
+		IsLatest         bool     `json:"isLatest"`
+		IsLocked         bool     `json:"isLocked"`
+		ProductionStatus string   `json:"productionStatus"`
+		StagingStatus    string   `json:"stagingStatus"`
+		MatchLocations   []string `json:"matchLocations"`
+	}
+
+	bulkSearch struct {
+		BulkSearchID       int                `json:"bulkSearchId"`
+		SearchTargetStatus string             `json:"searchTargetStatus"`
+		Results            []bulkSearchResult `json:"results"`
+	}
+
+	// bulkVersion is a property version created, patched or activated by a bulk operation
+	bulkVersion struct {
+		PropertyID        string               `json:"propertyId"`
+		PropertyVersion   int                  `json:"propertyVersion,omitempty"`
+		CreateFromVersion int                  `json:"createFromVersion,omitempty"`
+		Network           string               `json:"network,omitempty"`
+		Note              string               `json:"note,omitempty"`
+		Etag              string               `json:"etag,omitempty"`
+		Patches           []bulkPatchOperation `json:"patches,omitempty"`
+		Status            string               `json:"status,omitempty"`
+		ActivationID      string               `json:"activationId,omitempty"`
+		FatalError        string               `json:"fatalError,omitempty"`
+	}
+
+	// bulkPatchOperation is a JSON Patch operation applied to the rule tree
+	bulkPatchOperation struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		From  string          `json:"from,omitempty"`
+		Value json.RawMessage `json:"value,omitempty"`
+	}
+
+	bulkVersionCreation struct {
+		Status   string        `json:"bulkCreateVersionsStatus"`
+		Versions []bulkVersion `json:"createPropertyVersions"`
+	}
+
+	bulkPatch struct {
+		Status   string        `json:"bulkPatchStatus"`
+		Versions []bulkVersion `json:"patchPropertyVersions"`
+	}
+
+	// bulkActivationSettings apply to all activations of a bulk activation
+	bulkActivationSettings struct {
+		NotifyEmails           []string    `json:"notifyEmails"`
+		AcknowledgeAllWarnings bool        `json:"acknowledgeAllWarnings"`
+		FastPush               bool        `json:"fastPush"`
+		UseFastFallback        bool        `json:"useFastFallback"`
+		ComplianceRecord       interface{} `json:"complianceRecord,omitempty"`
+	}
+
+	bulkActivation struct {
+		Status   string                  `json:"bulkActivationStatus,omitempty"`
+		Settings *bulkActivationSettings `json:"defaultActivationSettings,omitempty"`
+		Versions []bulkVersion           `json:"activatePropertyVersions"`
+	}
+
+	// bulkClient sends the PAPI bulk requests, which the PAPI client has no methods for
+	bulkClient struct {
+		exec   executor
+		log    log.Interface
+		poller func() (*tools.Poller, error)
+	}
+)
+
+// newBulkClient returns the client of the bulk operations, polling their status with the poller
+func newBulkClient(exec executor, logger log.Interface, poller func() (*tools.Poller, error)) *bulkClient {
+	return &bulkClient{exec: exec, log: logger, poller: poller}
+}
+
+// search returns the highest version of every property whose rule tree matches the JSONPath expression,
+// which is not the latest version of the property when only older versions match
+func (c *bulkClient) search(ctx context.Context, contractID, groupID, match string) ([]bulkSearchResult, error) {
+	query := url.Values{}
+	if contractID != "" {
+		query.Set("contractId", contractID)
+	}
+	if groupID != "" {
+		query.Set("groupId", groupID)
+	}
+	body := map[string]interface{}{
+		"bulkSearchQuery": map[string]interface{}{
+			"syntax": "JSONPATH",
+			"match":  match,
+		},
+	}
+	uri := url.URL{Path: "/papi/v1/bulk/rules-search-requests", RawQuery: query.Encode()}
+	var search bulkSearch
+	if err := c.run(ctx, "search", uri.String(), body, "bulkSearchLink", &search, func() string {
+		return search.SearchTargetStatus
+	}); err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]bulkSearchResult)
+	for _, result := range search.Results {
+		if found, ok := latest[result.PropertyID]; !ok || found.PropertyVersion < result.PropertyVersion {
+			latest[result.PropertyID] = result
+		}
+	}
+	results := make([]bulkSearchResult, 0, len(latest))
+	for _, result := range latest {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].PropertyID < results[j].PropertyID
+	})
+	return results, nil
+}
+
+// createVersions creates a new version of every property from the given version
+func (c *bulkClient) createVersions(ctx context.Context, versions []bulkVersion) ([]bulkVersion, error) {
+	body := map[string]interface{}{"createPropertyVersions": versions}
+	var creation bulkVersionCreation
+	if err := c.run(ctx, "versioning", "/papi/v1/bulk/property-version-creations", body, "bulkCreateVersionLink", &creation, func() string {
+		return creation.Status
+	}); err != nil {
+		return nil, err
+	}
+	return creation.Versions, nil
+}
+
+// patch applies the JSON Patch operations to the rule trees of the property versions
+func (c *bulkClient) patch(ctx context.Context, versions []bulkVersion) ([]bulkVersion, error) {
+	body := map[string]interface{}{"patchPropertyVersions": versions}
+	var patch bulkPatch
+	if err := c.run(ctx, "patch", "/papi/v1/bulk/rules-patch-requests", body, "bulkPatchLink", &patch, func() string {
+		return patch.Status
+	}); err != nil {
+		return nil, err
+	}
+	return patch.Versions, nil
+}
+
+// activate activates the property versions
+func (c *bulkClient) activate(ctx context.Context, settings bulkActivationSettings, versions []bulkVersion) ([]bulkVersion, error) {
+	body := bulkActivation{Settings: &settings, Versions: versions}
+	var activation bulkActivation
+	if err := c.run(ctx, "activation", "/papi/v1/bulk/activations", body, "bulkActivationLink", &activation, func() string {
+		return activation.Status
+	}); err != nil {
+		return nil, err
+	}
+	return activation.Versions, nil
+}
+
+// run submits the bulk operation and polls the link returned under the link key until the operation is complete
+func (c *bulkClient) run(ctx context.Context, kind, uri string, body interface{}, linkKey string, out interface{}, status func() string) error {
+	var submitted map[string]interface{}
+	if err := c.do(ctx, http.MethodPost, uri, body, &submitted, http.StatusAccepted); err != nil {
+		return fmt.Errorf("%w: %s: %s", ErrBulkOperation, kind, err)
+	}
+	link, ok := submitted[linkKey].(string)
+	if !ok || link == "" {
+		return fmt.Errorf("%w: %s: response has no %s", ErrBulkOperation, kind, linkKey)
+	}
+
+	check := func(ctx context.Context) (bool, error) {
+		if err := c.do(ctx, http.MethodGet, link, nil, out, http.StatusOK); err != nil {
+			return false, fmt.Errorf("%w: %s: %s", ErrBulkOperation, kind, err)
+		}
+		c.log.Debugf("bulk %s %s: %s", kind, link, status())
+		return status() == bulkStatusComplete, nil
+	}
+	if done, err := check(ctx); done || err != nil {
+		return err
+	}
+	poller, err := c.poller()
+	if err != nil {
+		return err
+	}
+	return poller.Poll(ctx, check)
+}
+
+func (c *bulkClient) do(ctx context.Context, method, uri string, body, out interface{}, expectedStatus int) error {
+	return execRequest(ctx, c.exec, method, uri, body, out, expectedStatus)
+}
+
+// execRequest sends the request with the executor, returning a papi.Error when the response has an unexpected status
+func execRequest(ctx context.Context, exec executor, method, uri string, body, out interface{}, expectedStatus int) error {
+	req, err := http.NewRequestWithContext(ctx, method, uri, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %s", err)
+	}
+	var in []interface{}
+	if body != nil {
+		in = append(in, body)
+	}
+	resp, err := exec.Exec(req, out, in...)
+	if err != nil {
+		return fmt.Errorf("request failed: %s", err)
+	}
+	if resp.StatusCode != expectedStatus {
+		apiErr := papi.Error{StatusCode: resp.StatusCode}
+		if resp.Body != nil {
+			if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
+				apiErr.Title = "Failed to unmarshal error body"
+				apiErr.Detail = err.Error()
+			}
+		}
+		return &apiErr
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package property
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bulkResponse struct {
+	status int
+	body   string
+}
+
+// bulkSession is the executor responding to the requests with the response of their method and path, recording them in order
+type bulkSession struct {
+	responses map[string]bulkResponse
+	requests  []string
+}
+
+func (s *bulkSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	request := fmt.Sprintf("%s %s", r.Method, r.URL)
+	if len(in) > 0 {
+		body, err := json.Marshal(in[0])
+		if err != nil {
+			return nil, err
+		}
+		request = fmt.Sprintf("%s %s", request, body)
+	}
+	s.requests = append(s.requests, request)
+
+	resp, ok := s.responses[fmt.Sprintf("%s %s", r.Method, r.URL.Path)]
+	if !ok {
+		resp = bulkResponse{status: http.StatusNotFound, body: `{"title": "Not Found", "status": 404}`}
+	}
+	if resp.status >= 200 && resp.status < 300 && out != nil {
+		if err := json.Unmarshal([]byte(resp.body), out); err != nil {
+			return nil, err
+		}
+	}
+	return &http.Response{StatusCode: resp.status, Body: ioutil.NopCloser(strings.NewReader(resp.body))}, nil
+}
+
+func TestPatchesAtLocations(t *testing.T) {
+	patches := []bulkPatchOperation{
+		{Op: "replace", Path: "/options/hostname", Value: json.RawMessage(`"origin.example.com"`)},
+		{Op: "copy", Path: "/options/alias", From: "/options/hostname"},
+	}
+
+	located := patchesAtLocations(patches, []string{"/rules/behaviors/0", "/rules/children/1/behaviors/2/"})
+	assert.Equal(t, []bulkPatchOperation{
+		{Op: "replace", Path: "/rules/behaviors/0/options/hostname", Value: json.RawMessage(`"origin.example.com"`)},
+		{Op: "copy", Path: "/rules/behaviors/0/options/alias", From: "/rules/behaviors/0/options/hostname"},
+		{Op: "replace", Path: "/rules/children/1/behaviors/2/options/hostname", Value: json.RawMessage(`"origin.example.com"`)},
+		{Op: "copy", Path: "/rules/children/1/behaviors/2/options/alias", From: "/rules/children/1/behaviors/2/options/hostname"},
+	}, located)
+	assert.Equal(t, "/options/hostname", patches[0].Path, "patches must not be modified")
+}
@@ -0,0 +1,91 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrReportingGroup is returned when a CPRG reporting group request fails
+	ErrReportingGroup = errors.New("reporting group")
+)
+
+type (
+	// reportingGroup aggregates CP codes in reports
+	reportingGroup struct {
+		ReportingGroupID   int                      `json:"reportingGroupId,omitempty"`
+		ReportingGroupName string                   `json:"reportingGroupName"`
+		Contracts          []reportingGroupContract `json:"contracts"`
+		AccessGroup        reportingGroupAccess     `json:"accessGroup"`
+	}
+
+	// reportingGroupContract lists the CP codes of the reporting group under the contract
+	reportingGroupContract struct {
+		ContractID string                 `json:"contractId"`
+		CPCodes    []reportingGroupCPCode `json:"cpcodes"`
+	}
+
+	// reportingGroupCPCode is a CP code of the reporting group
+	reportingGroupCPCode struct {
+		CPCodeID   int    `json:"cpcodeId"`
+		CPCodeName string `json:"cpcodeName,omitempty"`
+	}
+
+	// reportingGroupAccess is the group whose users can access the reporting group
+	reportingGroupAccess struct {
+		GroupID    int    `json:"groupId"`
+		ContractID string `json:"contractId"`
+	}
+
+	// cprgClient sends the CPRG reporting group requests, which the PAPI client has no methods for
+	cprgClient struct {
+		exec executor
+	}
+)
+
+// newCPRGClient returns the client of the CPRG reporting groups
+func newCPRGClient(exec executor) *cprgClient {
+	return &cprgClient{exec: exec}
+}
+
+// createReportingGroup creates the reporting group and returns it with its ID
+func (c *cprgClient) createReportingGroup(ctx context.Context, group reportingGroup) (*reportingGroup, error) {
+	var created reportingGroup
+	if err := c.do(ctx, http.MethodPost, "/cprg/v1/reporting-groups", group, &created, http.StatusCreated); err != nil {
+		return nil, fmt.Errorf("%s: create %q: %w", ErrReportingGroup, group.ReportingGroupName, err)
+	}
+	return &created, nil
+}
+
+// getReportingGroup reads the reporting group
+func (c *cprgClient) getReportingGroup(ctx context.Context, id int) (*reportingGroup, error) {
+	var group reportingGroup
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/cprg/v1/reporting-groups/%d", id), nil, &group, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("%s: read %d: %w", ErrReportingGroup, id, err)
+	}
+	return &group, nil
+}
+
+// updateReportingGroup replaces the name and CP codes of the reporting group
+func (c *cprgClient) updateReportingGroup(ctx context.Context, group reportingGroup) (*reportingGroup, error) {
+	var updated reportingGroup
+	uri := fmt.Sprintf("/cprg/v1/reporting-groups/%d", group.ReportingGroupID)
+	if err := c.do(ctx, http.MethodPut, uri, group, &updated, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("%s: update %d: %w", ErrReportingGroup, group.ReportingGroupID, err)
+	}
+	return &updated, nil
+}
+
+// deleteReportingGroup deletes the reporting group
+func (c *cprgClient) deleteReportingGroup(ctx context.Context, id int) error {
+	if err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/cprg/v1/reporting-groups/%d", id), nil, nil, http.StatusNoContent); err != nil {
+		return fmt.Errorf("%s: delete %d: %w", ErrReportingGroup, id, err)
+	}
+	return nil
+}
+
+func (c *cprgClient) do(ctx context.Context, method, uri string, body, out interface{}, expectedStatus int) error {
+	return execRequest(ctx, c.exec, method, uri, body, out, expectedStatus)
+}
@@ -0,0 +1,145 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceCPCodes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataCPCodesRead,
+		Schema: map[string]*schema.Schema{
+			"contract_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Identifies the contract under which the CP codes are listed",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Identifies the group under which the CP codes are listed",
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				Description:      "Lists only the CP codes with names matching the regular expression",
+			},
+			"product_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Lists only the CP codes of the product, including the `prd_` prefix",
+			},
+			"cp_codes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of CP codes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cp_code_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The CP code's unique identifier, including the `cpc_` prefix",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the CP code",
+						},
+						"created_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date the CP code was created",
+						},
+						"product_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The products the CP code is available for",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataCPCodesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("PAPI", "dataCPCodesRead")
+	logger.Debug("Reading CP codes")
+
+	contractID, err := tools.GetStringValue("contract_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	groupID, err := tools.GetStringValue("group_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	nameRegex, err := tools.GetStringValue("name_regex", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	productID, err := tools.GetStringValue("product_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	nameRe, err := regexp.Compile(nameRegex)
+	if err != nil {
+		return diag.Errorf("invalid name_regex: %s", err)
+	}
+
+	cpCodes, err := client.GetCPCodes(ctx, papi.GetCPCodesRequest{
+		ContractID: contractID,
+		GroupID:    groupID,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("could not load CP codes: %w", err))
+	}
+
+	var attrs []interface{}
+	for _, cpCode := range cpCodes.CPCodes.Items {
+		if !nameRe.MatchString(cpCode.Name) {
+			continue
+		}
+		if productID != "" && !cpCodeHasProduct(cpCode, productID) {
+			continue
+		}
+		attrs = append(attrs, map[string]interface{}{
+			"cp_code_id":   cpCode.ID,
+			"name":         cpCode.Name,
+			"created_date": cpCode.CreatedDate,
+			"product_ids":  cpCode.ProductIDs,
+		})
+	}
+	logger.Debugf("Found %d of %d CP codes", len(attrs), len(cpCodes.CPCodes.Items))
+
+	if err := d.Set("cp_codes", attrs); err != nil {
+		return diag.Errorf("%v: %s", tools.ErrValueSet, err.Error())
+	}
+	d.SetId(strings.Join([]string{contractID, groupID, nameRegex, productID}, ":"))
+
+	return nil
+}
+
+func cpCodeHasProduct(cpCode papi.CPCode, productID string) bool {
+	productID = tools.AddPrefix(productID, "prd_")
+	for _, id := range cpCode.ProductIDs {
+		if tools.AddPrefix(id, "prd_") == productID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataCPCodes(t *testing.T) {
+	cpCodes := []papi.CPCode{
+		{ID: "cpc_1", Name: "static-assets", CreatedDate: "2022-11-02T10:10:10Z", ProductIDs: []string{"prd_SPM", "prd_Fresca"}},
+		{ID: "cpc_2", Name: "static-images", CreatedDate: "2022-11-03T10:10:10Z", ProductIDs: []string{"prd_Fresca"}},
+		{ID: "cpc_3", Name: "api", CreatedDate: "2022-11-04T10:10:10Z", ProductIDs: []string{"prd_SPM"}},
+	}
+
+	tests := map[string]struct {
+		configPath string
+		init       func(*papi.Mock)
+		checks     resource.TestCheckFunc
+		withError  *regexp.Regexp
+	}{
+		"all CP codes": {
+			configPath: "testdata/TestDataCPCodes/no_filters.tf",
+			init: func(m *papi.Mock) {
+				m.On("GetCPCodes", mock.Anything, papi.GetCPCodesRequest{ContractID: "ctr_1", GroupID: "grp_2"}).
+					Return(&papi.GetCPCodesResponse{CPCodes: papi.CPCodeItems{Items: cpCodes}}, nil)
+			},
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_cp_codes.test", "id", "ctr_1:grp_2::"),
+				resource.TestCheckResourceAttr("data.akamai_cp_codes.test", "cp_codes.#", "3"),
+				resource.TestCheckResourceAttr("data.akamai_cp_codes.test", "cp_codes.0.cp_code_id", "cpc_1"),
+				resource.TestCheckResourceAttr("data.akamai_cp_codes.test", "cp_codes.0.name", "static-assets"),
+				resource.TestCheckResourceAttr("data.akamai_cp_codes.test", "cp_codes.0.created_date", "2022-11-02T10:10:10Z"),
+				resource.TestCheckResourceAttr("data.akamai_cp_codes.test", "cp_codes.0.product_ids.#", "2"),
+				resource.TestCheckResourceAttr("data.akamai_cp_codes.test", "cp_codes.0.product_ids.1", "prd_Fresca"),
+			),
+		},
+		"CP codes filtered by name and product": {
+			configPath: "testdata/TestDataCPCodes/filters.tf",
+			init: func(m *papi.Mock) {
+				m.On("GetCPCodes", mock.Anything, papi.GetCPCodesRequest{ContractID: "ctr_1", GroupID: "grp_2"}).
+					Return(&papi.GetCPCodesResponse{CPCodes: papi.CPCodeItems{Items: cpCodes}}, nil)
+			},
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_cp_codes.test", "id", "ctr_1:grp_2:^static-:SPM"),
+				resource.TestCheckResourceAttr("data.akamai_cp_codes.test", "cp_codes.#", "1"),
+				resource.TestCheckResourceAttr("data.akamai_cp_codes.test", "cp_codes.0.cp_code_id", "cpc_1"),
+			),
+		},
+		"invalid name regex": {
+			configPath: "testdata/TestDataCPCodes/invalid_regex.tf",
+			init:       func(m *papi.Mock) {},
+			withError:  regexp.MustCompile(`"name_regex": error parsing regexp`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &papi.Mock{}
+			test.init(client)
+			useClient(client, nil, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{{
+						Config:      loadFixtureString(test.configPath),
+						Check:       test.checks,
+						ExpectError: test.withError,
+					}},
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCPCodeHasProduct(t *testing.T) {
+	cpCode := papi.CPCode{ProductIDs: []string{"prd_SPM"}}
+	tests := map[string]struct {
+		productID string
+		expected  bool
+	}{
+		"prefixed ID":   {productID: "prd_SPM", expected: true},
+		"unprefixed ID": {productID: "SPM", expected: true},
+		"other product": {productID: "prd_Fresca", expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, cpCodeHasProduct(cpCode, test.productID))
+		})
+	}
+}
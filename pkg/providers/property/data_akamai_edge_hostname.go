@@ -0,0 +1,208 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceEdgeHostname() *schema.Resource {
+	attributes := map[string]*schema.Schema{
+		"contract_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Identifies the contract of the edge hostname",
+		},
+		"group_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Identifies the group of the edge hostname",
+		},
+		"edge_hostname": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: tools.IsNotBlank,
+			Description:      "The domain of the edge hostname. Defaults to the `edgesuite.net` suffix when it has none of the edge hostname suffixes",
+		},
+		"ttl": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The time to live of the edge hostname DNS record in seconds",
+		},
+		"slot_number": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The slot number of the certificate deployed for the edge hostname. It is 0 for edge hostnames without a dedicated certificate",
+		},
+		"serial_number": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The serial number of the edge map serving the edge hostname",
+		},
+		"properties": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The property versions of the group with hostnames pointing to the edge hostname. The latest, staging and production version of each property are checked",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"property_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The property's unique identifier",
+					},
+					"property_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the property",
+					},
+					"version": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The property version referencing the edge hostname",
+					},
+					"hostnames": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "The hostnames of the version pointing to the edge hostname",
+					},
+				},
+			},
+		},
+	}
+	for name, attr := range edgeHostnameAttributesSchema() {
+		if name != "edge_hostname" {
+			attributes[name] = attr
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataEdgeHostnameRead,
+		Schema:      attributes,
+	}
+}
+
+func dataEdgeHostnameRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("PAPI", "dataEdgeHostnameRead")
+
+	contractID, err := tools.GetStringValue("contract_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	groupID, err := tools.GetStringValue("group_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	edgeHostname, err := tools.GetStringValue("edge_hostname", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debugf("Reading edge hostname %s", edgeHostname)
+
+	edgeHostnames, err := client.GetEdgeHostnames(ctx, papi.GetEdgeHostnamesRequest{
+		ContractID: contractID,
+		GroupID:    groupID,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("could not list edge hostnames: %w", err))
+	}
+	ehn, err := findEdgeHostname(edgeHostnames.EdgeHostnames, appendDefaultSuffixToEdgeHostname(edgeHostname))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	attrs, err := edgeHostnameAttributes(*ehn)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	delete(attrs, "edge_hostname")
+
+	id, err := edgeHostnameID(ehn.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hapiEdgeHostname, err := inst.HapiClient(meta).GetEdgeHostname(ctx, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	attrs["ttl"] = hapiEdgeHostname.TTL
+	attrs["slot_number"] = hapiEdgeHostname.SlotNumber
+	attrs["serial_number"] = hapiEdgeHostname.SerialNumber
+
+	properties, err := findEdgeHostnameProperties(ctx, client, contractID, groupID, ehn.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	attrs["properties"] = properties
+	logger.Debugf("Edge hostname %s is used in %d property versions", ehn.ID, len(properties))
+
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(ehn.ID)
+
+	return nil
+}
+
+// findEdgeHostnameProperties returns the property versions of the group with hostnames pointing to the edge hostname
+func findEdgeHostnameProperties(ctx context.Context, client papi.PAPI, contractID, groupID, edgeHostnameID string) ([]interface{}, error) {
+	properties, err := client.GetProperties(ctx, papi.GetPropertiesRequest{
+		ContractID: contractID,
+		GroupID:    groupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list properties: %w", err)
+	}
+
+	var result []interface{}
+	for _, property := range properties.Properties.Items {
+		versions := map[int]struct{}{property.LatestVersion: {}}
+		if property.StagingVersion != nil {
+			versions[*property.StagingVersion] = struct{}{}
+		}
+		if property.ProductionVersion != nil {
+			versions[*property.ProductionVersion] = struct{}{}
+		}
+		sorted := make([]int, 0, len(versions))
+		for version := range versions {
+			sorted = append(sorted, version)
+		}
+		sort.Ints(sorted)
+
+		for _, version := range sorted {
+			hostnames, err := client.GetPropertyVersionHostnames(ctx, papi.GetPropertyVersionHostnamesRequest{
+				PropertyID:      property.PropertyID,
+				PropertyVersion: version,
+				ContractID:      contractID,
+				GroupID:         groupID,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("could not get hostnames of property %s version %d: %w", property.PropertyID, version, err)
+			}
+			var cnameFrom []string
+			for _, hostname := range hostnames.Hostnames.Items {
+				if hostname.EdgeHostnameID == edgeHostnameID {
+					cnameFrom = append(cnameFrom, hostname.CnameFrom)
+				}
+			}
+			if len(cnameFrom) == 0 {
+				continue
+			}
+			result = append(result, map[string]interface{}{
+				"property_id":   property.PropertyID,
+				"property_name": property.PropertyName,
+				"version":       version,
+				"hostnames":     cnameFrom,
+			})
+		}
+	}
+	return result, nil
+}
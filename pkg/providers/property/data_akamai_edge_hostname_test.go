@@ -0,0 +1,120 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataEdgeHostname(t *testing.T) {
+	expectGetEdgeHostnames := func(m *papi.Mock) {
+		m.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{
+			ContractID: "ctr_1",
+			GroupID:    "grp_2",
+		}).Return(&papi.GetEdgeHostnamesResponse{
+			ContractID: "ctr_1",
+			GroupID:    "grp_2",
+			EdgeHostnames: papi.EdgeHostnameItems{Items: []papi.EdgeHostnameGetItem{
+				{
+					ID:                "ehn_1",
+					Domain:            "www.example.com.edgesuite.net",
+					ProductID:         "prd_SPM",
+					DomainPrefix:      "www.example.com",
+					DomainSuffix:      "edgesuite.net",
+					IPVersionBehavior: "IPV4",
+				},
+				{
+					ID:                "ehn_2",
+					Domain:            "www.example.com.edgekey.net",
+					ProductID:         "prd_SPM",
+					DomainPrefix:      "www.example.com",
+					DomainSuffix:      "edgekey.net",
+					Secure:            true,
+					IPVersionBehavior: "IPV6_COMPLIANCE",
+				},
+			}},
+		}, nil)
+	}
+
+	t.Run("edge hostname with referencing properties", func(t *testing.T) {
+		client := &papi.Mock{}
+		clientHapi := &hapi.Mock{}
+		expectGetEdgeHostnames(client)
+		clientHapi.On("GetEdgeHostname", mock.Anything, 2).Return(&hapi.GetEdgeHostnameResponse{
+			EdgeHostnameID: 2,
+			TTL:            300,
+			SlotNumber:     12345,
+			SerialNumber:   1061,
+		}, nil)
+		client.On("GetProperties", mock.Anything, papi.GetPropertiesRequest{ContractID: "ctr_1", GroupID: "grp_2"}).
+			Return(&papi.GetPropertiesResponse{Properties: papi.PropertiesItems{Items: []*papi.Property{
+				{PropertyID: "prp_1", PropertyName: "example", LatestVersion: 3, StagingVersion: tools.IntPtr(3), ProductionVersion: tools.IntPtr(2)},
+				{PropertyID: "prp_2", PropertyName: "other", LatestVersion: 1},
+			}}}, nil)
+		expectHostnames := func(propertyID string, version int, hostnames ...papi.Hostname) {
+			client.On("GetPropertyVersionHostnames", mock.Anything, papi.GetPropertyVersionHostnamesRequest{
+				PropertyID:      propertyID,
+				PropertyVersion: version,
+				ContractID:      "ctr_1",
+				GroupID:         "grp_2",
+			}).Return(&papi.GetPropertyVersionHostnamesResponse{
+				Hostnames: papi.HostnameResponseItems{Items: hostnames},
+			}, nil)
+		}
+		expectHostnames("prp_1", 2, papi.Hostname{CnameFrom: "www.example.com", EdgeHostnameID: "ehn_1"})
+		expectHostnames("prp_1", 3,
+			papi.Hostname{CnameFrom: "www.example.com", EdgeHostnameID: "ehn_2"},
+			papi.Hostname{CnameFrom: "example.com", EdgeHostnameID: "ehn_2"},
+		)
+		expectHostnames("prp_2", 1, papi.Hostname{CnameFrom: "other.example.com", EdgeHostnameID: "ehn_1"})
+
+		useClient(client, clientHapi, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{{
+					Config: loadFixtureString("testdata/TestDataEdgeHostname/edge_hostname.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "id", "ehn_2"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "edge_hostname_id", "ehn_2"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "edge_hostname", "www.example.com.edgekey.net"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "product_id", "prd_SPM"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "ip_behavior", "IPV6_COMPLIANCE"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "secure", "true"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "ttl", "300"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "slot_number", "12345"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "serial_number", "1061"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "properties.#", "1"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "properties.0.property_id", "prp_1"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "properties.0.property_name", "example"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "properties.0.version", "3"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "properties.0.hostnames.#", "2"),
+						resource.TestCheckResourceAttr("data.akamai_edge_hostname.test", "properties.0.hostnames.1", "example.com"),
+					),
+				}},
+			})
+		})
+		client.AssertExpectations(t)
+		clientHapi.AssertExpectations(t)
+	})
+
+	t.Run("edge hostname not found", func(t *testing.T) {
+		client := &papi.Mock{}
+		expectGetEdgeHostnames(client)
+
+		useClient(client, &hapi.Mock{}, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{{
+					Config:      loadFixtureString("testdata/TestDataEdgeHostname/not_found.tf"),
+					ExpectError: regexp.MustCompile("unable to find edge hostname"),
+				}},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,216 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceEdgeHostnames() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataEdgeHostnamesRead,
+		Schema: map[string]*schema.Schema{
+			"contract_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Identifies the contract under which the edge hostnames are listed",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Identifies the group under which the edge hostnames are listed",
+			},
+			"domain_suffix": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: tools.ValidateStringInSlice([]string{"edgesuite.net", "edgekey.net", "akamaized.net"}),
+				Description:      "Lists only the edge hostnames with the domain suffix, either `edgesuite.net`, `edgekey.net` or `akamaized.net`",
+			},
+			"ip_behavior": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateDiagFunc: tools.ValidateStringInSlice([]string{
+					papi.EHIPVersionV4, papi.EHIPVersionV6Performance, papi.EHIPVersionV6Compliance,
+				}),
+				Description: "Lists only the edge hostnames with the IP version behavior, either `IPV4`, `IPV6_PERFORMANCE` or `IPV6_COMPLIANCE`",
+			},
+			"secure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Lists only the secure edge hostnames when true, or only the non-secure ones when false",
+			},
+			"edge_hostnames": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of edge hostnames",
+				Elem: &schema.Resource{
+					Schema: edgeHostnameAttributesSchema(),
+				},
+			},
+		},
+	}
+}
+
+// edgeHostnameAttributesSchema returns the schema of the edge hostname attributes read from PAPI
+func edgeHostnameAttributesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"edge_hostname_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The edge hostname's unique identifier",
+		},
+		"edge_hostname": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The full domain of the edge hostname",
+		},
+		"domain_prefix": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The domain of the edge hostname without the suffix",
+		},
+		"domain_suffix": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The domain suffix of the edge hostname",
+		},
+		"product_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The product the edge hostname was created with",
+		},
+		"ip_behavior": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The IP version behavior of the edge hostname",
+		},
+		"secure": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the edge hostname serves secure traffic",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The status of the edge hostname, e.g. `PENDING` while it is being created",
+		},
+		"use_cases": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "A JSON encoded list of use cases",
+		},
+	}
+}
+
+type edgeHostnamesFilter struct {
+	domainSuffix string
+	ipBehavior   string
+	secure       *bool
+}
+
+func dataEdgeHostnamesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	logger := meta.Log("PAPI", "dataEdgeHostnamesRead")
+	logger.Debug("Reading edge hostnames")
+
+	contractID, err := tools.GetStringValue("contract_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	groupID, err := tools.GetStringValue("group_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var filter edgeHostnamesFilter
+	if filter.domainSuffix, err = tools.GetStringValue("domain_suffix", d); err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if filter.ipBehavior, err = tools.GetStringValue("ip_behavior", d); err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if secure, ok := d.GetOkExists("secure"); ok {
+		value := secure.(bool)
+		filter.secure = &value
+	}
+
+	edgeHostnames, err := client.GetEdgeHostnames(ctx, papi.GetEdgeHostnamesRequest{
+		ContractID: contractID,
+		GroupID:    groupID,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("could not list edge hostnames: %w", err))
+	}
+
+	var attrs []interface{}
+	for _, ehn := range edgeHostnames.EdgeHostnames.Items {
+		if !filter.matches(ehn) {
+			continue
+		}
+		ehnAttrs, err := edgeHostnameAttributes(ehn)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		attrs = append(attrs, ehnAttrs)
+	}
+	logger.Debugf("Found %d of %d edge hostnames", len(attrs), len(edgeHostnames.EdgeHostnames.Items))
+
+	if err := d.Set("edge_hostnames", attrs); err != nil {
+		return diag.Errorf("%v: %s", tools.ErrValueSet, err.Error())
+	}
+	d.SetId(filter.id(contractID, groupID))
+
+	return nil
+}
+
+func (f edgeHostnamesFilter) matches(ehn papi.EdgeHostnameGetItem) bool {
+	if f.domainSuffix != "" && !strings.EqualFold(ehn.DomainSuffix, f.domainSuffix) {
+		return false
+	}
+	if f.ipBehavior != "" && ehn.IPVersionBehavior != f.ipBehavior {
+		return false
+	}
+	if f.secure != nil && ehn.Secure != *f.secure {
+		return false
+	}
+	return true
+}
+
+func (f edgeHostnamesFilter) id(contractID, groupID string) string {
+	idElements := []string{contractID, groupID, f.domainSuffix, f.ipBehavior}
+	if f.secure != nil {
+		idElements = append(idElements, strconv.FormatBool(*f.secure))
+	}
+	return strings.Join(idElements, ":")
+}
+
+// edgeHostnameAttributes returns the attributes of edgeHostnameAttributesSchema
+func edgeHostnameAttributes(ehn papi.EdgeHostnameGetItem) (map[string]interface{}, error) {
+	var useCases string
+	if len(ehn.UseCases) > 0 {
+		useCasesJSON, err := useCases2JSON(ehn.UseCases)
+		if err != nil {
+			return nil, err
+		}
+		useCases = string(useCasesJSON)
+	}
+	return map[string]interface{}{
+		"edge_hostname_id": ehn.ID,
+		"edge_hostname":    ehn.Domain,
+		"domain_prefix":    ehn.DomainPrefix,
+		"domain_suffix":    ehn.DomainSuffix,
+		"product_id":       ehn.ProductID,
+		"ip_behavior":      ehn.IPVersionBehavior,
+		"secure":           ehn.Secure,
+		"status":           ehn.Status,
+		"use_cases":        useCases,
+	}, nil
+}
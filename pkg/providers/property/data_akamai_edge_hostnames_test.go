@@ -0,0 +1,122 @@
+package property
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataEdgeHostnames(t *testing.T) {
+	edgeHostnames := []papi.EdgeHostnameGetItem{
+		{
+			ID:                "ehn_1",
+			Domain:            "www.example.com.edgesuite.net",
+			ProductID:         "prd_SPM",
+			DomainPrefix:      "www.example.com",
+			DomainSuffix:      "edgesuite.net",
+			Status:            "ACTIVE",
+			IPVersionBehavior: "IPV4",
+		},
+		{
+			ID:                "ehn_2",
+			Domain:            "www.example.com.edgekey.net",
+			ProductID:         "prd_SPM",
+			DomainPrefix:      "www.example.com",
+			DomainSuffix:      "edgekey.net",
+			Secure:            true,
+			IPVersionBehavior: "IPV6_COMPLIANCE",
+			UseCases:          []papi.UseCase{{Option: "BACKGROUND", Type: "GLOBAL", UseCase: "Download_Mode"}},
+		},
+		{
+			ID:                "ehn_3",
+			Domain:            "static.example.com.edgekey.net",
+			ProductID:         "prd_Fresca",
+			DomainPrefix:      "static.example.com",
+			DomainSuffix:      "edgekey.net",
+			Secure:            true,
+			IPVersionBehavior: "IPV4",
+		},
+	}
+
+	tests := map[string]struct {
+		configPath string
+		getError   error
+		checks     resource.TestCheckFunc
+		withError  *regexp.Regexp
+	}{
+		"all edge hostnames": {
+			configPath: "testdata/TestDataEdgeHostnames/no_filters.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "id", "ctr_1:grp_2::"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.#", "3"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.edge_hostname_id", "ehn_1"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.edge_hostname", "www.example.com.edgesuite.net"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.domain_prefix", "www.example.com"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.domain_suffix", "edgesuite.net"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.product_id", "prd_SPM"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.ip_behavior", "IPV4"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.secure", "false"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.status", "ACTIVE"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.use_cases", ""),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.1.use_cases", "[\n  {\n    \"option\": \"BACKGROUND\",\n    \"type\": \"GLOBAL\",\n    \"useCase\": \"Download_Mode\"\n  }\n]"),
+			),
+		},
+		"filtered edge hostnames": {
+			configPath: "testdata/TestDataEdgeHostnames/filters.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "id", "ctr_1:grp_2:edgekey.net:IPV6_COMPLIANCE:true"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.#", "1"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.edge_hostname_id", "ehn_2"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.secure", "true"),
+			),
+		},
+		"non-secure edge hostnames": {
+			configPath: "testdata/TestDataEdgeHostnames/not_secure.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "id", "ctr_1:grp_2:::false"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.#", "1"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.edge_hostname_id", "ehn_1"),
+			),
+		},
+		"error listing edge hostnames": {
+			configPath: "testdata/TestDataEdgeHostnames/no_filters.tf",
+			getError:   errors.New("oops"),
+			withError:  regexp.MustCompile("could not list edge hostnames: oops"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &papi.Mock{}
+			call := client.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{
+				ContractID: "ctr_1",
+				GroupID:    "grp_2",
+			})
+			if test.getError != nil {
+				call.Return(nil, test.getError)
+			} else {
+				call.Return(&papi.GetEdgeHostnamesResponse{
+					ContractID:    "ctr_1",
+					GroupID:       "grp_2",
+					EdgeHostnames: papi.EdgeHostnameItems{Items: edgeHostnames},
+				}, nil)
+			}
+
+			useClient(client, nil, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{{
+						Config:      loadFixtureString(test.configPath),
+						Check:       test.checks,
+						ExpectError: test.withError,
+					}},
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
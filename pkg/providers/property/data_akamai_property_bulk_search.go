@@ -0,0 +1,123 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+)
+
+func dataSourcePropertyBulkSearch() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataPropertyBulkSearchRead,
+		Schema: map[string]*schema.Schema{
+			"contract_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Limits the search to the properties of the contract",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Limits the search to the properties of the group",
+			},
+			"match": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "JSONPath expression matched against the rule trees, e.g. $..behaviors[?(@.name == 'origin')]",
+				ValidateDiagFunc: tools.IsNotBlank,
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The latest matching version of each property",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id":       {Type: schema.TypeString, Computed: true},
+						"property_name":     {Type: schema.TypeString, Computed: true},
+						"property_version":  {Type: schema.TypeInt, Computed: true},
+						"is_latest":         {Type: schema.TypeBool, Computed: true},
+						"is_locked":         {Type: schema.TypeBool, Computed: true},
+						"production_status": {Type: schema.TypeString, Computed: true},
+						"staging_status":    {Type: schema.TypeString, Computed: true},
+						"match_locations": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(bulkPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
+		},
+	}
+}
+
+func dataPropertyBulkSearchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "dataPropertyBulkSearchRead")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	bulk := newBulkClient(inst.Executor(meta), logger, func() (*tools.Poller, error) {
+		return tools.NewPoller("property bulk search", d, bulkPollInterval, bulkPollMinimum, logger)
+	})
+
+	contractID, groupID, match, err := getBulkSearchQuery(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	results, err := bulk.search(ctx, contractID, groupID, match)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("results", flattenBulkSearchResults(results)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	d.SetId(fmt.Sprintf("%s:%s:%s", contractID, groupID, match))
+	return nil
+}
+
+// getBulkSearchQuery returns the contract, group and JSONPath expression of the bulk search
+func getBulkSearchQuery(d tools.ResourceDataFetcher) (string, string, string, error) {
+	values := make([]string, 3)
+	for i, key := range []string{"contract_id", "group_id", "match"} {
+		value, err := tools.GetStringValue(key, d)
+		if err != nil && !errors.Is(err, tools.ErrNotFound) {
+			return "", "", "", err
+		}
+		values[i] = value
+	}
+	contractID, groupID := values[0], values[1]
+	if contractID != "" {
+		contractID = tools.AddPrefix(contractID, "ctr_")
+	}
+	if groupID != "" {
+		groupID = tools.AddPrefix(groupID, "grp_")
+	}
+	return contractID, groupID, values[2], nil
+}
+
+func flattenBulkSearchResults(results []bulkSearchResult) []interface{} {
+	flattened := make([]interface{}, 0, len(results))
+	for _, r := range results {
+		flattened = append(flattened, map[string]interface{}{
+			"property_id":       r.PropertyID,
+			"property_name":     r.PropertyName,
+			"property_version":  r.PropertyVersion,
+			"is_latest":         r.IsLatest,
+			"is_locked":         r.IsLocked,
+			"production_status": r.ProductionStatus,
+			"staging_status":    r.StagingStatus,
+			"match_locations":   r.MatchLocations,
+		})
+	}
+	return flattened
+}
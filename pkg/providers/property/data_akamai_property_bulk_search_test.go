@@ -0,0 +1,110 @@
+package property
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataPropertyBulkSearch(t *testing.T) {
+	searchResults := `{
+  "bulkSearchId": 5,
+  "searchTargetStatus": "COMPLETE",
+  "results": [
+    {"propertyId": "prp_2", "propertyName": "second", "propertyVersion": 1, "isLatest": true, "isLocked": false,
+     "productionStatus": "INACTIVE", "stagingStatus": "ACTIVE", "matchLocations": ["/rules/behaviors/0"]},
+    {"propertyId": "prp_1", "propertyName": "first", "propertyVersion": 2, "isLatest": false, "isLocked": true,
+     "productionStatus": "ACTIVE", "stagingStatus": "INACTIVE", "matchLocations": ["/rules/behaviors/0"]},
+    {"propertyId": "prp_1", "propertyName": "first", "propertyVersion": 3, "isLatest": true, "isLocked": false,
+     "productionStatus": "INACTIVE", "stagingStatus": "INACTIVE", "matchLocations": ["/rules/behaviors/0", "/rules/children/0/behaviors/1"]}
+  ]
+}`
+
+	tests := map[string]struct {
+		config           string
+		responses        map[string]bulkResponse
+		expectedRequests []string
+		checks           resource.TestCheckFunc
+		withError        *regexp.Regexp
+	}{
+		"latest matching version of each property": {
+			config: "search.tf",
+			responses: map[string]bulkResponse{
+				"POST /papi/v1/bulk/rules-search-requests":  {status: http.StatusAccepted, body: `{"bulkSearchLink": "/papi/v1/bulk/rules-search-requests/5"}`},
+				"GET /papi/v1/bulk/rules-search-requests/5": {status: http.StatusOK, body: searchResults},
+			},
+			expectedRequests: []string{
+				`POST /papi/v1/bulk/rules-search-requests?contractId=ctr_1&groupId=grp_2 {"bulkSearchQuery":{"match":"$..behaviors[?(@.name == 'origin')]","syntax":"JSONPATH"}}`,
+				"GET /papi/v1/bulk/rules-search-requests/5",
+			},
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "id", "ctr_1:grp_2:$..behaviors[?(@.name == 'origin')]"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.#", "2"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.property_id", "prp_1"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.property_name", "first"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.property_version", "3"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.is_latest", "true"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.is_locked", "false"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.match_locations.#", "2"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.match_locations.1", "/rules/children/0/behaviors/1"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.1.property_id", "prp_2"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.1.property_version", "1"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.1.staging_status", "ACTIVE"),
+			),
+		},
+		"all properties": {
+			config: "no_filters.tf",
+			responses: map[string]bulkResponse{
+				"POST /papi/v1/bulk/rules-search-requests":  {status: http.StatusAccepted, body: `{"bulkSearchLink": "/papi/v1/bulk/rules-search-requests/5"}`},
+				"GET /papi/v1/bulk/rules-search-requests/5": {status: http.StatusOK, body: `{"bulkSearchId": 5, "searchTargetStatus": "COMPLETE", "results": []}`},
+			},
+			expectedRequests: []string{
+				`POST /papi/v1/bulk/rules-search-requests {"bulkSearchQuery":{"match":"$..behaviors[?(@.name == 'origin')]","syntax":"JSONPATH"}}`,
+				"GET /papi/v1/bulk/rules-search-requests/5",
+			},
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "id", "::$..behaviors[?(@.name == 'origin')]"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.#", "0"),
+			),
+		},
+		"search rejected": {
+			config: "search.tf",
+			responses: map[string]bulkResponse{
+				"POST /papi/v1/bulk/rules-search-requests": {status: http.StatusBadRequest, body: `{"title": "Invalid JSONPath", "status": 400}`},
+			},
+			withError: regexp.MustCompile("(?s)bulk operation: search: .*Invalid JSONPath"),
+		},
+		"search without link": {
+			config: "search.tf",
+			responses: map[string]bulkResponse{
+				"POST /papi/v1/bulk/rules-search-requests": {status: http.StatusAccepted, body: `{}`},
+			},
+			withError: regexp.MustCompile("bulk operation: search: response has no bulkSearchLink"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sess := &bulkSession{responses: test.responses}
+			useExecutor(&papi.Mock{}, sess, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers:  testAccProviders,
+					IsUnitTest: true,
+					Steps: []resource.TestStep{{
+						Config:      loadFixtureString("testdata/TestDataPropertyBulkSearch/%s", test.config),
+						Check:       test.checks,
+						ExpectError: test.withError,
+					}},
+				})
+			})
+			if test.expectedRequests != nil {
+				// the data source is read again for the plan after the apply
+				assert.Equal(t, test.expectedRequests, sess.requests[:len(test.expectedRequests)])
+			}
+		})
+	}
+}
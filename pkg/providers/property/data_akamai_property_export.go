@@ -0,0 +1,573 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+)
+
+func dataSourcePropertyExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataPropertyExportRead,
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        addPrefixToState("prp_"),
+				ValidateDiagFunc: tools.IsNotBlank,
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The property version to export. Defaults to the latest version",
+			},
+			"contract_id": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				StateFunc: addPrefixToState("ctr_"),
+			},
+			"group_id": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				StateFunc: addPrefixToState("grp_"),
+			},
+			"property_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"product_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rule_format": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"config": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Terraform configuration of the property, its edge hostnames, CP codes and activations",
+			},
+			"snippets": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Rule tree files of the 'property-snippets' folder by name: main.json and one file per top-level child rule",
+			},
+			"import_script": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "terraform import commands adopting the exported resources",
+			},
+		},
+	}
+}
+
+type (
+	// propertyExport holds the values rendered in the exported configuration
+	propertyExport struct {
+		Name          string
+		PropertyID    string
+		PropertyName  string
+		ContractID    string
+		GroupID       string
+		ProductID     string
+		RuleFormat    string
+		Version       int
+		Hostnames     []exportHostname
+		EdgeHostnames []exportEdgeHostname
+		CPCodes       []exportCPCode
+		Activations   []exportActivation
+	}
+
+	exportHostname struct {
+		CnameFrom            string
+		CnameTo              string
+		CertProvisioningType string
+		// EdgeHostname is the name of the exported edge hostname resource, empty if it wasn't found in the group
+		EdgeHostname string
+	}
+
+	exportEdgeHostname struct {
+		Name       string
+		ID         string
+		Domain     string
+		ProductID  string
+		IPBehavior string
+	}
+
+	exportCPCode struct {
+		Name      string
+		ID        string
+		CPCode    string
+		ProductID string
+	}
+
+	exportActivation struct {
+		Name    string
+		Network string
+		Version int
+		Contact []string
+		Note    string
+	}
+
+	// exportRules is a rule whose children are replaced by the includes of their snippets
+	exportRules struct {
+		papi.Rules
+		Children []string `json:"children,omitempty"`
+	}
+)
+
+var (
+	exportNameRegexp = regexp.MustCompile(`[^a-z0-9_-]+`)
+	snippetRegexp    = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+	exportFuncs = template.FuncMap{
+		"hcl": hclString,
+		"hclList": func(in []string) string {
+			quoted := make([]string, 0, len(in))
+			for _, s := range in {
+				quoted = append(quoted, hclString(s))
+			}
+			return "[" + strings.Join(quoted, ", ") + "]"
+		},
+	}
+
+	propertyExportTemplate = template.Must(template.New("property").Funcs(exportFuncs).Parse(`{{- range .CPCodes}}
+resource "akamai_cp_code" "{{.Name}}" {
+  name        = {{hcl .CPCode}}
+  contract_id = {{hcl $.ContractID}}
+  group_id    = {{hcl $.GroupID}}
+  product_id  = {{hcl .ProductID}}
+}
+{{end}}
+{{- range .EdgeHostnames}}
+resource "akamai_edge_hostname" "{{.Name}}" {
+  contract_id   = {{hcl $.ContractID}}
+  group_id      = {{hcl $.GroupID}}
+  product_id    = {{hcl .ProductID}}
+  edge_hostname = {{hcl .Domain}}
+  ip_behavior   = {{hcl .IPBehavior}}
+}
+{{end}}
+data "akamai_property_rules_template" "{{.Name}}" {
+  template_file = abspath("${path.module}/property-snippets/main.json")
+}
+
+resource "akamai_property" "{{.Name}}" {
+  name        = {{hcl .PropertyName}}
+  contract_id = {{hcl .ContractID}}
+  group_id    = {{hcl .GroupID}}
+  product_id  = {{hcl .ProductID}}
+  rule_format = {{hcl .RuleFormat}}
+{{- range .Hostnames}}
+
+  hostnames {
+    cname_from             = {{hcl .CnameFrom}}
+    cname_to               = {{if .EdgeHostname}}akamai_edge_hostname.{{.EdgeHostname}}.edge_hostname{{else}}{{hcl .CnameTo}}{{end}}
+    cert_provisioning_type = {{hcl .CertProvisioningType}}
+  }
+{{- end}}
+
+  rules = data.akamai_property_rules_template.{{.Name}}.json
+}
+{{- range .Activations}}
+
+resource "akamai_property_activation" "{{.Name}}" {
+  property_id = akamai_property.{{$.Name}}.id
+  network     = {{hcl .Network}}
+  version     = {{.Version}}
+{{- if .Contact}}
+  contact     = {{hclList .Contact}}
+{{- end}}
+{{- if .Note}}
+  note        = {{hcl .Note}}
+{{- end}}
+}
+{{- end}}
+`))
+
+	propertyImportTemplate = template.Must(template.New("import").Funcs(exportFuncs).Parse(`{{- range .CPCodes}}
+terraform import akamai_cp_code.{{.Name}} {{.ID}},{{$.ContractID}},{{$.GroupID}}
+{{- end}}
+{{- range .EdgeHostnames}}
+terraform import akamai_edge_hostname.{{.Name}} {{.ID}},{{$.ContractID}},{{$.GroupID}}
+{{- end}}
+terraform import akamai_property.{{.Name}} {{.PropertyID}},{{.ContractID}},{{.GroupID}},{{.Version}}
+{{- range .Activations}}
+terraform import akamai_property_activation.{{.Name}} {{$.PropertyID}}:{{.Network}}:v{{.Version}}
+{{- end}}
+`))
+)
+
+func dataPropertyExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "dataPropertyExportRead")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	client := inst.Client(meta)
+
+	propertyID, err := tools.GetStringValue("property_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	propertyID = tools.AddPrefix(propertyID, "prp_")
+	contractID, err := tools.GetStringValue("contract_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	groupID, err := tools.GetStringValue("group_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if contractID != "" {
+		contractID = tools.AddPrefix(contractID, "ctr_")
+	}
+	if groupID != "" {
+		groupID = tools.AddPrefix(groupID, "grp_")
+	}
+
+	prop, err := client.GetProperty(ctx, papi.GetPropertyRequest{PropertyID: propertyID, ContractID: contractID, GroupID: groupID})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	property := prop.Property
+
+	version, err := tools.GetIntValue("version", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if version == 0 {
+		version = property.LatestVersion
+	}
+
+	export, snippets, err := exportProperty(ctx, client, property, version)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var config, imports bytes.Buffer
+	if err := propertyExportTemplate.Execute(&config, export); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := propertyImportTemplate.Execute(&imports, export); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"version":       version,
+		"contract_id":   property.ContractID,
+		"group_id":      property.GroupID,
+		"property_name": property.PropertyName,
+		"product_id":    export.ProductID,
+		"rule_format":   export.RuleFormat,
+		"config":        strings.TrimLeft(config.String(), "\n"),
+		"snippets":      snippets,
+		"import_script": strings.TrimLeft(imports.String(), "\n"),
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s:%d", propertyID, version))
+	return nil
+}
+
+// exportProperty collects the version, hostnames, edge hostnames, CP codes and activations of the property
+// and splits its rule tree into snippets
+func exportProperty(ctx context.Context, client papi.PAPI, property *papi.Property, version int) (*propertyExport, map[string]interface{}, error) {
+	export := &propertyExport{
+		Name:         exportName(property.PropertyName),
+		PropertyID:   property.PropertyID,
+		PropertyName: property.PropertyName,
+		ContractID:   property.ContractID,
+		GroupID:      property.GroupID,
+		Version:      version,
+	}
+
+	versionResp, err := client.GetPropertyVersion(ctx, papi.GetPropertyVersionRequest{
+		PropertyID:      property.PropertyID,
+		PropertyVersion: version,
+		ContractID:      property.ContractID,
+		GroupID:         property.GroupID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	export.ProductID = versionResp.Version.ProductID
+
+	rules, err := client.GetRuleTree(ctx, papi.GetRuleTreeRequest{
+		PropertyID:      property.PropertyID,
+		PropertyVersion: version,
+		ContractID:      property.ContractID,
+		GroupID:         property.GroupID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	export.RuleFormat = rules.RuleFormat
+	snippets, err := exportSnippets(rules.Rules, rules.Comments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := exportHostnames(ctx, client, export); err != nil {
+		return nil, nil, err
+	}
+	if err := exportCPCodes(ctx, client, export, rules.Rules); err != nil {
+		return nil, nil, err
+	}
+	if err := exportActivations(ctx, client, export, property); err != nil {
+		return nil, nil, err
+	}
+
+	return export, snippets, nil
+}
+
+// exportSnippets returns main.json holding the default rule, which includes one snippet per top-level child rule
+func exportSnippets(rules papi.Rules, comments string) (map[string]interface{}, error) {
+	snippets := make(map[string]interface{}, len(rules.Children)+1)
+	main := exportRules{Rules: rules}
+	main.Rules.Children = nil
+	seen := make(map[string]int)
+	for _, child := range rules.Children {
+		name := snippetRegexp.ReplaceAllString(child.Name, "_")
+		if seen[name]++; seen[name] > 1 {
+			name = fmt.Sprintf("%s_%d", name, seen[name])
+		}
+		name += ".json"
+		snippet, err := marshalSnippet(child)
+		if err != nil {
+			return nil, err
+		}
+		snippets[name] = snippet
+		main.Children = append(main.Children, "#include:"+name)
+	}
+
+	snippet, err := marshalSnippet(struct {
+		Comments string      `json:"comments,omitempty"`
+		Rules    exportRules `json:"rules"`
+	}{Comments: comments, Rules: main})
+	if err != nil {
+		return nil, err
+	}
+	snippets["main.json"] = snippet
+	return snippets, nil
+}
+
+func marshalSnippet(v interface{}) (string, error) {
+	buf := bytes.Buffer{}
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// exportHostnames adds the hostnames of the version and the edge hostnames of the group they point to
+func exportHostnames(ctx context.Context, client papi.PAPI, export *propertyExport) error {
+	hostnames, err := client.GetPropertyVersionHostnames(ctx, papi.GetPropertyVersionHostnamesRequest{
+		PropertyID:      export.PropertyID,
+		PropertyVersion: export.Version,
+		ContractID:      export.ContractID,
+		GroupID:         export.GroupID,
+	})
+	if err != nil {
+		return err
+	}
+	if len(hostnames.Hostnames.Items) == 0 {
+		return nil
+	}
+
+	edgeHostnames, err := client.GetEdgeHostnames(ctx, papi.GetEdgeHostnamesRequest{
+		ContractID: export.ContractID,
+		GroupID:    export.GroupID,
+	})
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]papi.EdgeHostnameGetItem, len(edgeHostnames.EdgeHostnames.Items))
+	for _, ehn := range edgeHostnames.EdgeHostnames.Items {
+		byID[ehn.ID] = ehn
+	}
+
+	names := make(map[string]string)
+	for _, h := range hostnames.Hostnames.Items {
+		hostname := exportHostname{
+			CnameFrom:            h.CnameFrom,
+			CnameTo:              h.CnameTo,
+			CertProvisioningType: h.CertProvisioningType,
+		}
+		if ehn, ok := byID[h.EdgeHostnameID]; ok {
+			name, ok := names[ehn.ID]
+			if !ok {
+				name = uniqueExportName(ehn.Domain, len(export.EdgeHostnames), func(i int) string { return export.EdgeHostnames[i].Name })
+				names[ehn.ID] = name
+				export.EdgeHostnames = append(export.EdgeHostnames, exportEdgeHostname{
+					Name:       name,
+					ID:         ehn.ID,
+					Domain:     ehn.Domain,
+					ProductID:  ehn.ProductID,
+					IPBehavior: ehn.IPVersionBehavior,
+				})
+			}
+			hostname.EdgeHostname = name
+		}
+		export.Hostnames = append(export.Hostnames, hostname)
+	}
+	return nil
+}
+
+// exportCPCodes adds the CP codes of the group used by the cpCode behaviors of the rule tree
+func exportCPCodes(ctx context.Context, client papi.PAPI, export *propertyExport, rules papi.Rules) error {
+	var ids []int
+	seen := make(map[int]bool)
+	var walk func(papi.Rules)
+	walk = func(rule papi.Rules) {
+		for _, behavior := range rule.Behaviors {
+			if behavior.Name != "cpCode" {
+				continue
+			}
+			value, ok := behavior.Options["value"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if id, ok := value["id"].(float64); ok && !seen[int(id)] {
+				seen[int(id)] = true
+				ids = append(ids, int(id))
+			}
+		}
+		for _, child := range rule.Children {
+			walk(child)
+		}
+	}
+	walk(rules)
+
+	for _, id := range ids {
+		resp, err := client.GetCPCode(ctx, papi.GetCPCodeRequest{
+			CPCodeID:   strconv.Itoa(id),
+			ContractID: export.ContractID,
+			GroupID:    export.GroupID,
+		})
+		if akamai.IsAPINotFound(err) {
+			// CP codes of other groups are kept as IDs in the rules
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		cpCode := exportCPCode{
+			ID:        tools.AddPrefix(resp.CPCode.ID, "cpc_"),
+			CPCode:    resp.CPCode.Name,
+			ProductID: export.ProductID,
+		}
+		if len(resp.CPCode.ProductIDs) > 0 {
+			cpCode.ProductID = resp.CPCode.ProductIDs[0]
+		}
+		cpCode.Name = uniqueExportName(cpCode.CPCode, len(export.CPCodes), func(i int) string { return export.CPCodes[i].Name })
+		export.CPCodes = append(export.CPCodes, cpCode)
+	}
+	return nil
+}
+
+// exportActivations adds the activation of the version active on each network
+func exportActivations(ctx context.Context, client papi.PAPI, export *propertyExport, property *papi.Property) error {
+	active := make(map[papi.ActivationNetwork]int)
+	if property.StagingVersion != nil {
+		active[papi.ActivationNetworkStaging] = *property.StagingVersion
+	}
+	if property.ProductionVersion != nil {
+		active[papi.ActivationNetworkProduction] = *property.ProductionVersion
+	}
+	if len(active) == 0 {
+		return nil
+	}
+
+	activations, err := client.GetActivations(ctx, papi.GetActivationsRequest{
+		PropertyID: export.PropertyID,
+		ContractID: export.ContractID,
+		GroupID:    export.GroupID,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, network := range []papi.ActivationNetwork{papi.ActivationNetworkStaging, papi.ActivationNetworkProduction} {
+		version, ok := active[network]
+		if !ok {
+			continue
+		}
+		activation := exportActivation{
+			Name:    fmt.Sprintf("%s_%s", export.Name, strings.ToLower(string(network))),
+			Network: string(network),
+			Version: version,
+		}
+		var latest *papi.Activation
+		for _, a := range activations.Activations.Items {
+			if a.Network != network || a.PropertyVersion != version || a.ActivationType != papi.ActivationTypeActivate {
+				continue
+			}
+			if latest == nil || a.SubmitDate > latest.SubmitDate {
+				latest = a
+			}
+		}
+		if latest != nil {
+			activation.Contact = append([]string{}, latest.NotifyEmails...)
+			sort.Strings(activation.Contact)
+			activation.Note = latest.Note
+		}
+		export.Activations = append(export.Activations, activation)
+	}
+	return nil
+}
+
+// exportName returns a Terraform resource name for the given Akamai name
+func exportName(name string) string {
+	name = strings.Trim(exportNameRegexp.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "_" + name
+	}
+	return name
+}
+
+// uniqueExportName returns the resource name of the given Akamai name, suffixed when it's already used by one of the n previous resources
+func uniqueExportName(name string, n int, nameOf func(int) string) string {
+	base := exportName(name)
+	used := make(map[string]bool, n)
+	for i := 0; i < n; i++ {
+		used[nameOf(i)] = true
+	}
+	name = base
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	return name
+}
+
+// hclString quotes the string as an HCL string literal, escaping template sequences
+func hclString(s string) string {
+	// JSON string escapes are a subset of the HCL ones
+	quoted, err := marshalSnippet(s)
+	if err != nil {
+		return strconv.Quote(s)
+	}
+	quoted = strings.ReplaceAll(strings.TrimSpace(quoted), "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
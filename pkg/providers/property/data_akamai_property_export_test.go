@@ -0,0 +1,207 @@
+package property
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataPropertyExport(t *testing.T) {
+	exportedRules := papi.Rules{
+		Name: "default",
+		Behaviors: []papi.RuleBehavior{
+			{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "origin.example.com"}},
+			{Name: "cpCode", Options: papi.RuleOptionsMap{"value": map[string]interface{}{"id": float64(123), "name": "main"}}},
+		},
+		Variables: []papi.RuleVariable{{Name: "PMUSER_PATH", Value: "/a&b"}},
+		Children: []papi.Rules{
+			{
+				Name:      "Performance",
+				Behaviors: []papi.RuleBehavior{{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "ttl": "1d"}}},
+			},
+			{
+				Name:      "Offload & Cache",
+				Behaviors: []papi.RuleBehavior{{Name: "cpCode", Options: papi.RuleOptionsMap{"value": map[string]interface{}{"id": float64(456)}}}},
+				Criteria:  []papi.RuleBehavior{{Name: "path", Options: papi.RuleOptionsMap{"matchOperator": "MATCHES_ONE_OF", "values": []interface{}{"/static/*"}}}},
+			},
+			{
+				Name:     "Performance",
+				Children: []papi.Rules{{Name: "Nested", Behaviors: []papi.RuleBehavior{{Name: "http2", Options: papi.RuleOptionsMap{}}}}},
+			},
+		},
+	}
+	stagingVersion, productionVersion := 2, 1
+
+	expectExport := func(m *papi.Mock, contractID, groupID string, version int) {
+		m.On("GetProperty", mock.Anything, papi.GetPropertyRequest{PropertyID: "prp_1", ContractID: contractID, GroupID: groupID}).
+			Return(&papi.GetPropertyResponse{Property: &papi.Property{
+				PropertyID:        "prp_1",
+				PropertyName:      "www.example.com",
+				ContractID:        "ctr_1",
+				GroupID:           "grp_2",
+				LatestVersion:     3,
+				StagingVersion:    &stagingVersion,
+				ProductionVersion: &productionVersion,
+			}}, nil)
+		m.On("GetPropertyVersion", mock.Anything, papi.GetPropertyVersionRequest{PropertyID: "prp_1", PropertyVersion: version, ContractID: "ctr_1", GroupID: "grp_2"}).
+			Return(&papi.GetPropertyVersionsResponse{Version: papi.PropertyVersionGetItem{PropertyVersion: version, ProductID: "prd_Fresca"}}, nil)
+		m.On("GetRuleTree", mock.Anything, papi.GetRuleTreeRequest{PropertyID: "prp_1", PropertyVersion: version, ContractID: "ctr_1", GroupID: "grp_2"}).
+			Return(&papi.GetRuleTreeResponse{RuleFormat: "v2023-01-05", Rules: exportedRules, Comments: "exported"}, nil)
+		m.On("GetPropertyVersionHostnames", mock.Anything, papi.GetPropertyVersionHostnamesRequest{PropertyID: "prp_1", PropertyVersion: version, ContractID: "ctr_1", GroupID: "grp_2"}).
+			Return(&papi.GetPropertyVersionHostnamesResponse{Hostnames: papi.HostnameResponseItems{Items: []papi.Hostname{
+				{CnameFrom: "www.example.com", CnameTo: "www.example.com.edgesuite.net", EdgeHostnameID: "ehn_1", CertProvisioningType: "CPS_MANAGED"},
+				{CnameFrom: "example.com", CnameTo: "www.example.com.edgesuite.net", EdgeHostnameID: "ehn_1", CertProvisioningType: "CPS_MANAGED"},
+				{CnameFrom: "static.example.com", CnameTo: "static.other.net", EdgeHostnameID: "ehn_9", CertProvisioningType: "DEFAULT"},
+			}}}, nil)
+		m.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{ContractID: "ctr_1", GroupID: "grp_2"}).
+			Return(&papi.GetEdgeHostnamesResponse{EdgeHostnames: papi.EdgeHostnameItems{Items: []papi.EdgeHostnameGetItem{
+				{ID: "ehn_1", Domain: "www.example.com.edgesuite.net", ProductID: "prd_Fresca", IPVersionBehavior: "IPV6_COMPLIANCE"},
+				{ID: "ehn_2", Domain: "unused.example.com.edgesuite.net", ProductID: "prd_Fresca", IPVersionBehavior: "IPV4"},
+			}}}, nil)
+		m.On("GetCPCode", mock.Anything, papi.GetCPCodeRequest{CPCodeID: "123", ContractID: "ctr_1", GroupID: "grp_2"}).
+			Return(&papi.GetCPCodesResponse{CPCode: papi.CPCode{ID: "cpc_123", Name: "www.example.com", ProductIDs: []string{"prd_Fresca"}}}, nil)
+		m.On("GetCPCode", mock.Anything, papi.GetCPCodeRequest{CPCodeID: "456", ContractID: "ctr_1", GroupID: "grp_2"}).
+			Return(nil, &papi.Error{StatusCode: http.StatusNotFound, Title: "Not Found"})
+		m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_2"}).
+			Return(&papi.GetActivationsResponse{Activations: papi.ActivationsItems{Items: []*papi.Activation{
+				{Network: papi.ActivationNetworkStaging, PropertyVersion: 2, ActivationType: papi.ActivationTypeActivate, SubmitDate: "2023-01-02T00:00:00Z",
+					NotifyEmails: []string{"ops@example.com", "dev@example.com"}, Note: "staging rollout"},
+				{Network: papi.ActivationNetworkStaging, PropertyVersion: 2, ActivationType: papi.ActivationTypeActivate, SubmitDate: "2023-01-01T00:00:00Z",
+					NotifyEmails: []string{"old@example.com"}},
+				{Network: papi.ActivationNetworkProduction, PropertyVersion: 1, ActivationType: papi.ActivationTypeActivate, SubmitDate: "2022-12-01T00:00:00Z",
+					NotifyEmails: []string{"ops@example.com"}},
+			}}}, nil)
+	}
+
+	checkExport := func(version int) resource.TestCheckFunc {
+		checks := []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("data.akamai_property_export.test", "id", fmt.Sprintf("prp_1:%d", version)),
+			resource.TestCheckResourceAttr("data.akamai_property_export.test", "version", fmt.Sprint(version)),
+			resource.TestCheckResourceAttr("data.akamai_property_export.test", "contract_id", "ctr_1"),
+			resource.TestCheckResourceAttr("data.akamai_property_export.test", "group_id", "grp_2"),
+			resource.TestCheckResourceAttr("data.akamai_property_export.test", "property_name", "www.example.com"),
+			resource.TestCheckResourceAttr("data.akamai_property_export.test", "product_id", "prd_Fresca"),
+			resource.TestCheckResourceAttr("data.akamai_property_export.test", "rule_format", "v2023-01-05"),
+			resource.TestCheckResourceAttr("data.akamai_property_export.test", "config", loadFixtureString("testdata/TestDataPropertyExport/expected/property.tf")),
+			resource.TestCheckResourceAttr("data.akamai_property_export.test", "import_script",
+				loadFixtureString("testdata/TestDataPropertyExport/expected/import_v%d.sh", version)),
+			resource.TestCheckResourceAttr("data.akamai_property_export.test", "snippets.%", "4"),
+		}
+		for _, snippet := range []string{"main.json", "Performance.json", "Offload_Cache.json", "Performance_2.json"} {
+			checks = append(checks, resource.TestCheckResourceAttr("data.akamai_property_export.test", "snippets."+snippet,
+				loadFixtureString("testdata/TestDataPropertyExport/expected/property-snippets/%s", snippet)))
+		}
+		return resource.ComposeAggregateTestCheckFunc(checks...)
+	}
+
+	tests := map[string]struct {
+		init      func(*papi.Mock)
+		config    string
+		checks    resource.TestCheckFunc
+		withError *regexp.Regexp
+	}{
+		"latest version": {
+			init: func(m *papi.Mock) {
+				expectExport(m, "", "", 3)
+			},
+			config: "export.tf",
+			checks: checkExport(3),
+		},
+		"given version": {
+			init: func(m *papi.Mock) {
+				expectExport(m, "ctr_1", "grp_2", 2)
+			},
+			config: "version.tf",
+			checks: checkExport(2),
+		},
+		"property not found": {
+			init: func(m *papi.Mock) {
+				m.On("GetProperty", mock.Anything, papi.GetPropertyRequest{PropertyID: "prp_1"}).
+					Return(nil, fmt.Errorf("%w: property not found", papi.ErrGetProperty)).Once()
+			},
+			config:    "export.tf",
+			withError: regexp.MustCompile("property not found"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &papi.Mock{}
+			test.init(client)
+			useClient(client, nil, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers:  testAccProviders,
+					IsUnitTest: true,
+					Steps: []resource.TestStep{{
+						Config:      loadFixtureString("testdata/TestDataPropertyExport/%s", test.config),
+						Check:       test.checks,
+						ExpectError: test.withError,
+					}},
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+
+	t.Run("snippets render the exported rules", func(t *testing.T) {
+		snippets, err := exportSnippets(exportedRules, "exported")
+		require.NoError(t, err)
+		dir := filepath.Join(t.TempDir(), "property-snippets")
+		require.NoError(t, os.Mkdir(dir, 0755))
+		for name, snippet := range snippets {
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(snippet.(string)), 0644))
+		}
+		expected, err := json.Marshal(papi.RulesUpdate{Rules: exportedRules, Comments: "exported"})
+		require.NoError(t, err)
+
+		useClient(&papi.Mock{}, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers:  testAccProviders,
+				IsUnitTest: true,
+				Steps: []resource.TestStep{{
+					Config: fmt.Sprintf(loadFixtureString("testdata/TestDataPropertyExport/template.tf"), filepath.ToSlash(filepath.Join(dir, "main.json"))),
+					Check: resource.TestCheckResourceAttrWith("data.akamai_property_rules_template.test", "json", func(value string) error {
+						if !assert.JSONEq(t, string(expected), value) {
+							return fmt.Errorf("rendered rules differ from the exported rules")
+						}
+						return nil
+					}),
+				}},
+			})
+		})
+	})
+}
+
+func TestExportName(t *testing.T) {
+	tests := map[string]string{
+		"www.example.com":    "www_example_com",
+		"Offload & Cache":    "offload_cache",
+		"123.example.com":    "_123_example_com",
+		"my-property":        "my-property",
+		"...":                "_",
+		"static.example.net": "static_example_net",
+	}
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, exportName(name))
+		})
+	}
+}
+
+func TestHCLString(t *testing.T) {
+	assert.Equal(t, `"plain"`, hclString("plain"))
+	assert.Equal(t, `"a \"quoted\" \\ value\n"`, hclString("a \"quoted\" \\ value\n"))
+	assert.Equal(t, `"$${var} %%{if}"`, hclString("${var} %{if}"))
+	assert.Equal(t, `"a&b<c>"`, hclString("a&b<c>"))
+}
@@ -0,0 +1,385 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+)
+
+var (
+	// ErrRulesBuilder is returned when the rule tree cannot be built from the blocks
+	ErrRulesBuilder = errors.New("building rule tree")
+
+	ruleVariableNameRegexp = regexp.MustCompile(`^PMUSER_[A-Z0-9_]+$`)
+)
+
+func dataSourcePropertyRulesBuilder() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataPropertyRulesBuilderRead,
+		Schema: map[string]*schema.Schema{
+			"rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.ValidateRuleFormat,
+				Description:      "The rule format of the rule tree, of the form vYYYY-MM-DD",
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "The rule, with its criteria, behaviors, variables and children",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: tools.IsNotBlank,
+							Description:      "The name of the rule, default for the top level rule",
+						},
+						"comments": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The comments of the rule",
+						},
+						"is_secure": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether the property serves HTTPS traffic, set only on the default rule",
+						},
+						"criteria_must_satisfy": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{string(papi.RuleCriteriaMustSatisfyAll), string(papi.RuleCriteriaMustSatisfyAny)}, false)),
+							Description:      "Whether all or any of the criteria must match, either all or any",
+						},
+						"criteria_locked": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether the criteria are locked from further edits",
+						},
+						"uuid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The UUID of the rule",
+						},
+						"template_uuid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The UUID of the rule template",
+						},
+						"template_link": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The link to the rule template",
+						},
+						"advanced_override": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The XML metadata of the advanced override, set only on the default rule",
+						},
+						"custom_override": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "The custom override of the rule",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The name of the custom override",
+									},
+									"override_id": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The ID of the custom override",
+									},
+								},
+							},
+						},
+						"variable": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The variables of the rule, set only on the default rule",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:             schema.TypeString,
+										Required:         true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(ruleVariableNameRegexp, "must start with PMUSER_ and contain only uppercase letters, digits and underscores")),
+										Description:      "The name of the variable, starting with PMUSER_",
+									},
+									"value": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The initial value of the variable",
+									},
+									"description": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The description of the variable",
+									},
+									"hidden": {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Description: "Whether the variable is hidden from the debug headers",
+									},
+									"sensitive": {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Description: "Whether the variable value is sensitive",
+									},
+								},
+							},
+						},
+						"criterion": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The criteria of the rule, in order",
+							Elem:        ruleBehaviorSchema("criterion"),
+						},
+						"behavior": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The behaviors of the rule, in order",
+							Elem:        ruleBehaviorSchema("behavior"),
+						},
+						"children": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The child rules, in order, as the json of other akamai_property_rules_builder data sources",
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: tools.ValidateJSON,
+							},
+						},
+					},
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rule tree JSON, for the rules of akamai_property or the children of another builder",
+			},
+		},
+	}
+}
+
+// ruleBehaviorSchema returns the schema of a behavior or a criterion block
+func ruleBehaviorSchema(kind string) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.IsNotBlank,
+				Description:      fmt.Sprintf("The name of the %s, e.g. origin", kind),
+			},
+			"options": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "{}",
+				ValidateDiagFunc: tools.ValidateJSON,
+				Description:      fmt.Sprintf("The options of the %s as a JSON object, usually written with jsonencode", kind),
+			},
+			"locked": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: fmt.Sprintf("Whether the %s is locked from further edits", kind),
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: fmt.Sprintf("The UUID of the %s", kind),
+			},
+			"template_uuid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: fmt.Sprintf("The UUID of the template of the %s", kind),
+			},
+		},
+	}
+}
+
+func dataPropertyRulesBuilderRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "dataPropertyRulesBuilderRead")
+
+	ruleFormat, err := tools.GetStringValue("rule_format", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rulesList, err := tools.GetListValue("rules", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ruleMap, ok := rulesList[0].(map[string]interface{})
+	if !ok {
+		return diag.Errorf("%s: %s, %q", tools.ErrInvalidType, "rules", "map[string]interface{}")
+	}
+
+	rules, err := buildRule(ruleMap)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debugf("Built rule %q with %d children for rule format %s", rules.Name, len(rules.Children), ruleFormat)
+
+	rulesJSON, err := marshalRuleTree(rules)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the rules are validated against the rule format schema if it is in the schema directory
+	s, err := loadRuleFormatSchema(ruleFormatSchemaDir(), "", ruleFormat)
+	if err != nil {
+		logger.Debugf("rules are not validated against the %s schema: %s", ruleFormat, err)
+	} else if err := s.validateRulesJSON(string(rulesJSON)); err != nil {
+		return diag.Errorf("%s %s:\n%s", ErrRulesSchemaValidation, ruleFormat, err)
+	}
+
+	h := sha1.New()
+	h.Write([]byte(ruleFormat))
+	h.Write(rulesJSON)
+	d.SetId(hex.EncodeToString(h.Sum(nil)))
+
+	if err := d.Set("json", string(rulesJSON)); err != nil {
+		return diag.Errorf("%v: %s", tools.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
+// marshalRuleTree returns the indented JSON of the rule tree, in the form accepted by akamai_property rules
+func marshalRuleTree(rules papi.Rules) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(papi.RulesUpdate{Rules: rules}); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRulesBuilder, err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// buildRule converts a rules block into a PAPI rule
+func buildRule(ruleMap map[string]interface{}) (papi.Rules, error) {
+	name, _ := ruleMap["name"].(string)
+	rules := papi.Rules{Name: name}
+
+	rules.Comments, _ = ruleMap["comments"].(string)
+	rules.Options.IsSecure, _ = ruleMap["is_secure"].(bool)
+	rules.CriteriaLocked, _ = ruleMap["criteria_locked"].(bool)
+	rules.UUID, _ = ruleMap["uuid"].(string)
+	rules.TemplateUuid, _ = ruleMap["template_uuid"].(string)
+	rules.TemplateLink, _ = ruleMap["template_link"].(string)
+	rules.AdvancedOverride, _ = ruleMap["advanced_override"].(string)
+	if mustSatisfy, _ := ruleMap["criteria_must_satisfy"].(string); mustSatisfy != "" {
+		rules.CriteriaMustSatisfy = papi.RuleCriteriaMustSatisfy(mustSatisfy)
+	}
+
+	if overrides, _ := ruleMap["custom_override"].([]interface{}); len(overrides) > 0 {
+		override, ok := overrides[0].(map[string]interface{})
+		if !ok {
+			return papi.Rules{}, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "custom_override", "map[string]interface{}")
+		}
+		rules.CustomOverride = &papi.RuleCustomOverride{}
+		rules.CustomOverride.Name, _ = override["name"].(string)
+		rules.CustomOverride.OverrideID, _ = override["override_id"].(string)
+	}
+
+	variables, _ := ruleMap["variable"].([]interface{})
+	for _, v := range variables {
+		variable, ok := v.(map[string]interface{})
+		if !ok {
+			return papi.Rules{}, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "variable", "map[string]interface{}")
+		}
+		var ruleVariable papi.RuleVariable
+		ruleVariable.Name, _ = variable["name"].(string)
+		ruleVariable.Value, _ = variable["value"].(string)
+		ruleVariable.Description, _ = variable["description"].(string)
+		ruleVariable.Hidden, _ = variable["hidden"].(bool)
+		ruleVariable.Sensitive, _ = variable["sensitive"].(bool)
+		rules.Variables = append(rules.Variables, ruleVariable)
+	}
+
+	var err error
+	if rules.Criteria, err = buildRuleBehaviors(ruleMap, "criterion"); err != nil {
+		return papi.Rules{}, err
+	}
+	if rules.Behaviors, err = buildRuleBehaviors(ruleMap, "behavior"); err != nil {
+		return papi.Rules{}, err
+	}
+
+	children, _ := ruleMap["children"].([]interface{})
+	for i, c := range children {
+		childJSON, _ := c.(string)
+		child, err := unmarshalChildRule(childJSON)
+		if err != nil {
+			return papi.Rules{}, fmt.Errorf("%w: rule %q: children[%d]: %s", ErrRulesBuilder, name, i, err)
+		}
+		rules.Children = append(rules.Children, child)
+	}
+
+	return rules, nil
+}
+
+// buildRuleBehaviors converts the behavior or criterion blocks into PAPI behaviors
+func buildRuleBehaviors(ruleMap map[string]interface{}, key string) ([]papi.RuleBehavior, error) {
+	blocks, _ := ruleMap[key].([]interface{})
+	behaviors := make([]papi.RuleBehavior, 0, len(blocks))
+	for _, b := range blocks {
+		block, ok := b.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, key, "map[string]interface{}")
+		}
+		var behavior papi.RuleBehavior
+		behavior.Name, _ = block["name"].(string)
+		behavior.Locked, _ = block["locked"].(bool)
+		behavior.UUID, _ = block["uuid"].(string)
+		behavior.TemplateUuid, _ = block["template_uuid"].(string)
+
+		behavior.Options = papi.RuleOptionsMap{}
+		if options, _ := block["options"].(string); options != "" {
+			if err := json.Unmarshal([]byte(options), &behavior.Options); err != nil {
+				return nil, fmt.Errorf("%w: %s %q: options: %s", ErrRulesBuilder, key, behavior.Name, err)
+			}
+		}
+		behaviors = append(behaviors, behavior)
+	}
+	return behaviors, nil
+}
+
+// unmarshalChildRule decodes the JSON of a child rule, either a rule or a rule tree with a top level rules key
+func unmarshalChildRule(childJSON string) (papi.Rules, error) {
+	var tree map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(childJSON), &tree); err != nil {
+		return papi.Rules{}, err
+	}
+	ruleJSON := []byte(childJSON)
+	if rules, ok := tree["rules"]; ok {
+		ruleJSON = rules
+	}
+
+	var child papi.Rules
+	dec := json.NewDecoder(bytes.NewReader(ruleJSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&child); err != nil {
+		return papi.Rules{}, err
+	}
+	if child.Name == "" {
+		return papi.Rules{}, errors.New("rule name cannot be empty")
+	}
+	return child, nil
+}
@@ -0,0 +1,84 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDSPropertyRulesBuilder(t *testing.T) {
+	t.Run("rule tree with children", func(t *testing.T) {
+		client := papi.Mock{}
+		useClient(&client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDSPropertyRulesBuilder/rules_builder.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_property_rules_builder.default", "json", loadFixtureString("testdata/TestDSPropertyRulesBuilder/rules/default.json")),
+							resource.TestCheckResourceAttrSet("data.akamai_property_rules_builder.default", "id"),
+							resource.TestCheckResourceAttr("data.akamai_property_rules_builder.static", "rules.0.behavior.1.options", "{}"),
+						),
+					},
+				},
+			})
+		})
+	})
+
+	t.Run("rules not matching the rule format schema", func(t *testing.T) {
+		t.Setenv(RuleFormatSchemaDirEnv, testRuleFormatSchemaDir)
+		client := papi.Mock{}
+		useClient(&client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestDSPropertyRulesBuilder/invalid_rules_schema.tf"),
+						ExpectError: regexp.MustCompile(`(?s)rules do not match the rule format schema v2023-01-05.*/rules/behaviors/0/options/ttl`),
+					},
+				},
+			})
+		})
+	})
+
+	tests := map[string]struct {
+		configPath string
+		withError  string
+	}{
+		"invalid variable name": {
+			configPath: "testdata/TestDSPropertyRulesBuilder/invalid_variable_name.tf",
+			withError:  "must start with PMUSER_",
+		},
+		"invalid options": {
+			configPath: "testdata/TestDSPropertyRulesBuilder/invalid_options.tf",
+			withError:  "invalid JSON",
+		},
+		"invalid child rule": {
+			configPath: "testdata/TestDSPropertyRulesBuilder/invalid_child.tf",
+			withError:  `unknown field "behaviour"`,
+		},
+		"latest rule format": {
+			configPath: "testdata/TestDSPropertyRulesBuilder/latest_rule_format.tf",
+			withError:  `"rule_format" 'latest' is not valid`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := papi.Mock{}
+			useClient(&client, nil, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config:      loadFixtureString(test.configPath),
+							ExpectError: regexp.MustCompile(test.withError),
+						},
+					},
+				})
+			})
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"text/template"
@@ -123,6 +124,7 @@ func dataPropertyRulesTemplateRead(_ context.Context, d *schema.ResourceData, m
 	}
 
 	var dir string
+	mainTemplate := templateSource{name: "main", location: file}
 	if err == nil {
 		if _, err = os.Stat(file); err != nil {
 			return diag.FromErr(err)
@@ -137,18 +139,19 @@ func dataPropertyRulesTemplateRead(_ context.Context, d *schema.ResourceData, m
 			logger.Errorf("snippets file should be under 'property-snippets' folder with .json extension and valid json data: %s", file)
 			return diag.FromErr(fmt.Errorf("snippets file should be under 'property-snippets' folder with .json extension and valid json data. Invalid file: %s ", file))
 		}
+		mainTemplate.data = string(fileData)
 	}
 
-	var templateDataStr string
 	if dir == "" {
 		templateSet, err := tools.GetSetValue("template", d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		templateDataStr, dir, err = flattenTemplate(templateSet.List())
+		mainTemplate.data, dir, err = flattenTemplate(templateSet.List())
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		mainTemplate.location = "template_data"
 
 		if _, err := os.Stat(dir); err != nil {
 			return diag.FromErr(err)
@@ -160,24 +163,6 @@ func dataPropertyRulesTemplateRead(_ context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	var templateStr string
-	if templateDataStr == "" {
-		templateStr, err = convertToTemplate(file)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	} else {
-		templateStr, err = stringToTemplate(templateDataStr)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
-	tmpl, err := template.New("main").Delims(leftDelim, rightDelim).Option("missingkey=error").Parse(templateStr)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
 	varsMap := make(map[string]interface{})
 	vars, err := tools.GetSetValue("variables", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -204,7 +189,7 @@ func dataPropertyRulesTemplateRead(_ context.Context, d *schema.ResourceData, m
 			return diag.FromErr(err)
 		}
 	}
-	templateFiles := make(map[string]string)
+	templates := map[string]templateSource{mainTemplate.name: mainTemplate}
 	err = filepath.Walk(dir,
 		func(path string, info os.FileInfo, err error) error {
 			if err != nil {
@@ -219,7 +204,8 @@ func dataPropertyRulesTemplateRead(_ context.Context, d *schema.ResourceData, m
 
 				if json.Valid(pathData) {
 					logger.Debugf("Template snippet found: %s", path)
-					templateFiles[strings.TrimPrefix(filepath.ToSlash(path), fmt.Sprintf("%s/", filepath.ToSlash(dir)))] = path
+					name := strings.TrimPrefix(filepath.ToSlash(path), fmt.Sprintf("%s/", filepath.ToSlash(dir)))
+					templates[name] = templateSource{name: name, location: path, data: string(pathData)}
 				}
 			}
 			return nil
@@ -227,18 +213,22 @@ func dataPropertyRulesTemplateRead(_ context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	for name, f := range templateFiles {
-		templateStr, err := convertToTemplate(f)
+	if diags := findUndefinedVariables(templates, varsMap); diags.HasError() {
+		return diags
+	}
+
+	tmpl := template.New(mainTemplate.name).Funcs(templateFuncs())
+	for _, source := range templates {
+		templateStr, err := stringToTemplate(source.data)
 		if err != nil {
-			return diag.FromErr(err)
+			return diag.Errorf("%s: %s", source.location, err)
 		}
-		tmpl, err = tmpl.New(name).Delims(leftDelim, rightDelim).Option("missingkey=error").Parse(templateStr)
-		if err != nil {
+		if _, err := tmpl.New(source.name).Delims(leftDelim, rightDelim).Option("missingkey=error").Parse(templateStr); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 	wr := bytes.Buffer{}
-	err = tmpl.ExecuteTemplate(&wr, "main", varsMap)
+	err = tmpl.ExecuteTemplate(&wr, mainTemplate.name, varsMap)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -246,14 +236,8 @@ func dataPropertyRulesTemplateRead(_ context.Context, d *schema.ResourceData, m
 		return diag.Errorf("snippets file under 'property-snippets' folder should have .json files. Invalid file %s ", file)
 	}
 
-	// Create a new SHA1 hash based on templateDataStr
-	h := sha1.New()
-	h.Write([]byte(templateDataStr))
-	shaHash := hex.EncodeToString(h.Sum(nil))
-	d.SetId(shaHash)
-
 	formatted := bytes.Buffer{}
-	result := wr.Bytes()
+	result := removeOmitted(wr.Bytes())
 	err = json.Indent(&formatted, result, "", "  ")
 	if err != nil {
 		logger.Debugf("Creating rule tree resulted in invalid JSON: %s\nError: %s", result, err)
@@ -262,12 +246,99 @@ func dataPropertyRulesTemplateRead(_ context.Context, d *schema.ResourceData, m
 	if err := d.Set("json", formatted.String()); err != nil {
 		return diag.Errorf("%v: %s", tools.ErrValueSet, err.Error())
 	}
+	d.SetId(templateHash(formatted.Bytes(), templates))
 	return nil
 }
 
+// templateSource holds the content of a template along with the name it is included by and the place it was read from
+type templateSource struct {
+	name     string
+	location string
+	data     string
+}
+
+// templateHash creates a SHA1 hash of the rendered rules and all snippets available to the template,
+// so that a change in any of the snippet files results in a new ID
+func templateHash(rendered []byte, templates map[string]templateSource) string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha1.New()
+	h.Write(rendered)
+	for _, name := range names {
+		fmt.Fprintf(h, "\x00%s\x00%s", name, templates[name].data)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// findUndefinedVariables returns an error for every variable used by the main template or the snippets it includes
+// which has no value, pointing to the file and line the variable is used in
+func findUndefinedVariables(templates map[string]templateSource, vars map[string]interface{}) diag.Diagnostics {
+	// withItem is set for snippets included by #forEach, where the current element is available as 'item'
+	type inclusion struct {
+		name     string
+		withItem bool
+	}
+	var diags diag.Diagnostics
+	visited := map[inclusion]bool{{name: "main"}: true}
+	queue := []inclusion{{name: "main"}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		source, ok := templates[current.name]
+		if !ok {
+			continue
+		}
+		for i, line := range strings.Split(source.data, "\n") {
+			var varNames, includes, itemIncludes []string
+			for _, match := range varRegexp.FindAllStringSubmatch(line, -1) {
+				varNames = append(varNames, strings.SplitN(match[1], ".", 2)[0])
+			}
+			for _, match := range funcRegexp.FindAllStringSubmatch(line, -1) {
+				varNames = append(varNames, funcEnvRefs(match[2])...)
+			}
+			for _, match := range includeIfRegexp.FindAllStringSubmatch(line, -1) {
+				varNames = append(varNames, match[1])
+				includes = append(includes, match[2])
+			}
+			for _, match := range forEachRegexp.FindAllStringSubmatch(line, -1) {
+				varNames = append(varNames, match[1])
+				itemIncludes = append(itemIncludes, match[2])
+			}
+			for _, match := range includeRegexp.FindAllStringSubmatch(line, -1) {
+				includes = append(includes, match[1])
+			}
+
+			for _, varName := range varNames {
+				if _, ok := vars[varName]; !ok && !(current.withItem && varName == "item") {
+					diags = append(diags, diag.FromErr(fmt.Errorf("%w %q in %s line %d", ErrUndefinedVariable, varName, source.location, i+1))...)
+				}
+			}
+			for _, include := range includes {
+				next := inclusion{name: include, withItem: current.withItem}
+				if !visited[next] {
+					visited[next] = true
+					queue = append(queue, next)
+				}
+			}
+			for _, include := range itemIncludes {
+				next := inclusion{name: include, withItem: true}
+				if !visited[next] {
+					visited[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+	return diags
+}
+
 var (
-	includeRegexp  = regexp.MustCompile(`"#include:.+"`)
-	varRegexp      = regexp.MustCompile(`"\${.+}"`)
+	includeRegexp  = regexp.MustCompile(`"#include:([^"]+)"`)
+	varRegexp      = regexp.MustCompile(`"\${env\.([^"}]+)}"`)
 	jsonFileRegexp = regexp.MustCompile(`\.json+$`)
 )
 
@@ -280,6 +351,8 @@ var (
 	ErrFormatValue = errors.New("formatting value")
 	// ErrUnknownType is used to specify unknown error.
 	ErrUnknownType = errors.New("unknown 'type' value")
+	// ErrUndefinedVariable is used to specify a variable used in template which has no value.
+	ErrUndefinedVariable = errors.New("undefined variable")
 )
 
 // flattenTemplate formats the template schema into a couple of strings holding template_data and template_dir values
@@ -317,19 +390,26 @@ func flattenTemplate(templateList []interface{}) (string, string, error) {
 
 // stringToTemplate takes a large string (templateDataStr) and formats include/variable statements.
 func stringToTemplate(templateDataStr string) (string, error) {
-	includeStatement := includeRegexp.FindString(templateDataStr)
-	for len(includeStatement) > 0 {
-		templateName := strings.TrimPrefix(strings.TrimSuffix(includeStatement, `"`), `"#include:`)
-		templateDataStr = strings.ReplaceAll(templateDataStr, includeStatement, fmt.Sprintf(`%stemplate "%s" .%s`, leftDelim, templateName, rightDelim))
-		includeStatement = includeRegexp.FindString(templateDataStr)
-	}
-
-	varStatement := varRegexp.FindString(templateDataStr)
-	for len(varStatement) > 0 {
-		varName := strings.TrimPrefix(strings.TrimSuffix(varStatement, `}"`), `"${env`)
-		templateDataStr = strings.ReplaceAll(templateDataStr, varStatement, fmt.Sprintf("%s%s%s", leftDelim, varName, rightDelim))
-		varStatement = varRegexp.FindString(templateDataStr)
+	templateDataStr = includeIfRegexp.ReplaceAllString(templateDataStr,
+		fmt.Sprintf(`%[1]sif isTrue .${1}%[2]s%[1]stemplate "${2}" .%[2]s%[1]selse%[2]s%[1]somit%[2]s%[1]send%[2]s`, leftDelim, rightDelim))
+	templateDataStr = forEachRegexp.ReplaceAllString(templateDataStr,
+		fmt.Sprintf(`%[1]srange $$i, $$item := items .${1}%[2]s%[1]sif $$i%[2]s,%[1]send%[2]s%[1]stemplate "${2}" (withItem $$ $$item)%[2]s%[1]selse%[2]s%[1]somit%[2]s%[1]send%[2]s`, leftDelim, rightDelim))
+	templateDataStr = includeRegexp.ReplaceAllString(templateDataStr, fmt.Sprintf(`%stemplate "${1}" .%s`, leftDelim, rightDelim))
+
+	var funcErr error
+	templateDataStr = funcRegexp.ReplaceAllStringFunc(templateDataStr, func(statement string) string {
+		match := funcRegexp.FindStringSubmatch(statement)
+		call, err := funcToTemplate(match[1], match[2])
+		if err != nil {
+			funcErr = err
+			return statement
+		}
+		return fmt.Sprintf("%s%s%s", leftDelim, call, rightDelim)
+	})
+	if funcErr != nil {
+		return "", funcErr
 	}
+	templateDataStr = varRegexp.ReplaceAllString(templateDataStr, fmt.Sprintf("%s.${1}%s", leftDelim, rightDelim))
 
 	if string(templateDataStr[len(templateDataStr)-1]) != "\n" {
 		return fmt.Sprintf("%s\n", templateDataStr), nil
@@ -381,7 +461,11 @@ func convertToTypedMap(vars []interface{}) (map[string]interface{}, error) {
 		}
 		switch varTypeStr {
 		case "string":
-			result[varNameStr] = fmt.Sprintf(`"%s"`, valueStr)
+			str, err := marshalValue(valueStr)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %s", ErrFormatValue, varNameStr, err)
+			}
+			result[varNameStr] = str
 		case "jsonBlock":
 			var targetMap map[string]interface{}
 			if err := json.Unmarshal([]byte(valueStr), &targetMap); err != nil {
@@ -431,6 +515,9 @@ func getVarsFromFile(definitionsPath, valuesPath string) (map[string]interface{}
 	}
 	vars := make(map[string]interface{})
 	for name, varDef := range definitions.Definitions {
+		if err := validateValueType(varDef.Type, varDef.Default); err != nil {
+			return nil, fmt.Errorf("%w: default value of variable '%s' in %s: %s", tools.ErrInvalidType, name, definitionsPath, err)
+		}
 		v, err := formatValue(varDef.Default)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", ErrFormatValue, err)
@@ -448,6 +535,9 @@ func getVarsFromFile(definitionsPath, valuesPath string) (map[string]interface{}
 		}
 		for name, value := range values {
 			if _, ok := vars[name]; ok && value != nil {
+				if err := validateValueType(definitions.Definitions[name].Type, value); err != nil {
+					return nil, fmt.Errorf("%w: value of variable '%s' in %s: %s", tools.ErrInvalidType, name, valuesPath, err)
+				}
 				v, err := formatValue(value)
 				if err != nil {
 					return nil, fmt.Errorf("%w: %s", ErrFormatValue, err)
@@ -464,17 +554,60 @@ func getVarsFromFile(definitionsPath, valuesPath string) (map[string]interface{}
 	return vars, nil
 }
 
+// validateValueType verifies that the value read from variables file matches the type declared in variable definition.
+// Types other than string, number and boolean are not checked, null is accepted for any type
+func validateValueType(varType string, val interface{}) error {
+	if val == nil {
+		return nil
+	}
+	var ok bool
+	switch varType {
+	case "string":
+		_, ok = val.(string)
+	case "number":
+		_, ok = val.(float64)
+	case "bool", "boolean":
+		_, ok = val.(bool)
+	default:
+		return nil
+	}
+	if !ok {
+		return fmt.Errorf("expected %s, got %s", varType, valueJSONType(val))
+	}
+	return nil
+}
+
+func valueJSONType(val interface{}) string {
+	switch val.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
 func formatValue(val interface{}) (interface{}, error) {
 	switch v := val.(type) {
-	case string:
-		return fmt.Sprintf(`"%s"`, v), nil
-	case map[string]interface{}, []interface{}:
-		jsonBlock, err := json.Marshal(v)
-		if err != nil {
-			return nil, err
-		}
-		return string(jsonBlock), nil
+	case string, map[string]interface{}, []interface{}:
+		return marshalValue(v)
 	default:
 		return val, nil
 	}
 }
+
+// marshalValue encodes the value as JSON so that it can be placed in the template as is
+func marshalValue(val interface{}) (string, error) {
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(val); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
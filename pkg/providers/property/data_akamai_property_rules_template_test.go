@@ -3,6 +3,9 @@ package property
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -151,13 +154,129 @@ func TestDataAkamaiPropertyRulesRead(t *testing.T) {
 				Providers: testAccProviders,
 				Steps: []resource.TestStep{
 					{
-						Config:      loadFixtureString("testdata/TestDSRulesTemplate/template_var_not_found.tf"),
-						ExpectError: regexp.MustCompile(`executing "snippets/sub/another-template.json" at <.options>: map has no entry for key "options"`),
+						Config: loadFixtureString("testdata/TestDSRulesTemplate/template_var_not_found.tf"),
+						ExpectError: regexp.MustCompile(`(?s)undefined variable "options" in testdata/TestDSRulesTemplate/rules/property-snippets/snippets/sub/another-template.json line 8.*` +
+							`undefined variable "domain" in testdata/TestDSRulesTemplate/rules/property-snippets/snippets/sub/list-template.json line 10`),
+					},
+					{
+						Config:      loadFixtureString("testdata/TestDSRulesTemplate/template_var_not_found_with_data.tf"),
+						ExpectError: regexp.MustCompile(`undefined variable "comments" in template_data line 4`),
+					},
+				},
+			})
+		})
+	})
+	t.Run("string variables are escaped", func(t *testing.T) {
+		client := papi.Mock{}
+		useClient(&client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDSRulesTemplate/template_vars_escaped.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_property_rules_template.test", "json", loadFixtureString("testdata/TestDSRulesTemplate/rules/rules_escaped.json")),
+						),
 					},
 				},
 			})
 		})
 	})
+	t.Run("template functions and conditionals", func(t *testing.T) {
+		client := papi.Mock{}
+		useClient(&client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDSRulesTemplate/template_funcs.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_property_rules_template.test", "json", loadFixtureString("testdata/TestDSRulesTemplate/rules/rules_funcs.json")),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestDSRulesTemplate/template_funcs_debug.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_property_rules_template.test", "json", loadFixtureString("testdata/TestDSRulesTemplate/rules/rules_funcs_debug.json")),
+						),
+					},
+				},
+			})
+		})
+	})
+	t.Run("invalid template function call", func(t *testing.T) {
+		client := papi.Mock{}
+		useClient(&client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestDSRulesTemplate/template_funcs_invalid.tf"),
+						ExpectError: regexp.MustCompile(`template_data: template function: invalid argument of lower: \.name`),
+					},
+					{
+						Config:      loadFixtureString("testdata/TestDSRulesTemplate/template_funcs_not_bool.tf"),
+						ExpectError: regexp.MustCompile(`template function: #includeIf: expected bool, got string`),
+					},
+				},
+			})
+		})
+	})
+	t.Run("id changes with snippets", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "property-snippets")
+		require.NoError(t, os.Mkdir(dir, 0755))
+		writeSnippet := func(name, content string) func() {
+			return func() {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+			}
+		}
+		writeSnippet("main.json", `{"rules": {"name": "default", "children": ["#include:child.json"]}}`)()
+		writeSnippet("child.json", `{"name": "child", "behaviors": []}`)()
+
+		var ids []string
+		checkID := resource.TestCheckResourceAttrWith("data.akamai_property_rules_template.test", "id", func(id string) error {
+			for _, previous := range ids {
+				if id == previous {
+					return fmt.Errorf("id %s did not change", id)
+				}
+			}
+			ids = append(ids, id)
+			return nil
+		})
+		config := fmt.Sprintf(`
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_rules_template" "test" {
+  template_file = "%s"
+}
+`, filepath.ToSlash(filepath.Join(dir, "main.json")))
+
+		client := papi.Mock{}
+		useClient(&client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  checkID,
+					},
+					{
+						PreConfig: writeSnippet("child.json", `{"name": "child", "behaviors": [{"name": "http2", "options": {}}]}`),
+						Config:    config,
+						Check:     checkID,
+					},
+					{
+						PreConfig: writeSnippet("unused.json", `{"name": "unused"}`),
+						Config:    config,
+						Check:     checkID,
+					},
+				},
+			})
+		})
+		assert.Len(t, ids, 3)
+	})
 	t.Run("invalid variable in map", func(t *testing.T) {
 		client := papi.Mock{}
 		useClient(&client, nil, func() {
@@ -272,6 +391,10 @@ func TestFormatValue(t *testing.T) {
 			given:    "test",
 			expected: `"test"`,
 		},
+		"string with special characters": {
+			given:    `a "quoted" \ value & <b>`,
+			expected: `"a \"quoted\" \\ value & <b>"`,
+		},
 		"map": {
 			given:    map[string]interface{}{"string": "value", "num": 1, "map": map[string]interface{}{"bool": true}},
 			expected: `{"map":{"bool":true},"num":1,"string":"value"}`,
@@ -329,6 +452,32 @@ func TestGetValuesFromMap(t *testing.T) {
 				"testNumber":    "null",
 			},
 		},
+		"typed values are escaped": {
+			definitionsFile: "typed_definitions.json",
+			expected: map[string]interface{}{
+				"testString": `"a \"quoted\" value"`,
+				"testNumber": float64(1),
+				"testBool":   "null",
+			},
+		},
+		"typed values overwrite defaults": {
+			definitionsFile: "typed_definitions.json",
+			valuesFile:      "typed_values.json",
+			expected: map[string]interface{}{
+				"testString": `"C:\\temp"`,
+				"testNumber": 2.5,
+				"testBool":   true,
+			},
+		},
+		"default does not match type": {
+			definitionsFile: "invalid_typed_definitions.json",
+			withError:       tools.ErrInvalidType,
+		},
+		"value does not match type": {
+			definitionsFile: "typed_definitions.json",
+			valuesFile:      "invalid_typed_values.json",
+			withError:       tools.ErrInvalidType,
+		},
 		"definitions file not found": {
 			definitionsFile: "not_existing.json",
 			withError:       ErrReadFile,
@@ -381,9 +530,11 @@ func TestConvertToTypedMap(t *testing.T) {
 				map[string]interface{}{"name": "testJSONMap", "type": "jsonBlock", "value": `{"abc": "cba", "number":1}`},
 				map[string]interface{}{"name": "testJSONArray", "type": "jsonBlock", "value": `["a", "b", "c"]`},
 				map[string]interface{}{"name": "testBool", "type": "bool", "value": "true"},
+				map[string]interface{}{"name": "testEscaped", "type": "string", "value": `"quoted" \ value`},
 			},
 			expected: map[string]interface{}{
 				"testString":    `"test"`,
+				"testEscaped":   `"\"quoted\" \\ value"`,
 				"testNum":       1.23,
 				"testJSONMap":   `{"abc": "cba", "number":1}`,
 				"testJSONArray": `["a", "b", "c"]`,
@@ -2,8 +2,10 @@ package property
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
@@ -124,3 +126,220 @@ func orderVariables(variables []papi.RuleVariable) []papi.RuleVariable {
 	})
 	return variables
 }
+
+// rulesDiff lists the changes between two rule trees, one line per changed path,
+// e.g. default/children[2]/behaviors/caching.ttl: 1d -> 7d
+// Behaviors, criteria and variables are matched by name, as their order is not significant, and child rules
+// are matched by name too, so that inserting a rule does not show every following rule as changed
+func rulesDiff(old, new *papi.RulesUpdate) []string {
+	var changes []string
+	if old.Comments != new.Comments {
+		changes = append(changes, fmt.Sprintf("comments: %s -> %s", formatDiffValue(old.Comments), formatDiffValue(new.Comments)))
+	}
+	name := new.Rules.Name
+	if name == "" {
+		name = "default"
+	}
+	return append(changes, diffRule(name, &old.Rules, &new.Rules)...)
+}
+
+func diffRule(path string, old, new *papi.Rules) []string {
+	changes := diffValues(path+"/", ruleFields(old), ruleFields(new))
+	changes = append(changes, diffBehaviors(path+"/criteria/", old.Criteria, new.Criteria)...)
+	changes = append(changes, diffBehaviors(path+"/behaviors/", old.Behaviors, new.Behaviors)...)
+	changes = append(changes, diffVariables(path+"/variables/", old.Variables, new.Variables)...)
+
+	oldChildren := make(map[string]int, len(old.Children))
+	for i, child := range old.Children {
+		oldChildren[occurrenceKey(oldChildren, child.Name)] = i
+	}
+	seen := make(map[string]int, len(new.Children))
+	matched := make(map[int]bool, len(old.Children))
+	for i := range new.Children {
+		childPath := fmt.Sprintf("%s/children[%d]", path, i)
+		key := occurrenceKey(seen, new.Children[i].Name)
+		j, ok := oldChildren[key]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("%s: added rule %q", childPath, new.Children[i].Name))
+			continue
+		}
+		matched[j] = true
+		if i != j {
+			changes = append(changes, fmt.Sprintf("%s: moved rule %q from children[%d]", childPath, new.Children[i].Name, j))
+		}
+		changes = append(changes, diffRule(childPath, &old.Children[j], &new.Children[i])...)
+	}
+	for j := range old.Children {
+		if !matched[j] {
+			changes = append(changes, fmt.Sprintf("%s/children[%d]: removed rule %q", path, j, old.Children[j].Name))
+		}
+	}
+	return changes
+}
+
+// ruleFields returns the attributes of the rule other than its behaviors, criteria, variables and children,
+// leaving out the values which the API treats as unset
+func ruleFields(rule *papi.Rules) map[string]interface{} {
+	fields := toDiffMap(rule)
+	for _, key := range []string{"name", "behaviors", "criteria", "variables", "children"} {
+		delete(fields, key)
+	}
+	if fields["criteriaMustSatisfy"] == string(papi.RuleCriteriaMustSatisfyAll) {
+		delete(fields, "criteriaMustSatisfy")
+	}
+	return flattenDiffMap("", fields)
+}
+
+func diffBehaviors(path string, old, new []papi.RuleBehavior) []string {
+	index := func(behaviors []papi.RuleBehavior) (map[string]papi.RuleBehavior, []string) {
+		byKey := make(map[string]papi.RuleBehavior, len(behaviors))
+		keys := make([]string, 0, len(behaviors))
+		seen := make(map[string]int, len(behaviors))
+		for _, b := range behaviors {
+			key := occurrenceKey(seen, b.Name)
+			byKey[key] = b
+			keys = append(keys, key)
+		}
+		return byKey, keys
+	}
+	oldBehaviors, oldKeys := index(old)
+	newBehaviors, newKeys := index(new)
+
+	var changes []string
+	for _, key := range oldKeys {
+		if _, ok := newBehaviors[key]; !ok {
+			changes = append(changes, fmt.Sprintf("%s%s: removed", path, key))
+		}
+	}
+	for _, key := range newKeys {
+		o, ok := oldBehaviors[key]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("%s%s: added", path, key))
+			continue
+		}
+		n := newBehaviors[key]
+		changes = append(changes, diffValues(path+key+".", flattenDiffMap("", o.Options), flattenDiffMap("", n.Options))...)
+		o.Options, n.Options = nil, nil
+		changes = append(changes, diffValues(path+key+"/", behaviorFields(o), behaviorFields(n))...)
+	}
+	return changes
+}
+
+// behaviorFields returns the attributes of the behavior or criterion other than its name and options
+func behaviorFields(b papi.RuleBehavior) map[string]interface{} {
+	fields := toDiffMap(b)
+	delete(fields, "name")
+	delete(fields, "options")
+	return flattenDiffMap("", fields)
+}
+
+func diffVariables(path string, old, new []papi.RuleVariable) []string {
+	oldVariables := make(map[string]papi.RuleVariable, len(old))
+	for _, v := range old {
+		oldVariables[v.Name] = v
+	}
+	newVariables := make(map[string]papi.RuleVariable, len(new))
+	for _, v := range new {
+		newVariables[v.Name] = v
+	}
+
+	var changes []string
+	for _, v := range old {
+		if _, ok := newVariables[v.Name]; !ok {
+			changes = append(changes, fmt.Sprintf("%s%s: removed", path, v.Name))
+		}
+	}
+	for _, v := range new {
+		o, ok := oldVariables[v.Name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("%s%s: added", path, v.Name))
+			continue
+		}
+		oldFields, newFields := toDiffMap(o), toDiffMap(v)
+		delete(oldFields, "name")
+		delete(newFields, "name")
+		changes = append(changes, diffValues(path+v.Name+".", oldFields, newFields)...)
+	}
+	return changes
+}
+
+// diffValues lists the keys of two flattened maps whose values differ, in alphabetical order
+func diffValues(path string, old, new map[string]interface{}) []string {
+	keys := make([]string, 0, len(old)+len(new))
+	for key := range old {
+		keys = append(keys, key)
+	}
+	for key := range new {
+		if _, ok := old[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []string
+	for _, key := range keys {
+		o, n := formatDiffValue(old[key]), formatDiffValue(new[key])
+		if o != n {
+			changes = append(changes, fmt.Sprintf("%s%s: %s -> %s", path, key, o, n))
+		}
+	}
+	return changes
+}
+
+// occurrenceKey returns the name, suffixed with the number of its previous occurrences if it repeats
+func occurrenceKey(seen map[string]int, name string) string {
+	n := seen[name]
+	seen[name]++
+	if n == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s[%d]", name, n)
+}
+
+// toDiffMap converts a rule tree element to a map of its JSON attributes
+func toDiffMap(v interface{}) map[string]interface{} {
+	res := make(map[string]interface{})
+	b, err := json.Marshal(v)
+	if err != nil {
+		return res
+	}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return make(map[string]interface{})
+	}
+	return res
+}
+
+// flattenDiffMap flattens nested objects into keys joined with dots, keeping arrays as single values
+func flattenDiffMap(prefix string, m map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{})
+	for key, value := range m {
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			for k, v := range flattenDiffMap(prefix+key+".", nested) {
+				res[k] = v
+			}
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) == 0 {
+			continue
+		}
+		res[prefix+key] = value
+	}
+	return res
+}
+
+func formatDiffValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		if val == "" {
+			return `""`
+		}
+		return val
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSpace(string(b))
+}
@@ -606,3 +606,73 @@ func TestCompareRules(t *testing.T) {
 		})
 	}
 }
+
+func TestRulesDiff(t *testing.T) {
+	caching := func(ttl string) papi.RuleBehavior {
+		return papi.RuleBehavior{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "ttl": ttl}}
+	}
+	tree := func(children ...papi.Rules) *papi.RulesUpdate {
+		return &papi.RulesUpdate{Rules: papi.Rules{
+			Name:      "default",
+			Behaviors: []papi.RuleBehavior{{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "origin.example.com", "httpPort": 80}}},
+			Variables: []papi.RuleVariable{{Name: "PMUSER_ORIGIN", Value: "origin.example.com"}},
+			Children:  children,
+		}}
+	}
+
+	tests := map[string]struct {
+		old, new *papi.RulesUpdate
+		expected []string
+	}{
+		"equal rules": {
+			old: tree(papi.Rules{Name: "Static", Behaviors: []papi.RuleBehavior{caching("1d")}}),
+			new: tree(papi.Rules{Name: "Static", Behaviors: []papi.RuleBehavior{caching("1d")}, CriteriaMustSatisfy: papi.RuleCriteriaMustSatisfyAll}),
+		},
+		"changed option of a child rule": {
+			old:      tree(papi.Rules{Name: "Images"}, papi.Rules{Name: "Scripts"}, papi.Rules{Name: "Static", Behaviors: []papi.RuleBehavior{caching("1d")}}),
+			new:      tree(papi.Rules{Name: "Images"}, papi.Rules{Name: "Scripts"}, papi.Rules{Name: "Static", Behaviors: []papi.RuleBehavior{caching("7d")}}),
+			expected: []string{"default/children[2]/behaviors/caching.ttl: 1d -> 7d"},
+		},
+		"added, removed and moved rules": {
+			old: tree(papi.Rules{Name: "Images"}, papi.Rules{Name: "Static"}),
+			new: tree(papi.Rules{Name: "Static"}, papi.Rules{Name: "Scripts", CriteriaMustSatisfy: papi.RuleCriteriaMustSatisfyAny}),
+			expected: []string{
+				`default/children[0]: moved rule "Static" from children[1]`,
+				`default/children[1]: added rule "Scripts"`,
+				`default/children[0]: removed rule "Images"`,
+			},
+		},
+		"behaviors, criteria and variables": {
+			old: &papi.RulesUpdate{Rules: papi.Rules{
+				Name:      "default",
+				Comments:  "first",
+				Behaviors: []papi.RuleBehavior{caching("1d"), {Name: "gzipResponse", Options: papi.RuleOptionsMap{"behavior": "ALWAYS"}}},
+				Criteria:  []papi.RuleBehavior{{Name: "path", Options: papi.RuleOptionsMap{"values": []interface{}{"/a"}}}},
+				Variables: []papi.RuleVariable{{Name: "PMUSER_A", Value: "a"}, {Name: "PMUSER_B", Value: "b"}},
+			}},
+			new: &papi.RulesUpdate{Rules: papi.Rules{
+				Name:      "default",
+				Comments:  "second",
+				Behaviors: []papi.RuleBehavior{{Name: "prefreshCache"}, {Name: "caching", Locked: true, Options: papi.RuleOptionsMap{"behavior": "MAX_AGE"}}},
+				Criteria:  []papi.RuleBehavior{{Name: "path", Options: papi.RuleOptionsMap{"values": []interface{}{"/a", "/b"}}}},
+				Variables: []papi.RuleVariable{{Name: "PMUSER_A", Value: "c"}},
+			}},
+			expected: []string{
+				"default/comments: first -> second",
+				`default/criteria/path.values: ["/a"] -> ["/a","/b"]`,
+				"default/behaviors/gzipResponse: removed",
+				"default/behaviors/prefreshCache: added",
+				"default/behaviors/caching.ttl: 1d -> null",
+				"default/behaviors/caching/locked: null -> true",
+				"default/variables/PMUSER_B: removed",
+				"default/variables/PMUSER_A.value: a -> c",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, rulesDiff(test.old, test.new))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/hapi"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
+)
+
+const (
+	// hapiChangeStatusPending is the status of an edge hostname change which is still being applied
+	hapiChangeStatusPending = "PENDING"
+	// hapiChangeStatusSucceeded is the status of an applied edge hostname change
+	hapiChangeStatusSucceeded = "SUCCEEDED"
+	// hapiChangeStatusFailed is the status of an edge hostname change which could not be applied
+	hapiChangeStatusFailed = "FAILED"
+
+	// edgeHostnamePollInterval is the default interval between the status checks of edge hostname changes
+	edgeHostnamePollInterval = 30 * time.Second
+
+	// edgeHostnamePollMinimum is the minimum interval between the status checks of edge hostname changes
+	edgeHostnamePollMinimum = time.Second
+)
+
+var (
+	// ErrEdgeHostnameChange is returned when a change of an edge hostname cannot be read or fails
+	ErrEdgeHostnameChange = errors.New("edge hostname change")
+)
+
+// hapiChange is a change of edge hostnames submitted to HAPI
+type hapiChange struct {
+	ChangeID      int    `json:"changeId"`
+	Action        string `json:"action"`
+	Status        string `json:"status"`
+	StatusMessage string `json:"statusMessage"`
+}
+
+// getHapiChange reads the status of the edge hostname change, which the HAPI client has no method for
+func getHapiChange(ctx context.Context, exec executor, changeID int) (*hapiChange, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("/hapi/v1/changes/%d", changeID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d: failed to create request: %s", ErrEdgeHostnameChange, changeID, err)
+	}
+	var change hapiChange
+	resp, err := exec.Exec(req, &change)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d: request failed: %s", ErrEdgeHostnameChange, changeID, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := hapi.Error{Status: resp.StatusCode}
+		if resp.Body != nil {
+			if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
+				apiErr.Title = "Failed to unmarshal error body"
+				apiErr.Detail = err.Error()
+			}
+		}
+		return nil, fmt.Errorf("%s: %d: %w", ErrEdgeHostnameChange, changeID, &apiErr)
+	}
+	return &change, nil
+}
+
+// waitForHapiChange polls the status of the edge hostname change until it succeeds or fails
+func waitForHapiChange(ctx context.Context, exec executor, change hapiChange, poller *tools.Poller, logger log.Interface) (*hapiChange, error) {
+	check := func(ctx context.Context) (bool, error) {
+		switch change.Status {
+		case hapiChangeStatusSucceeded:
+			return true, nil
+		case hapiChangeStatusFailed:
+			return false, fmt.Errorf("%w: %d: %s", ErrEdgeHostnameChange, change.ChangeID, change.StatusMessage)
+		}
+		return false, nil
+	}
+	if done, err := check(ctx); done || err != nil {
+		return &change, err
+	}
+
+	err := poller.Poll(ctx, func(ctx context.Context) (bool, error) {
+		current, err := getHapiChange(ctx, exec, change.ChangeID)
+		if err != nil {
+			return false, err
+		}
+		change = *current
+		logger.Debugf("edge hostname change %d: %s", change.ChangeID, change.Status)
+		return check(ctx)
+	})
+	return &change, err
+}
+
+// waitForHapiEdgeHostname polls HAPI until it knows the edge hostname, which is created with PAPI and can be changed
+// with HAPI only once it is propagated there
+func waitForHapiEdgeHostname(ctx context.Context, client hapi.HAPI, id int, poller *tools.Poller, logger log.Interface) error {
+	check := func(ctx context.Context) (bool, error) {
+		_, err := client.GetEdgeHostname(ctx, id)
+		if err == nil {
+			return true, nil
+		}
+		if akamai.IsAPINotFound(err) {
+			logger.Debugf("edge hostname %d is not known to HAPI yet", id)
+			return false, nil
+		}
+		return false, err
+	}
+	if done, err := check(ctx); done || err != nil {
+		return err
+	}
+	return poller.Poll(ctx, check)
+}
+
+// edgeHostnameID returns the numeric ID of the edge hostname, used by HAPI
+func edgeHostnameID(id string) (int, error) {
+	num, err := strconv.Atoi(id[strings.LastIndex(id, "_")+1:])
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid edge hostname ID: %s", tools.ErrInvalidType, id)
+	}
+	return num, nil
+}
@@ -0,0 +1,87 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForHapiChange(t *testing.T) {
+	tests := map[string]struct {
+		change         hapiChange
+		response       string
+		expectedStatus string
+		expectedChecks int
+		withError      error
+	}{
+		"change already succeeded": {
+			change:         hapiChange{ChangeID: 1, Status: "SUCCEEDED"},
+			expectedStatus: "SUCCEEDED",
+		},
+		"change succeeds while polling": {
+			change:         hapiChange{ChangeID: 1, Status: "PENDING"},
+			response:       `{"changeId": 1, "action": "DELETE", "status": "SUCCEEDED"}`,
+			expectedStatus: "SUCCEEDED",
+			expectedChecks: 1,
+		},
+		"change fails": {
+			change:         hapiChange{ChangeID: 1, Status: "PENDING"},
+			response:       `{"changeId": 1, "action": "DELETE", "status": "FAILED", "statusMessage": "edge hostname is in use"}`,
+			expectedChecks: 1,
+			withError:      ErrEdgeHostnameChange,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			exec := &mockExecutor{}
+			if test.expectedChecks > 0 {
+				exec.On("Exec", http.MethodGet, "/hapi/v1/changes/1", "").
+					Return(http.StatusOK, test.response, nil).Times(test.expectedChecks)
+			}
+			poller := &tools.Poller{Name: "test", Interval: time.Millisecond}
+
+			change, err := waitForHapiChange(context.Background(), exec, test.change, poller, log.Log)
+			exec.AssertExpectations(t)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, change.Status)
+		})
+	}
+}
+
+func TestGetHapiChangeNotFound(t *testing.T) {
+	exec := &mockExecutor{}
+	exec.On("Exec", http.MethodGet, "/hapi/v1/changes/5", "").
+		Return(http.StatusNotFound, `{"title": "Not Found", "status": 404}`, nil).Once()
+
+	_, err := getHapiChange(context.Background(), exec, 5)
+	exec.AssertExpectations(t)
+	require.Error(t, err)
+	assert.True(t, akamai.IsAPINotFound(err))
+	assert.Contains(t, err.Error(), "edge hostname change: 5")
+}
+
+func TestEdgeHostnameID(t *testing.T) {
+	id, err := edgeHostnameID("ehn_123")
+	require.NoError(t, err)
+	assert.Equal(t, 123, id)
+
+	id, err = edgeHostnameID("456")
+	require.NoError(t, err)
+	assert.Equal(t, 456, id)
+
+	_, err = edgeHostnameID("ehn_abc")
+	assert.True(t, errors.Is(err, tools.ErrInvalidType))
+}
@@ -0,0 +1,211 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
+)
+
+const (
+	// hostnameActivationStatusActive is the status of a hostname activation whose changes are serving
+	hostnameActivationStatusActive = "ACTIVE"
+
+	// hostnameBucketPollInterval is the default interval between the status checks of hostname activations
+	hostnameBucketPollInterval = 30 * time.Second
+
+	// hostnameBucketPollMinimum is the minimum interval between the status checks of hostname activations
+	hostnameBucketPollMinimum = time.Second
+
+	// hostnameBucketPageSize is the number of hostnames read with each request
+	hostnameBucketPageSize = 1000
+)
+
+var (
+	// ErrHostnameBucket is returned when the hostname bucket of a property cannot be read or changed
+	ErrHostnameBucket = errors.New("hostname bucket")
+
+	// hostnameActivationFailedStatuses are the statuses of hostname activations which will not become active
+	hostnameActivationFailedStatuses = map[string]struct{}{
+		"ABORTED":     {},
+		"FAILED":      {},
+		"DEACTIVATED": {},
+	}
+
+	// hostnameActivationPendingStatuses are the statuses of hostname activations which are still in progress,
+	// besides the ZONE_ statuses reported while the activation propagates to each zone
+	hostnameActivationPendingStatuses = map[string]struct{}{
+		"NEW":     {},
+		"PENDING": {},
+	}
+)
+
+type (
+	// bucketHostname is a hostname of the property hostname bucket with its state on each network
+	bucketHostname struct {
+		CnameFrom          string `json:"cnameFrom"`
+		CnameType          string `json:"cnameType"`
+		StagingCnameTo     string `json:"stagingCnameTo,omitempty"`
+		StagingCertType    string `json:"stagingCertType,omitempty"`
+		ProductionCnameTo  string `json:"productionCnameTo,omitempty"`
+		ProductionCertType string `json:"productionCertType,omitempty"`
+	}
+
+	// bucketHostnames is a page of the hostnames of the property hostname bucket
+	bucketHostnames struct {
+		Hostnames struct {
+			Items      []bucketHostname `json:"items"`
+			TotalItems int              `json:"totalItems"`
+		} `json:"hostnames"`
+	}
+
+	// bucketHostnameChange is a hostname added to the hostname bucket, or removed with the remove action
+	bucketHostnameChange struct {
+		Action               string `json:"action,omitempty"`
+		CnameType            string `json:"cnameType,omitempty"`
+		CnameFrom            string `json:"cnameFrom"`
+		CnameTo              string `json:"cnameTo,omitempty"`
+		CertProvisioningType string `json:"certProvisioningType,omitempty"`
+	}
+
+	// bucketPatch adds and removes hostnames of the hostname bucket on the network
+	bucketPatch struct {
+		Network      string                 `json:"network"`
+		Note         string                 `json:"note,omitempty"`
+		NotifyEmails []string               `json:"notifyEmails,omitempty"`
+		Add          []bucketHostnameChange `json:"add"`
+		Remove       []string               `json:"remove"`
+	}
+
+	// hostnameActivation is the activation of the hostname bucket changes
+	hostnameActivation struct {
+		ActivationID string                 `json:"activationId"`
+		Network      string                 `json:"network"`
+		Status       string                 `json:"status"`
+		Hostnames    []bucketHostnameChange `json:"hostnames"`
+	}
+
+	// bucketClient sends the hostname bucket requests, which the PAPI client has no methods for
+	bucketClient struct {
+		exec       executor
+		propertyID string
+		contractID string
+		groupID    string
+	}
+)
+
+// newBucketClient returns the client of the hostname bucket of the property
+func newBucketClient(exec executor, propertyID, contractID, groupID string) *bucketClient {
+	return &bucketClient{exec: exec, propertyID: propertyID, contractID: contractID, groupID: groupID}
+}
+
+// hostnames reads all hostnames of the hostname bucket
+func (c *bucketClient) hostnames(ctx context.Context) ([]bucketHostname, error) {
+	var hostnames []bucketHostname
+	for {
+		query := c.query()
+		query.Set("offset", fmt.Sprint(len(hostnames)))
+		query.Set("limit", fmt.Sprint(hostnameBucketPageSize))
+		uri := url.URL{Path: fmt.Sprintf("/papi/v1/properties/%s/hostnames", c.propertyID), RawQuery: query.Encode()}
+
+		var page bucketHostnames
+		if err := execRequest(ctx, c.exec, http.MethodGet, uri.String(), nil, &page, http.StatusOK); err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", ErrHostnameBucket, c.propertyID, err)
+		}
+		hostnames = append(hostnames, page.Hostnames.Items...)
+		if len(page.Hostnames.Items) == 0 || len(hostnames) >= page.Hostnames.TotalItems {
+			return hostnames, nil
+		}
+	}
+}
+
+// patch adds and removes the hostnames and returns the ID of the hostname activation applying the changes
+func (c *bucketClient) patch(ctx context.Context, patch bucketPatch) (string, error) {
+	uri := url.URL{Path: fmt.Sprintf("/papi/v1/properties/%s/hostnames", c.propertyID), RawQuery: c.query().Encode()}
+	var patched struct {
+		ActivationLink string `json:"activationLink"`
+	}
+	if err := execRequest(ctx, c.exec, http.MethodPatch, uri.String(), patch, &patched, http.StatusAccepted); err != nil {
+		return "", fmt.Errorf("%s: %s: %w", ErrHostnameBucket, c.propertyID, err)
+	}
+	link, err := url.Parse(patched.ActivationLink)
+	if err != nil || patched.ActivationLink == "" {
+		return "", fmt.Errorf("%w: %s: response has no valid activationLink: %q", ErrHostnameBucket, c.propertyID, patched.ActivationLink)
+	}
+	return path.Base(link.Path), nil
+}
+
+// activation reads the hostname activation with the hostnames it changes
+func (c *bucketClient) activation(ctx context.Context, activationID string) (*hostnameActivation, error) {
+	query := c.query()
+	query.Set("includeHostnames", "true")
+	uri := url.URL{
+		Path:     fmt.Sprintf("/papi/v1/properties/%s/hostname-activations/%s", c.propertyID, activationID),
+		RawQuery: query.Encode(),
+	}
+	var activation struct {
+		HostnameActivation hostnameActivation `json:"hostnameActivation"`
+	}
+	if err := execRequest(ctx, c.exec, http.MethodGet, uri.String(), nil, &activation, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("%s: %s: activation %s: %w", ErrHostnameBucket, c.propertyID, activationID, err)
+	}
+	return &activation.HostnameActivation, nil
+}
+
+// isPending returns true if the hostname activation is still in progress
+func (a hostnameActivation) isPending() bool {
+	if _, ok := hostnameActivationPendingStatuses[a.Status]; ok {
+		return true
+	}
+	return strings.HasPrefix(a.Status, "ZONE_")
+}
+
+// waitForActivation polls the hostname activation until it is active or fails
+func (c *bucketClient) waitForActivation(ctx context.Context, activation hostnameActivation, poller *tools.Poller, logger log.Interface) (*hostnameActivation, error) {
+	check := func() (bool, error) {
+		if activation.Status == hostnameActivationStatusActive {
+			return true, nil
+		}
+		if _, failed := hostnameActivationFailedStatuses[activation.Status]; failed {
+			return false, fmt.Errorf("%w: %s: activation %s: %s", ErrHostnameBucket, c.propertyID, activation.ActivationID, activation.Status)
+		}
+		return false, nil
+	}
+	if done, err := check(); done || err != nil {
+		return &activation, err
+	}
+
+	err := poller.Poll(ctx, func(ctx context.Context) (bool, error) {
+		current, err := c.activation(ctx, activation.ActivationID)
+		if err != nil {
+			return false, err
+		}
+		activation = *current
+		logger.Debugf("hostname activation %s: %s", activation.ActivationID, activation.Status)
+		return check()
+	})
+	return &activation, err
+}
+
+func (c *bucketClient) query() url.Values {
+	query := url.Values{}
+	query.Set("contractId", c.contractID)
+	query.Set("groupId", c.groupID)
+	return query
+}
+
+// onNetwork returns the edge hostname and certificate provisioning type the hostname serves with on the network
+func (h bucketHostname) onNetwork(network string) (cnameTo, certType string, ok bool) {
+	if network == string(papi.ActivationNetworkProduction) {
+		return h.ProductionCnameTo, h.ProductionCertType, h.ProductionCnameTo != ""
+	}
+	return h.StagingCnameTo, h.StagingCertType, h.StagingCnameTo != ""
+}
@@ -83,6 +83,9 @@ var (
 	// ErrPropertyInclude is returned when operation on property include fails
 	ErrPropertyInclude = errors.New("property include")
 
+	// ErrIncludesNotActive is returned when a property version references includes which are not active on the network
+	ErrIncludesNotActive = errors.New("referenced includes are not active")
+
 	// DiagWarnActivationTimeout returned on activation poll timeout
 	DiagWarnActivationTimeout = diag.Diagnostic{
 		Severity: diag.Warning,
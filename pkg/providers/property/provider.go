@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/apex/log"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/hapi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/config"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
@@ -25,6 +28,18 @@ type (
 		client papi.PAPI
 
 		hapiClient hapi.HAPI
+
+		exec executor
+	}
+
+	// executor sends the PAPI, HAPI and CPRG requests which the clients have no methods for
+	executor interface {
+		Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error)
+	}
+
+	// sessionExecutor sends the requests with the session, asking PAPI for prefixed IDs like the PAPI client does
+	sessionExecutor struct {
+		session.Session
 	}
 
 	// Option is a papi provider option
@@ -78,11 +93,16 @@ func Provider() *schema.Provider {
 			"akamai_contract":                    dataSourcePropertyContract(),
 			"akamai_contracts":                   dataSourceContracts(),
 			"akamai_cp_code":                     dataSourceCPCode(),
+			"akamai_cp_codes":                    dataSourceCPCodes(),
+			"akamai_edge_hostname":               dataSourceEdgeHostname(),
+			"akamai_edge_hostnames":              dataSourceEdgeHostnames(),
 			"akamai_group":                       dataSourcePropertyGroup(),
 			"akamai_groups":                      dataSourcePropertyMultipleGroups(),
 			"akamai_properties":                  dataSourceProperties(),
 			"akamai_properties_search":           dataSourcePropertiesSearch(),
 			"akamai_property":                    dataSourceProperty(),
+			"akamai_property_bulk_search":        dataSourcePropertyBulkSearch(),
+			"akamai_property_export":             dataSourcePropertyExport(),
 			"akamai_property_hostnames":          dataSourcePropertyHostnames(),
 			"akamai_property_include":            dataSourcePropertyInclude(),
 			"akamai_property_include_activation": dataSourcePropertyIncludeActivation(),
@@ -92,16 +112,22 @@ func Provider() *schema.Provider {
 			"akamai_property_products":           dataSourcePropertyProducts(),
 			"akamai_property_rule_formats":       dataSourcePropertyRuleFormats(),
 			"akamai_property_rules":              dataSourcePropertyRules(),
+			"akamai_property_rules_builder":      dataSourcePropertyRulesBuilder(),
 			"akamai_property_rules_template":     dataSourcePropertyRulesTemplate(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"akamai_cp_code":                     resourceCPCode(),
-			"akamai_edge_hostname":               resourceSecureEdgeHostName(),
-			"akamai_property":                    resourceProperty(),
-			"akamai_property_activation":         resourcePropertyActivation(),
-			"akamai_property_include":            resourcePropertyInclude(),
-			"akamai_property_include_activation": resourcePropertyIncludeActivation(),
-			"akamai_property_variables":          resourcePropertyVariables(),
+			"akamai_cp_code":                      akamai.WithProviderDefaults(resourceCPCode()),
+			"akamai_cp_code_reporting_group":      akamai.WithProviderDefaults(resourceCPCodeReportingGroup()),
+			"akamai_edge_hostname":                akamai.WithProviderDefaults(resourceSecureEdgeHostName()),
+			"akamai_property":                     akamai.WithProviderDefaults(resourceProperty()),
+			"akamai_property_activation":          akamai.WithProviderDefaults(resourcePropertyActivation()),
+			"akamai_property_activation_rollback": akamai.WithProviderDefaults(resourcePropertyActivationRollback()),
+			"akamai_property_bulk_activation":     akamai.WithProviderDefaults(resourcePropertyBulkActivation()),
+			"akamai_property_bulk_patch":          akamai.WithProviderDefaults(resourcePropertyBulkPatch()),
+			"akamai_property_hostname_bucket":     akamai.WithProviderDefaults(resourcePropertyHostnameBucket()),
+			"akamai_property_include":             akamai.WithProviderDefaults(resourcePropertyInclude()),
+			"akamai_property_include_activation":  akamai.WithProviderDefaults(resourcePropertyIncludeActivation()),
+			"akamai_property_variables":           resourcePropertyVariables(),
 		},
 	}
 	return provider
@@ -130,6 +156,22 @@ func (p *provider) HapiClient(meta akamai.OperationMeta) hapi.HAPI {
 	return hapi.Client(meta.Session())
 }
 
+// Executor returns the executor of the requests which the PAPI and HAPI clients have no methods for
+func (p *provider) Executor(meta akamai.OperationMeta) executor {
+	if p.exec != nil {
+		return p.exec
+	}
+	return sessionExecutor{meta.Session()}
+}
+
+// Exec sends the request, setting the PAPI-Use-Prefixes header of PAPI requests
+func (e sessionExecutor) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	if strings.HasPrefix(r.URL.Path, "/papi/") {
+		r.Header.Set("PAPI-Use-Prefixes", "true")
+	}
+	return e.Session.Exec(r, out, in...)
+}
+
 func getPAPIV1Service(d *schema.ResourceData) error {
 	var inlineConfig *schema.Set
 	for _, key := range []string{"property", "config"} {
@@ -1,9 +1,11 @@
 package property
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
 	"os"
 	"strings"
 	"sync"
@@ -15,6 +17,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/mock"
 )
 
 var testAccProviders map[string]*schema.Provider
@@ -63,6 +66,48 @@ func useClient(client papi.PAPI, hapiClient hapi.HAPI, f func()) {
 	f()
 }
 
+// useExecutor swaps out the client and the executor on the global instance for the duration of the given func
+func useExecutor(client papi.PAPI, exec executor, f func()) {
+	useClient(client, nil, func() {
+		orig := inst.exec
+		inst.exec = exec
+		defer func() {
+			inst.exec = orig
+		}()
+
+		f()
+	})
+}
+
+// mockExecutor is the mock of the executor. It is called with the method, the URL and the JSON body of the request,
+// and returns the status and the body of the response, which is unmarshalled into the output on success
+type mockExecutor struct {
+	mock.Mock
+}
+
+func (m *mockExecutor) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	var body string
+	if len(in) > 0 {
+		data, err := json.Marshal(in[0])
+		if err != nil {
+			return nil, err
+		}
+		body = string(data)
+	}
+	args := m.Called(r.Method, r.URL.String(), body)
+	if err := args.Error(2); err != nil {
+		return nil, err
+	}
+
+	status, data := args.Int(0), args.String(1)
+	if status >= 200 && status < 300 && out != nil && data != "" {
+		if err := json.Unmarshal([]byte(data), out); err != nil {
+			return nil, err
+		}
+	}
+	return &http.Response{StatusCode: status, Body: ioutil.NopCloser(strings.NewReader(data))}, nil
+}
+
 // TODO marks a test as being in a "pending" state and logs a message telling the user why. Such tests are expected to
 // fail for the time being and may exist for the sake of unfinished/future features or to document known buggy cases
 // that won't be fixed right away. The failure of a pending test is not considered an error and the test will therefore
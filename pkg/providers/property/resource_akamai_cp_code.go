@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
+	"net/http"
 	"strings"
 	"time"
 
+	"github.com/apex/log"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -24,8 +26,7 @@ func resourceCPCode() *schema.Resource {
 		CreateContext: resourceCPCodeCreate,
 		ReadContext:   resourceCPCodeRead,
 		UpdateContext: resourceCPCodeUpdate,
-		// NB: CP Codes cannot be deleted https://techdocs.akamai.com/property-mgr/reference/post-cpcodes
-		DeleteContext: schema.NoopContext,
+		DeleteContext: resourceCPCodeDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceCPCodeImport,
 		},
@@ -78,6 +79,18 @@ func resourceCPCode() *schema.Resource {
 				ConflictsWith: []string{"product"},
 				StateFunc:     addPrefixToState("prd_"),
 			},
+			"purgeable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the content served under the CP code can be purged. Managed with the CPRG API",
+			},
+			"time_zone_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the time zone overriding the default time zone of the CP code in reports. Managed with the CPRG API",
+			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Update: &cpCodeResourceUpdateTimeout,
@@ -131,6 +144,15 @@ func resourceCPCodeCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	}
 
 	logger.Debugf("Resulting CP Code: %#v", cpCode)
+
+	// purgeable flag and time zone are only set with CPRG, so they are changed after the creation when configured
+	_, purgeableSet := d.GetOkExists("purgeable")
+	_, timeZoneSet := d.GetOk("time_zone_id")
+	if purgeableSet || timeZoneSet {
+		if err := updateCPCodeDetail(ctx, d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return resourceCPCodeRead(ctx, d, m)
 }
 
@@ -161,6 +183,9 @@ func resourceCPCodeRead(ctx context.Context, d *schema.ResourceData, m interface
 		GroupID:    groupID,
 	})
 	if err != nil {
+		if akamai.RemoveIfNotFound(d, err, logger) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
@@ -180,6 +205,14 @@ func resourceCPCodeRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.Errorf("%s: %s", tools.ErrValueSet, err.Error())
 	}
 	d.SetId(cpCode.ID)
+
+	// purgeable and time_zone_id are only read when they are managed, so that CP codes
+	// which do not use them can be refreshed without access to the CPRG API
+	if cpCodeDetailManaged(d) {
+		if err := readCPCodeDetail(ctx, d, client, logger); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	logger.Debugf("Read CP Code: %+v", cpCode)
 	return nil
 }
@@ -197,31 +230,16 @@ func resourceCPCodeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	contractID, groupID := getContractIDAndGroupID(d)
 
-	cpCodeID, err := strconv.Atoi(strings.TrimPrefix(d.Id(), "cpc_"))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
 	name, err := tools.GetStringValue("name", d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	cpCode, err := client.GetCPCodeDetail(ctx, cpCodeID)
-	if err != nil {
+	if err := updateCPCodeDetail(ctx, d, meta); err != nil {
 		return diag.FromErr(err)
 	}
-
-	_, err = client.UpdateCPCode(ctx, papi.UpdateCPCodeRequest{
-		ID:               cpCode.ID,
-		Name:             name,
-		Purgeable:        &cpCode.Purgeable,
-		OverrideTimeZone: &cpCode.OverrideTimeZone,
-		Contracts:        cpCode.Contracts,
-		Products:         cpCode.Products,
-	})
-	if err != nil {
-		return diag.FromErr(err)
+	if !d.HasChange("name") {
+		return resourceCPCodeRead(ctx, d, m)
 	}
 
 	// Because we use CPRG API for update, we need to ensure that changes are also present when fetching cpCode with PAPI
@@ -235,6 +253,17 @@ func resourceCPCodeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	return resourceCPCodeRead(ctx, d, m)
 }
 
+func resourceCPCodeDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourceCPCodeDelete")
+	logger.Debugf("Delete CP Code")
+
+	// NB: CP Codes cannot be deleted https://techdocs.akamai.com/property-mgr/reference/post-cpcodes
+	cpCodeID := d.Id()
+	d.SetId("")
+	return tools.DiagWarningf("CP code %s cannot be deleted, it was only removed from the state", cpCodeID)
+}
+
 func resourceCPCodeImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	meta := akamai.Meta(m)
 	logger := meta.Log("PAPI", "resourceCPCodeImport")
@@ -283,6 +312,9 @@ func resourceCPCodeImport(ctx context.Context, d *schema.ResourceData, m interfa
 		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
 	}
 	d.SetId(cpCode.ID)
+	if err := readCPCodeDetail(ctx, d, client, logger); err != nil {
+		return nil, err
+	}
 	logger.Debugf("Import CP Code: %+v", cpCode)
 	return []*schema.ResourceData{d}, nil
 }
@@ -305,6 +337,47 @@ func createCPCode(ctx context.Context, name, productID, contractID, groupID stri
 	return r.CPCodeID, nil
 }
 
+// updateCPCodeDetail sets the name, purgeable flag and time zone of the CP code with the CPRG API
+func updateCPCodeDetail(ctx context.Context, d *schema.ResourceData, meta akamai.OperationMeta) error {
+	client := inst.Client(meta)
+
+	cpCodeID, err := tools.GetIntID(d.Id(), "cpc_")
+	if err != nil {
+		return err
+	}
+	name, err := tools.GetStringValue("name", d)
+	if err != nil {
+		return err
+	}
+	cpCode, err := client.GetCPCodeDetail(ctx, cpCodeID)
+	if err != nil {
+		return err
+	}
+
+	// settings which are not configured keep their current values
+	purgeable := cpCode.Purgeable
+	if v, ok := d.GetOkExists("purgeable"); ok && (d.IsNewResource() || d.HasChange("purgeable")) {
+		purgeable = v.(bool)
+	}
+	timeZone := cpCode.OverrideTimeZone
+	if v, ok := d.GetOk("time_zone_id"); ok && (d.IsNewResource() || d.HasChange("time_zone_id")) {
+		timeZone = papi.CPCodeTimeZone{TimeZoneID: v.(string)}
+	}
+	if name == cpCode.Name && purgeable == cpCode.Purgeable && timeZone.TimeZoneID == cpCode.OverrideTimeZone.TimeZoneID {
+		return nil
+	}
+
+	_, err = client.UpdateCPCode(ctx, papi.UpdateCPCodeRequest{
+		ID:               cpCode.ID,
+		Name:             name,
+		Purgeable:        &purgeable,
+		OverrideTimeZone: &timeZone,
+		Contracts:        cpCode.Contracts,
+		Products:         cpCode.Products,
+	})
+	return err
+}
+
 func checkImmutableChanged(d *schema.ResourceData) diag.Diagnostics {
 	immutables := []string{
 		"contract",
@@ -371,3 +444,43 @@ func waitForCPCodeNameUpdate(ctx context.Context, client papi.PAPI, contractID,
 
 	return nil
 }
+
+// cpCodeDetailManaged returns true if purgeable or time_zone_id is set in the configuration or in the state
+func cpCodeDetailManaged(d *schema.ResourceData) bool {
+	for _, raw := range []cty.Value{d.GetRawConfig(), d.GetRawState()} {
+		if raw.IsNull() || !raw.IsKnown() {
+			continue
+		}
+		for _, key := range []string{"purgeable", "time_zone_id"} {
+			if val := raw.GetAttr(key); val.IsKnown() && !val.IsNull() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readCPCodeDetail sets purgeable and time_zone_id from the CPRG API
+//
+// the settings are left unset when the client is not authorized to read them, as they are then not managed by terraform
+func readCPCodeDetail(ctx context.Context, d *schema.ResourceData, client papi.PAPI, logger log.Interface) error {
+	cpCodeID, err := tools.GetIntID(d.Id(), "cpc_")
+	if err != nil {
+		return err
+	}
+	detail, err := client.GetCPCodeDetail(ctx, cpCodeID)
+	if err != nil {
+		if status, ok := akamai.StatusCode(err); ok && status == http.StatusForbidden {
+			logger.Warnf("Not authorized to read the details of CP code %d, purgeable and time_zone_id are not managed: %s", cpCodeID, err)
+			return nil
+		}
+		return err
+	}
+	if err := d.Set("purgeable", detail.Purgeable); err != nil {
+		return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("time_zone_id", detail.OverrideTimeZone.TimeZoneID); err != nil {
+		return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	return nil
+}
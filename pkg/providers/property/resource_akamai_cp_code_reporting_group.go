@@ -0,0 +1,209 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+)
+
+// resourceCPCodeReportingGroup manages a CPRG reporting group aggregating CP codes of a contract
+func resourceCPCodeReportingGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCPCodeReportingGroupCreate,
+		ReadContext:   resourceCPCodeReportingGroupRead,
+		UpdateContext: resourceCPCodeReportingGroupUpdate,
+		DeleteContext: resourceCPCodeReportingGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.IsNotBlank,
+				Description:      "The name of the reporting group",
+			},
+			"contract_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("ctr_"),
+				Description: "The contract of the reporting group and of its CP codes",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("grp_"),
+				Description: "The group whose users can access the reporting group",
+			},
+			"cp_codes": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The CP codes aggregated by the reporting group, with or without the `cpc_` prefix",
+			},
+		},
+	}
+}
+
+func resourceCPCodeReportingGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourceCPCodeReportingGroupCreate")
+
+	client := newCPRGClient(inst.Executor(meta))
+	group, err := reportingGroupFromData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debugf("Creating reporting group %q", group.ReportingGroupName)
+
+	created, err := client.createReportingGroup(ctx, *group)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(strconv.Itoa(created.ReportingGroupID))
+	logger.Debugf("Created reporting group %d", created.ReportingGroupID)
+
+	return resourceCPCodeReportingGroupRead(ctx, d, m)
+}
+
+func resourceCPCodeReportingGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourceCPCodeReportingGroupRead")
+
+	client := newCPRGClient(inst.Executor(meta))
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("invalid reporting group ID %q: %s", d.Id(), err)
+	}
+
+	group, err := client.getReportingGroup(ctx, id)
+	if err != nil {
+		if akamai.RemoveIfNotFound(d, err, logger) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if len(group.Contracts) != 1 {
+		return diag.Errorf("%s: %d: expected the CP codes of a single contract, got %d contracts", ErrReportingGroup, id, len(group.Contracts))
+	}
+
+	// CP codes keep the form they are configured with, so that the prefix does not cause a diff
+	configured := make(map[int]string)
+	for _, cpCode := range d.Get("cp_codes").(*schema.Set).List() {
+		if cpCodeID, err := tools.GetIntID(cpCode.(string), "cpc_"); err == nil {
+			configured[cpCodeID] = cpCode.(string)
+		}
+	}
+	cpCodes := make([]interface{}, 0, len(group.Contracts[0].CPCodes))
+	for _, cpCode := range group.Contracts[0].CPCodes {
+		if configuredID, ok := configured[cpCode.CPCodeID]; ok {
+			cpCodes = append(cpCodes, configuredID)
+			continue
+		}
+		cpCodes = append(cpCodes, fmt.Sprintf("cpc_%d", cpCode.CPCodeID))
+	}
+
+	attrs := map[string]interface{}{
+		"name":        group.ReportingGroupName,
+		"contract_id": tools.AddPrefix(group.Contracts[0].ContractID, "ctr_"),
+		"group_id":    fmt.Sprintf("grp_%d", group.AccessGroup.GroupID),
+		"cp_codes":    cpCodes,
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debugf("Read reporting group %d with %d CP codes", id, len(cpCodes))
+	return nil
+}
+
+func resourceCPCodeReportingGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourceCPCodeReportingGroupUpdate")
+
+	client := newCPRGClient(inst.Executor(meta))
+	group, err := reportingGroupFromData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if group.ReportingGroupID, err = strconv.Atoi(d.Id()); err != nil {
+		return diag.Errorf("invalid reporting group ID %q: %s", d.Id(), err)
+	}
+	logger.Debugf("Updating reporting group %d", group.ReportingGroupID)
+
+	if _, err := client.updateReportingGroup(ctx, *group); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceCPCodeReportingGroupRead(ctx, d, m)
+}
+
+func resourceCPCodeReportingGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourceCPCodeReportingGroupDelete")
+
+	client := newCPRGClient(inst.Executor(meta))
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("invalid reporting group ID %q: %s", d.Id(), err)
+	}
+	logger.Debugf("Deleting reporting group %d", id)
+
+	if err := client.deleteReportingGroup(ctx, id); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// reportingGroupFromData returns the reporting group of the configuration, with the IDs in the form CPRG expects
+func reportingGroupFromData(d *schema.ResourceData) (*reportingGroup, error) {
+	name, err := tools.GetStringValue("name", d)
+	if err != nil {
+		return nil, err
+	}
+	contractID, err := tools.GetStringValue("contract_id", d)
+	if err != nil {
+		return nil, err
+	}
+	contractID = strings.TrimPrefix(contractID, "ctr_")
+	groupIDStr, err := tools.GetStringValue("group_id", d)
+	if err != nil {
+		return nil, err
+	}
+	groupID, err := tools.GetIntID(groupIDStr, "grp_")
+	if err != nil {
+		return nil, fmt.Errorf("invalid group_id %q: %s", groupIDStr, err)
+	}
+	cpCodeSet, err := tools.GetSetValue("cp_codes", d)
+	if err != nil {
+		return nil, err
+	}
+
+	var cpCodes []reportingGroupCPCode
+	for _, cpCode := range cpCodeSet.List() {
+		cpCodeID, err := tools.GetIntID(cpCode.(string), "cpc_")
+		if err != nil {
+			return nil, fmt.Errorf("invalid CP code %q: %s", cpCode, err)
+		}
+		cpCodes = append(cpCodes, reportingGroupCPCode{CPCodeID: cpCodeID})
+	}
+	sort.Slice(cpCodes, func(i, j int) bool {
+		return cpCodes[i].CPCodeID < cpCodes[j].CPCodeID
+	})
+
+	return &reportingGroup{
+		ReportingGroupName: name,
+		Contracts:          []reportingGroupContract{{ContractID: contractID, CPCodes: cpCodes}},
+		AccessGroup:        reportingGroupAccess{GroupID: groupID, ContractID: contractID},
+	}, nil
+}
@@ -0,0 +1,144 @@
+package property
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// reportingGroupSession serves the updated reporting group once it has been replaced
+type reportingGroupSession struct {
+	*bulkSession
+	updated string
+}
+
+func (s *reportingGroupSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	resp, err := s.bulkSession.Exec(r, out, in...)
+	if err == nil && r.Method == http.MethodPut {
+		s.responses[fmt.Sprintf("GET %s", r.URL.Path)] = bulkResponse{status: http.StatusOK, body: s.updated}
+	}
+	return resp, err
+}
+
+func TestResCPCodeReportingGroup(t *testing.T) {
+	created := `{
+  "reportingGroupId": 42,
+  "reportingGroupName": "test reporting group",
+  "contracts": [{"contractId": "1-ABC", "cpcodes": [{"cpcodeId": 123, "cpcodeName": "first"}, {"cpcodeId": 456, "cpcodeName": "second"}]}],
+  "accessGroup": {"groupId": 2, "contractId": "1-ABC"}
+}`
+	updated := `{
+  "reportingGroupId": 42,
+  "reportingGroupName": "renamed reporting group",
+  "contracts": [{"contractId": "1-ABC", "cpcodes": [{"cpcodeId": 123, "cpcodeName": "first"}]}],
+  "accessGroup": {"groupId": 2, "contractId": "1-ABC"}
+}`
+
+	// changes returns the requests which are not reads
+	changes := func(requests []string) []string {
+		var result []string
+		for _, request := range requests {
+			if !strings.HasPrefix(request, http.MethodGet) {
+				result = append(result, request)
+			}
+		}
+		return result
+	}
+
+	t.Run("create, update and delete reporting group", func(t *testing.T) {
+		sess := &reportingGroupSession{bulkSession: &bulkSession{responses: map[string]bulkResponse{
+			"POST /cprg/v1/reporting-groups":      {status: http.StatusCreated, body: created},
+			"GET /cprg/v1/reporting-groups/42":    {status: http.StatusOK, body: created},
+			"PUT /cprg/v1/reporting-groups/42":    {status: http.StatusOK, body: updated},
+			"DELETE /cprg/v1/reporting-groups/42": {status: http.StatusNoContent},
+		}}, updated: updated}
+		useExecutor(&papi.Mock{}, sess, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers:  testAccProviders,
+				IsUnitTest: true,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResCPCodeReportingGroup/create.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_cp_code_reporting_group.test", "id", "42"),
+							resource.TestCheckResourceAttr("akamai_cp_code_reporting_group.test", "name", "test reporting group"),
+							resource.TestCheckResourceAttr("akamai_cp_code_reporting_group.test", "contract_id", "ctr_1-ABC"),
+							resource.TestCheckResourceAttr("akamai_cp_code_reporting_group.test", "group_id", "grp_2"),
+							resource.TestCheckResourceAttr("akamai_cp_code_reporting_group.test", "cp_codes.#", "2"),
+							resource.TestCheckTypeSetElemAttr("akamai_cp_code_reporting_group.test", "cp_codes.*", "cpc_123"),
+							resource.TestCheckTypeSetElemAttr("akamai_cp_code_reporting_group.test", "cp_codes.*", "456"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResCPCodeReportingGroup/update.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_cp_code_reporting_group.test", "id", "42"),
+							resource.TestCheckResourceAttr("akamai_cp_code_reporting_group.test", "name", "renamed reporting group"),
+							resource.TestCheckResourceAttr("akamai_cp_code_reporting_group.test", "cp_codes.#", "1"),
+							resource.TestCheckTypeSetElemAttr("akamai_cp_code_reporting_group.test", "cp_codes.*", "cpc_123"),
+						),
+					},
+				},
+			})
+		})
+		assert.Equal(t, []string{
+			`POST /cprg/v1/reporting-groups {"reportingGroupName":"test reporting group",` +
+				`"contracts":[{"contractId":"1-ABC","cpcodes":[{"cpcodeId":123},{"cpcodeId":456}]}],"accessGroup":{"groupId":2,"contractId":"1-ABC"}}`,
+			`PUT /cprg/v1/reporting-groups/42 {"reportingGroupId":42,"reportingGroupName":"renamed reporting group",` +
+				`"contracts":[{"contractId":"1-ABC","cpcodes":[{"cpcodeId":123}]}],"accessGroup":{"groupId":2,"contractId":"1-ABC"}}`,
+			"DELETE /cprg/v1/reporting-groups/42",
+		}, changes(sess.requests))
+	})
+
+	t.Run("import reporting group", func(t *testing.T) {
+		sess := &bulkSession{responses: map[string]bulkResponse{
+			"POST /cprg/v1/reporting-groups":      {status: http.StatusCreated, body: created},
+			"GET /cprg/v1/reporting-groups/42":    {status: http.StatusOK, body: created},
+			"DELETE /cprg/v1/reporting-groups/42": {status: http.StatusNoContent},
+		}}
+		useExecutor(&papi.Mock{}, sess, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers:  testAccProviders,
+				IsUnitTest: true,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResCPCodeReportingGroup/create.tf"),
+					},
+					{
+						ImportState:             true,
+						ImportStateId:           "42",
+						ResourceName:            "akamai_cp_code_reporting_group.test",
+						ImportStateVerify:       true,
+						ImportStateVerifyIgnore: []string{"cp_codes"},
+					},
+				},
+			})
+		})
+	})
+
+	t.Run("reporting group creation fails", func(t *testing.T) {
+		exec := &mockExecutor{}
+		exec.On("Exec", http.MethodPost, "/cprg/v1/reporting-groups", mock.Anything).
+			Return(http.StatusBadRequest, `{"title": "Invalid CP code", "status": 400}`, nil).Once()
+		useExecutor(&papi.Mock{}, exec, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers:  testAccProviders,
+				IsUnitTest: true,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestResCPCodeReportingGroup/create.tf"),
+						ExpectError: regexp.MustCompile(`(?s)reporting group: create "test reporting group": .*Invalid CP code`),
+					},
+				},
+			})
+		})
+		exec.AssertExpectations(t)
+	})
+}
@@ -3,6 +3,7 @@ package property
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
 	"testing"
@@ -358,6 +359,65 @@ func TestResCPCode(t *testing.T) {
 		})
 	})
 
+	t.Run("change purgeable and time zone", func(t *testing.T) {
+		client := &papi.Mock{}
+		defer client.AssertExpectations(t)
+
+		// Contains CP Codes known to mock PAPI
+		CPCodes := []papi.CPCode{}
+		detail := papi.CPCodeDetailResponse{
+			ID:       0,
+			Name:     "test cpcode",
+			Products: []papi.CPCodeProduct{{ProductID: "prd_1"}},
+		}
+
+		expectGetCPCodes(client, "ctr_1", "grp_1", &CPCodes).Once()
+		expectCreateCPCode(client, "test cpcode", "prd_1", "ctr_1", "grp_1", &CPCodes)
+		expectGetCPCode(client, "ctr_1", "grp_1", 0, &CPCodes, nil)
+		var detailCall *mock.Call
+		detailCall = client.On("GetCPCodeDetail", AnyCTX, 0).Run(func(mock.Arguments) {
+			res := detail
+			detailCall.Return(&res, nil)
+		})
+
+		purgeable := true
+		client.On("UpdateCPCode", AnyCTX, papi.UpdateCPCodeRequest{
+			ID:               0,
+			Name:             "test cpcode",
+			Purgeable:        &purgeable,
+			OverrideTimeZone: &papi.CPCodeTimeZone{TimeZoneID: "GMT+1"},
+			Products:         []papi.CPCodeProduct{{ProductID: "prd_1"}},
+		}).Run(func(mock.Arguments) {
+			detail.Purgeable = true
+			detail.OverrideTimeZone = papi.CPCodeTimeZone{TimeZoneID: "GMT+1"}
+		}).Return(&detail, nil).Once()
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResCPCode/change_name_step0.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_cp_code.test", "id", "cpc_0"),
+							resource.TestCheckNoResourceAttr("akamai_cp_code.test", "purgeable"),
+							resource.TestCheckNoResourceAttr("akamai_cp_code.test", "time_zone_id"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResCPCode/change_settings.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_cp_code.test", "id", "cpc_0"),
+							resource.TestCheckResourceAttr("akamai_cp_code.test", "name", "test cpcode"),
+							resource.TestCheckResourceAttr("akamai_cp_code.test", "purgeable", "true"),
+							resource.TestCheckResourceAttr("akamai_cp_code.test", "time_zone_id", "GMT+1"),
+						),
+					},
+				},
+			})
+		})
+	})
+
 	t.Run("import existing cp code", func(t *testing.T) {
 		client := &papi.Mock{}
 		id := "0,1,2"
@@ -365,6 +425,7 @@ func TestResCPCode(t *testing.T) {
 		CPCodes := []papi.CPCode{{ID: "cpc_0", Name: "test cpcode", ProductIDs: []string{"prd_Web_Accel"}}}
 		expectGetCPCodes(client, "ctr_1", "grp_2", &CPCodes)
 		expectGetCPCode(client, "ctr_1", "grp_2", 0, &CPCodes, nil).Times(4)
+		expectGetCPCodeDetail(client, 0, &CPCodes, nil).Times(2)
 		useClient(client, nil, func() {
 			resource.UnitTest(t, resource.TestCase{
 				Providers: testAccProviders,
@@ -387,9 +448,46 @@ func TestResCPCode(t *testing.T) {
 							assert.Equal(t, "prd_Web_Accel", rs.Attributes["product"])
 							assert.Equal(t, "cpc_0", rs.Attributes["id"])
 							assert.Equal(t, "test cpcode", rs.Attributes["name"])
+							assert.Equal(t, "false", rs.Attributes["purgeable"])
 							return nil
 						},
 						ImportStateVerify: true,
+						// settings managed with CPRG are only read on import when they are not configured
+						ImportStateVerifyIgnore: []string{"purgeable", "time_zone_id"},
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("import existing cp code without access to CPRG", func(t *testing.T) {
+		client := &papi.Mock{}
+		id := "0,1,2"
+
+		CPCodes := []papi.CPCode{{ID: "cpc_0", Name: "test cpcode", ProductIDs: []string{"prd_Web_Accel"}}}
+		expectGetCPCodes(client, "ctr_1", "grp_2", &CPCodes)
+		expectGetCPCode(client, "ctr_1", "grp_2", 0, &CPCodes, nil).Times(4)
+		expectGetCPCodeDetail(client, 0, &CPCodes, &papi.Error{StatusCode: http.StatusForbidden}).Once()
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResCPCode/import_cp_code.tf"),
+					},
+					{
+						ImportState:   true,
+						ImportStateId: id,
+						ResourceName:  "akamai_cp_code.test",
+						ImportStateCheck: func(s []*terraform.InstanceState) error {
+							assert.Len(t, s, 1)
+							rs := s[0]
+							assert.Equal(t, "cpc_0", rs.Attributes["id"])
+							assert.NotContains(t, rs.Attributes, "purgeable")
+							assert.NotContains(t, rs.Attributes, "time_zone_id")
+							return nil
+						},
 					},
 				},
 			})
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/hapi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
@@ -102,6 +104,25 @@ var akamaiSecureEdgeHostNameSchema = map[string]*schema.Schema{
 		DiffSuppressFunc: suppressEdgeHostnameUseCases,
 		Description:      "A JSON encoded list of use cases",
 	},
+	"ttl": {
+		Type:             schema.TypeInt,
+		Optional:         true,
+		Computed:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+		Description:      "The time to live of the edge hostname DNS record in seconds. Changes are applied through the Edge Hostnames API",
+	},
+	"change_id": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "ID of the last change of the edge hostname submitted to the Edge Hostnames API",
+	},
+	"change_status": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Status of the last change of the edge hostname submitted to the Edge Hostnames API",
+	},
+	tools.PollIntervalKey: tools.PollIntervalSchema(edgeHostnamePollInterval),
+	tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 }
 
 func resourceSecureEdgeHostNameCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -210,7 +231,8 @@ func resourceSecureEdgeHostNameCreate(ctx context.Context, d *schema.ResourceDat
 		newHostname.UseCases = useCases
 	}
 
-	if ehnID == "" {
+	created := ehnID == ""
+	if created {
 		logger.Debugf("Creating new edge hostname: %#v", newHostname)
 		hostname, err := client.CreateEdgeHostname(ctx, papi.CreateEdgeHostnameRequest{
 			EdgeHostname: newHostname,
@@ -226,6 +248,30 @@ func resourceSecureEdgeHostNameCreate(ctx context.Context, d *schema.ResourceDat
 		d.SetId(ehnID)
 	}
 	logger.Debugf("Resulting EHN Id: %s ", ehnID)
+
+	if _, ok := d.GetOk("ttl"); ok {
+		emails, err := getStatusUpdateEmails(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if created {
+			id, err := edgeHostnameID(ehnID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			poller, err := tools.NewPoller("edge hostname creation", d, edgeHostnamePollInterval, edgeHostnamePollMinimum, logger)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if err := waitForHapiEdgeHostname(ctx, inst.HapiClient(meta), id, poller, logger); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		logger.Debugf("Proceeding to update /ttl for %s", edgeHostname)
+		if err := patchEdgeHostname(ctx, d, meta, []string{"ttl"}, emails); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return resourceSecureEdgeHostNameRead(ctx, d, meta)
 }
 
@@ -317,6 +363,32 @@ func resourceSecureEdgeHostNameRead(ctx context.Context, d *schema.ResourceData,
 	}
 	d.SetId(foundEdgeHostname.ID)
 
+	// TTL is only known to HAPI, so it is read when managed by the configuration
+	if _, ok := d.GetOk("ttl"); ok {
+		id, err := edgeHostnameID(foundEdgeHostname.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		hostname, err := inst.HapiClient(meta).GetEdgeHostname(ctx, id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("ttl", hostname.TTL); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+	}
+
+	if changeID := d.Get("change_id").(int); changeID != 0 && d.Get("change_status").(string) == hapiChangeStatusPending {
+		change, err := getHapiChange(ctx, inst.Executor(meta), changeID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		logger.Debugf("Edge hostname change %d: %s", changeID, change.Status)
+		if err := d.Set("change_status", change.Status); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+	}
+
 	return nil
 }
 
@@ -324,48 +396,24 @@ func resourceSecureEdgeHostNameUpdate(ctx context.Context, d *schema.ResourceDat
 	meta := akamai.Meta(m)
 	logger := meta.Log("PAPI", "resourceSecureEdgeHostNameUpdate")
 
-	if d.HasChange("ip_behavior") {
-		edgeHostname, err := tools.GetStringValue("edge_hostname", d)
-		if err != nil {
-			return diag.FromErr(err)
+	var changed []string
+	for _, key := range []string{"ip_behavior", "ttl"} {
+		if d.HasChange(key) {
+			changed = append(changed, key)
 		}
-		dnsZone, _ := parseEdgeHostname(edgeHostname)
-		ipBehavior, err := tools.GetStringValue("ip_behavior", d)
+	}
+	if len(changed) > 0 {
+		emails, err := getStatusUpdateEmails(d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		emails, err := tools.GetListValue("status_update_email", d)
-		if err != nil && !errors.Is(err, tools.ErrNotFound) {
-			return diag.FromErr(err)
-		}
 		if len(emails) == 0 {
 			return diag.Errorf(`"status_update_email" is a required parameter to update an edge hostname`)
 		}
-		statusUpdateEmails := make([]string, len(emails))
-		for i, email := range emails {
-			statusUpdateEmails[i] = email.(string)
-		}
-
-		logger.Debugf("Proceeding to update /ipVersionBehavior for %s", edgeHostname)
-		// IPV6_COMPLIANCE type has to mapped to IPV6_IPV4_DUALSTACK which is only accepted value by HAPI client
-		if ipBehavior == papi.EHIPVersionV6Compliance {
-			ipBehavior = "IPV6_IPV4_DUALSTACK"
-		}
 
-		if _, err = inst.HapiClient(meta).UpdateEdgeHostname(ctx, hapi.UpdateEdgeHostnameRequest{
-			DNSZone:           dnsZone,
-			RecordName:        strings.ReplaceAll(edgeHostname, "."+dnsZone, ""),
-			Comments:          fmt.Sprintf("change /ipVersionBehavior to %s", ipBehavior),
-			StatusUpdateEmail: statusUpdateEmails,
-			Body: []hapi.UpdateEdgeHostnameRequestBody{
-				{
-					Op:    "replace",
-					Path:  "/ipVersionBehavior",
-					Value: ipBehavior,
-				},
-			},
-		}); err != nil {
-			if err2 := tools.RestoreOldValues(d, []string{"ip_behavior"}); err2 != nil {
+		logger.Debugf("Proceeding to update %s of %s", strings.Join(changed, ", "), d.Get("edge_hostname"))
+		if err = patchEdgeHostname(ctx, d, meta, changed, emails); err != nil {
+			if err2 := tools.RestoreOldValues(d, []string{"ip_behavior", "ttl"}); err2 != nil {
 				return diag.Errorf(`%s failed. No changes were written to server:
 %s
 
@@ -379,16 +427,116 @@ Failed to restore previous local schema values. The schema will remain in tainte
 	return resourceSecureEdgeHostNameRead(ctx, d, m)
 }
 
-func resourceSecureEdgeHostNameDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceSecureEdgeHostNameDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	meta := akamai.Meta(m)
 	logger := meta.Log("PAPI", "resourceSecureEdgeHostNameDelete")
-	logger.Debug("DELETING")
-	logger.Info("PAPI does not support edge hostname deletion - resource will only be removed from state")
+	client := inst.HapiClient(meta)
+
+	edgeHostname, err := tools.GetStringValue("edge_hostname", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	edgeHostname = appendDefaultSuffixToEdgeHostname(edgeHostname)
+	dnsZone, _ := parseEdgeHostname(edgeHostname)
+	emails, err := getStatusUpdateEmails(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Debugf("Deleting edge hostname %s", edgeHostname)
+	deletion, err := client.DeleteEdgeHostname(ctx, hapi.DeleteEdgeHostnameRequest{
+		DNSZone:           dnsZone,
+		RecordName:        strings.TrimSuffix(edgeHostname, "."+dnsZone),
+		StatusUpdateEmail: emails,
+		Comments:          fmt.Sprintf("delete %s", edgeHostname),
+	})
+	if err != nil {
+		if akamai.IsAPINotFound(err) {
+			logger.Infof("Edge hostname %s does not exist anymore, removing it from the state", edgeHostname)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	poller, err := tools.NewPoller("edge hostname deletion", d, edgeHostnamePollInterval, edgeHostnamePollMinimum, logger)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	change, err := waitForHapiChange(ctx, inst.Executor(meta), hapiChange{
+		ChangeID:      deletion.ChangeID,
+		Action:        deletion.Action,
+		Status:        deletion.Status,
+		StatusMessage: deletion.StatusMessage,
+	}, poller, logger)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debugf("Edge hostname %s deleted with change %d", edgeHostname, change.ChangeID)
+
 	d.SetId("")
-	logger.Debugf("DONE")
 	return nil
 }
 
+// patchEdgeHostname submits the change of the given attributes to HAPI and stores the ID and status of the change
+func patchEdgeHostname(ctx context.Context, d *schema.ResourceData, meta akamai.OperationMeta, keys, emails []string) error {
+	edgeHostname, err := tools.GetStringValue("edge_hostname", d)
+	if err != nil {
+		return err
+	}
+	edgeHostname = appendDefaultSuffixToEdgeHostname(edgeHostname)
+	dnsZone, _ := parseEdgeHostname(edgeHostname)
+
+	var body []hapi.UpdateEdgeHostnameRequestBody
+	var comments []string
+	for _, key := range keys {
+		var path, value string
+		switch key {
+		case "ip_behavior":
+			path, value = "/ipVersionBehavior", d.Get(key).(string)
+			// IPV6_COMPLIANCE type has to mapped to IPV6_IPV4_DUALSTACK which is only accepted value by HAPI client
+			if value == papi.EHIPVersionV6Compliance {
+				value = "IPV6_IPV4_DUALSTACK"
+			}
+		case "ttl":
+			path, value = "/ttl", strconv.Itoa(d.Get(key).(int))
+		}
+		body = append(body, hapi.UpdateEdgeHostnameRequestBody{Op: "replace", Path: path, Value: value})
+		comments = append(comments, fmt.Sprintf("%s to %s", path, value))
+	}
+
+	change, err := inst.HapiClient(meta).UpdateEdgeHostname(ctx, hapi.UpdateEdgeHostnameRequest{
+		DNSZone:           dnsZone,
+		RecordName:        strings.TrimSuffix(edgeHostname, "."+dnsZone),
+		Comments:          fmt.Sprintf("change %s", strings.Join(comments, ", ")),
+		StatusUpdateEmail: emails,
+		Body:              body,
+	})
+	if err != nil {
+		return err
+	}
+	if err := d.Set("change_id", change.ChangeID); err != nil {
+		return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("change_status", change.Status); err != nil {
+		return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
+// getStatusUpdateEmails returns the addresses receiving the updates on HAPI changes
+func getStatusUpdateEmails(d *schema.ResourceData) ([]string, error) {
+	emails, err := tools.GetListValue("status_update_email", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, err
+	}
+	statusUpdateEmails := make([]string, 0, len(emails))
+	for _, email := range emails {
+		statusUpdateEmails = append(statusUpdateEmails, email.(string))
+	}
+	return statusUpdateEmails, nil
+}
+
 func resourceSecureEdgeHostNameImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	meta := akamai.Meta(m)
 	client := inst.Client(meta)
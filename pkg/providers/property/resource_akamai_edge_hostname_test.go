@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
 
@@ -96,6 +97,9 @@ func TestResourceEdgeHostname(t *testing.T) {
 						},
 					}},
 				}, nil)
+
+				// delete
+				expectDeleteEdgeHostname(mh, "edgesuite.net", "test2")
 			},
 			steps: []resource.TestStep{
 				{
@@ -181,6 +185,9 @@ func TestResourceEdgeHostname(t *testing.T) {
 						},
 					}},
 				}, nil)
+
+				// delete
+				expectDeleteEdgeHostname(mh, "edgekey.net", "test")
 			},
 			steps: []resource.TestStep{
 				{
@@ -264,6 +271,9 @@ func TestResourceEdgeHostname(t *testing.T) {
 						},
 					}},
 				}, nil)
+
+				// delete
+				expectDeleteEdgeHostname(mh, "akamaized.net", "test")
 			},
 			steps: []resource.TestStep{
 				{
@@ -360,6 +370,9 @@ func TestResourceEdgeHostname(t *testing.T) {
 						},
 					}},
 				}, nil)
+
+				// delete
+				expectDeleteEdgeHostname(mh, "edgesuite.net", "test.aka")
 			},
 			steps: []resource.TestStep{
 				{
@@ -401,6 +414,9 @@ func TestResourceEdgeHostname(t *testing.T) {
 						},
 					}},
 				}, nil).Times(3)
+
+				// delete
+				expectDeleteEdgeHostname(mh, "akamaized.net", "test")
 			},
 			steps: []resource.TestStep{
 				{
@@ -508,6 +524,9 @@ func TestResourceEdgeHostname(t *testing.T) {
 						},
 					}},
 				}, nil).Twice()
+
+				// delete
+				expectDeleteEdgeHostname(mh, "akamaized.net", "test", "hello@akamai.com")
 			},
 			steps: []resource.TestStep{
 				{
@@ -532,6 +551,174 @@ func TestResourceEdgeHostname(t *testing.T) {
 				},
 			},
 		},
+		"new edge hostname with ttl - waits for HAPI before changing ttl": {
+			init: func(mp *papi.Mock, mh *hapi.Mock) {
+				mp.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{
+					ContractID: "ctr_2",
+					GroupID:    "grp_2",
+				}).Return(&papi.GetEdgeHostnamesResponse{
+					ContractID:    "ctr_2",
+					GroupID:       "grp_2",
+					EdgeHostnames: papi.EdgeHostnameItems{Items: []papi.EdgeHostnameGetItem{}},
+				}, nil).Once()
+				mp.On("CreateEdgeHostname", mock.Anything, papi.CreateEdgeHostnameRequest{
+					ContractID: "ctr_2",
+					GroupID:    "grp_2",
+					EdgeHostname: papi.EdgeHostnameCreate{
+						ProductID:         "prd_2",
+						DomainPrefix:      "test",
+						DomainSuffix:      "akamaized.net",
+						SecureNetwork:     "SHARED_CERT",
+						IPVersionBehavior: "IPV4",
+					},
+				}).Return(&papi.CreateEdgeHostnameResponse{
+					EdgeHostnameID: "ehn_123",
+				}, nil).Once()
+				mp.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{
+					ContractID: "ctr_2",
+					GroupID:    "grp_2",
+				}).Return(&papi.GetEdgeHostnamesResponse{
+					ContractID: "ctr_2",
+					GroupID:    "grp_2",
+					EdgeHostnames: papi.EdgeHostnameItems{Items: []papi.EdgeHostnameGetItem{
+						{
+							ID:                "ehn_123",
+							Domain:            "test.akamaized.net",
+							ProductID:         "prd_2",
+							DomainPrefix:      "test",
+							DomainSuffix:      "akamaized.net",
+							IPVersionBehavior: "IPV4",
+						},
+					}},
+				}, nil)
+
+				// the edge hostname is not known to HAPI right after its creation
+				mh.On("GetEdgeHostname", mock.Anything, 123).Return(nil, &hapi.Error{Status: http.StatusNotFound}).Once()
+				mh.On("GetEdgeHostname", mock.Anything, 123).Return(&hapi.GetEdgeHostnameResponse{
+					EdgeHostnameID: 123,
+					TTL:            21600,
+				}, nil).Once()
+				mh.On("UpdateEdgeHostname", mock.Anything, hapi.UpdateEdgeHostnameRequest{
+					DNSZone:           "akamaized.net",
+					RecordName:        "test",
+					Comments:          "change /ttl to 300",
+					StatusUpdateEmail: []string{"hello@akamai.com"},
+					Body: []hapi.UpdateEdgeHostnameRequestBody{
+						{
+							Op:    "replace",
+							Path:  "/ttl",
+							Value: "300",
+						},
+					},
+				}).Return(&hapi.UpdateEdgeHostnameResponse{ChangeID: 1, Status: "SUCCEEDED"}, nil).Once()
+				mh.On("GetEdgeHostname", mock.Anything, 123).Return(&hapi.GetEdgeHostnameResponse{
+					EdgeHostnameID: 123,
+					TTL:            300,
+				}, nil)
+
+				// delete
+				expectDeleteEdgeHostname(mh, "akamaized.net", "test", "hello@akamai.com")
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString(fmt.Sprintf("%s/%s", testDir, "new_akamaized_ttl_poll.tf")),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "id", "ehn_123"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ttl", "300"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "change_id", "1"),
+					),
+				},
+			},
+		},
+		"edge hostname exists - update ttl and ip_behavior": {
+			init: func(mp *papi.Mock, mh *hapi.Mock) {
+				mp.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{
+					ContractID: "ctr_2",
+					GroupID:    "grp_2",
+				}).Return(&papi.GetEdgeHostnamesResponse{
+					ContractID: "ctr_2",
+					GroupID:    "grp_2",
+					EdgeHostnames: papi.EdgeHostnameItems{Items: []papi.EdgeHostnameGetItem{
+						{
+							ID:           "ehn_123",
+							Domain:       "test.akamaized.net",
+							ProductID:    "prd_2",
+							DomainPrefix: "test",
+							DomainSuffix: "akamaized.net",
+						},
+					}},
+				}, nil)
+
+				// 1st step
+				// create
+				mh.On("UpdateEdgeHostname", mock.Anything, hapi.UpdateEdgeHostnameRequest{
+					DNSZone:           "akamaized.net",
+					RecordName:        "test",
+					Comments:          "change /ttl to 300",
+					StatusUpdateEmail: []string{"hello@akamai.com"},
+					Body: []hapi.UpdateEdgeHostnameRequestBody{
+						{
+							Op:    "replace",
+							Path:  "/ttl",
+							Value: "300",
+						},
+					},
+				}).Return(&hapi.UpdateEdgeHostnameResponse{ChangeID: 1, Status: "SUCCEEDED"}, nil).Once()
+				mh.On("GetEdgeHostname", mock.Anything, 123).Return(&hapi.GetEdgeHostnameResponse{
+					EdgeHostnameID: 123,
+					TTL:            300,
+				}, nil).Times(3)
+
+				// 2nd step
+				// update
+				mh.On("UpdateEdgeHostname", mock.Anything, hapi.UpdateEdgeHostnameRequest{
+					DNSZone:           "akamaized.net",
+					RecordName:        "test",
+					Comments:          "change /ipVersionBehavior to IPV6_IPV4_DUALSTACK, /ttl to 600",
+					StatusUpdateEmail: []string{"hello@akamai.com"},
+					Body: []hapi.UpdateEdgeHostnameRequestBody{
+						{
+							Op:    "replace",
+							Path:  "/ipVersionBehavior",
+							Value: "IPV6_IPV4_DUALSTACK",
+						},
+						{
+							Op:    "replace",
+							Path:  "/ttl",
+							Value: "600",
+						},
+					},
+				}).Return(&hapi.UpdateEdgeHostnameResponse{ChangeID: 2, Status: "SUCCEEDED"}, nil).Once()
+				mh.On("GetEdgeHostname", mock.Anything, 123).Return(&hapi.GetEdgeHostnameResponse{
+					EdgeHostnameID: 123,
+					TTL:            600,
+				}, nil)
+
+				// delete
+				expectDeleteEdgeHostname(mh, "akamaized.net", "test", "hello@akamai.com")
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString(fmt.Sprintf("%s/%s", testDir, "new_akamaized_ttl.tf")),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "id", "ehn_123"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ip_behavior", "IPV4"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ttl", "300"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "change_id", "1"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "change_status", "SUCCEEDED"),
+					),
+				},
+				{
+					Config: loadFixtureString(fmt.Sprintf("%s/%s", testDir, "new_akamaized_update_ttl.tf")),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ip_behavior", "IPV6_COMPLIANCE"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ttl", "600"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "change_id", "2"),
+						resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "change_status", "SUCCEEDED"),
+					),
+				},
+			},
+		},
 		"error - update ip_behavior to ipv6_performance": {
 			init: func(mp *papi.Mock, mh *hapi.Mock) {
 				// 1. call from create method and refresh 2. update ip_behvior to improper value
@@ -599,6 +786,9 @@ func TestResourceEdgeHostname(t *testing.T) {
 						},
 					},
 				}).Return(nil, errors.New("invalid IP version behavior: valid values are IPV4 and IPV6_IPV4_DUALSTACK; IPV6 and other values aren't currently supported")).Once()
+
+				// delete
+				expectDeleteEdgeHostname(mh, "akamaized.net", "test", "hello@akamai.com")
 			},
 			steps: []resource.TestStep{
 				{
@@ -670,6 +860,9 @@ func TestResourceEdgeHostname(t *testing.T) {
 						},
 					}},
 				}, nil).Once()
+
+				// delete
+				expectDeleteEdgeHostname(mh, "akamaized.net", "test")
 			},
 			steps: []resource.TestStep{
 				{
@@ -741,6 +934,9 @@ func TestResourceEdgeHostname(t *testing.T) {
 						},
 					}},
 				}, nil).Once()
+
+				// delete
+				expectDeleteEdgeHostname(mh, "akamaized.net", "test")
 			},
 			steps: []resource.TestStep{
 				{
@@ -869,6 +1065,9 @@ func TestResourceEdgeHostname(t *testing.T) {
 				}).Return(&papi.CreateEdgeHostnameResponse{
 					EdgeHostnameID: "eh_123",
 				}, nil)
+
+				// delete
+				expectDeleteEdgeHostname(mh, "akamaized.net", "test")
 			},
 			steps: []resource.TestStep{
 				{
@@ -964,11 +1163,13 @@ func TestResourceEdgeHostnames_WithImport(t *testing.T) {
 
 	t.Run("import existing edgehostname code", func(t *testing.T) {
 		client := &papi.Mock{}
+		clientHapi := &hapi.Mock{}
 		id := "eh_1,1,2"
 
 		expectGetEdgeHostname(client, "eh_1", "ctr_1", "grp_2")
 		expectGetEdgeHostnames(client, "ctr_1", "grp_2")
-		useClient(client, nil, func() {
+		expectDeleteEdgeHostname(clientHapi, "akamaized.net", "test")
+		useClient(client, clientHapi, func() {
 			resource.UnitTest(t, resource.TestCase{
 				Providers: testAccProviders,
 				Steps: []resource.TestStep{
@@ -995,6 +1196,7 @@ func TestResourceEdgeHostnames_WithImport(t *testing.T) {
 			})
 		})
 		client.AssertExpectations(t)
+		clientHapi.AssertExpectations(t)
 	})
 }
 
@@ -1254,3 +1456,19 @@ func TestConvertingUseCases2JSON(t *testing.T) {
 		})
 	}
 }
+
+func expectDeleteEdgeHostname(m *hapi.Mock, dnsZone, recordName string, emails ...string) *mock.Call {
+	if emails == nil {
+		emails = []string{}
+	}
+	return m.On("DeleteEdgeHostname", mock.Anything, hapi.DeleteEdgeHostnameRequest{
+		DNSZone:           dnsZone,
+		RecordName:        recordName,
+		StatusUpdateEmail: emails,
+		Comments:          fmt.Sprintf("delete %s.%s", recordName, dnsZone),
+	}).Return(&hapi.DeleteEdgeHostnameResponse{
+		Action:   "DELETE",
+		ChangeID: 1,
+		Status:   "SUCCEEDED",
+	}, nil).Once()
+}
@@ -74,6 +74,7 @@ func resourceProperty() *schema.Resource {
 		DeleteContext: resourcePropertyDelete,
 		CustomizeDiff: customdiff.All(
 			rulesCustomDiff,
+			ruleFormatSchemaCustomDiff,
 			hostNamesCustomDiff,
 			versionsComputedValuesCustomDiff,
 		),
@@ -150,14 +151,14 @@ func resourceProperty() *schema.Resource {
 				Optional:         true,
 				Computed:         true,
 				Description:      "Specify the rule format version (defaults to latest version available when created)",
-				ValidateDiagFunc: tools.ValidateRuleFormatAcceptLatest,
+				ValidateDiagFunc: tools.AggregateValidations(tools.ValidateRuleFormatAcceptLatest, validateLatestRuleFormat),
 			},
 			"rules": {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
 				Description:      "Property Rules as JSON",
-				ValidateDiagFunc: validateRules,
+				ValidateDiagFunc: tools.AggregateValidations(validateRules, validateRulesSchema),
 				DiffSuppressFunc: diffSuppressRules,
 				StateFunc: func(v interface{}) string {
 					var js string
@@ -248,6 +249,12 @@ func resourceProperty() *schema.Resource {
 				Computed: true,
 				Elem:     papiError(),
 			},
+			"rules_diff": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The changes of the rules in the plan, one per changed path, e.g. default/children[2]/behaviors/caching.ttl: 1d -> 7d",
+			},
 			"rule_warnings": {
 				Type:       schema.TypeList,
 				Optional:   true,
@@ -316,10 +323,18 @@ func validatePropertyName(v interface{}, _ cty.Path) diag.Diagnostics {
 
 // rulesCustomDiff compares Rules.Criteria and Rules.Children fields from terraform state and from a new configuration.
 // If some of these fields are empty lists in the new configuration and are nil in the terraform state, then this function
-// returns no difference for these fields
-func rulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+// returns no difference for these fields.
+// It also lists the changed paths of the rule tree in rules_diff, so that the plan shows which behaviors change
+func rulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, m interface{}) error {
 	o, n := diff.GetChange("rules")
 
+	if diff.Id() != "" && !diff.NewValueKnown("rules") {
+		if err := diff.SetNewComputed("rules_diff"); err != nil {
+			return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+		}
+		return nil
+	}
+
 	oldValue := o.(string)
 	newValue := n.(string)
 
@@ -350,6 +365,15 @@ func rulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}
 		return fmt.Errorf("cannot parse rules JSON from config: %s", err)
 	}
 
+	if !diffSuppressRules("", oldValue, newValue, nil) {
+		changes := rulesDiff(&oldRulesUpdate, &newRulesUpdate)
+		logger := akamai.Meta(m).Log("PAPI", "rulesCustomDiff")
+		logger.Debugf("rules changes of property %s:\n%s", diff.Id(), strings.Join(changes, "\n"))
+		if err := diff.SetNew("rules_diff", changes); err != nil {
+			return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+		}
+	}
+
 	rules, err := compareFields(&oldRulesUpdate, &newRulesUpdate)
 	if err != nil {
 		return fmt.Errorf("cannot encode rules JSON %s", err)
@@ -476,7 +500,7 @@ func resourcePropertyCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 	PropertyID, err := createProperty(ctx, client, PropertyName, GroupID, ContractID, ProductID, RuleFormat)
 	if err != nil {
-		if strings.Contains(err.Error(), "\"statusCode\": 404") {
+		if akamai.IsAPINotFound(err) {
 			// find out what is missing from the request
 			if _, err = getGroup(ctx, meta, GroupID); err != nil {
 				if errors.Is(err, ErrGroupNotFound) {
@@ -555,7 +579,7 @@ func resourcePropertyCreate(ctx context.Context, d *schema.ResourceData, m inter
 		}
 	}
 
-	return resourcePropertyRead(ctx, d, m)
+	return append(ruleFormatSchemaWarnings(d), resourcePropertyRead(ctx, d, m)...)
 }
 
 func resourcePropertyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -578,6 +602,9 @@ func resourcePropertyRead(ctx context.Context, d *schema.ResourceData, m interfa
 		Property, v, err = fetchProperty(ctx, client, PropertyID, GroupID, ContractID, strconv.Itoa(ReadVersionID))
 	}
 	if err != nil {
+		if akamai.RemoveIfNotFound(d, err, logger) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 	if v == 0 {
@@ -649,6 +676,8 @@ func resourcePropertyRead(ctx context.Context, d *schema.ResourceData, m interfa
 		"rule_format":        RuleFormat,
 		"rule_errors":        papiErrorsToList(RuleErrors),
 		"read_version":       ReadVersionID,
+		// the changes are only listed in the plan
+		"rules_diff": []string{},
 	}
 	if Property.ProductID != "" {
 		attrs["product_id"] = Property.ProductID
@@ -795,7 +824,7 @@ func resourcePropertyUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		}
 	}
 
-	return resourcePropertyRead(ctx, d, m)
+	return append(ruleFormatSchemaWarnings(d), resourcePropertyRead(ctx, d, m)...)
 }
 
 func resourcePropertyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +17,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
 	"github.com/apex/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/spf13/cast"
 )
@@ -24,7 +28,14 @@ func resourcePropertyActivation() *schema.Resource {
 		ReadContext:   resourcePropertyActivationRead,
 		UpdateContext: resourcePropertyActivationUpdate,
 		DeleteContext: resourcePropertyActivationDelete,
-		Schema:        akamaiPropertyActivationSchema,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePropertyActivationImport,
+		},
+		Schema: akamaiPropertyActivationSchema,
+		CustomizeDiff: customdiff.All(
+			complianceRecordCustomDiff,
+			referencedIncludesCustomDiff,
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Default: &PropertyResourceTimeout,
 		},
@@ -115,6 +126,39 @@ var akamaiPropertyActivationSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "assigns a log message to the activation request",
 	},
+	"compliance_record": complianceRecordSchema(),
+	"fast_push": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "whether to push the activation to the edge servers as soon as possible. default is true",
+	},
+	"use_fast_fallback": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "whether to use fast fallback, which activates the previous version in seconds within an hour of the activation of the current one",
+	},
+	"ignore_http_errors": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "whether to ignore HTTP errors when pushing the activation. default is true",
+	},
+	"activate_includes": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "whether to activate the referenced includes which are not active on the network before the property, with the version active on staging for production activations. default is false",
+	},
+	"activated_includes": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "the include versions activated before the property, in the form include_id@version",
+	},
+	tools.PollIntervalKey: tools.PollIntervalSchema(ActivationPollInterval),
+	tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 }
 
 func papiError() *schema.Resource {
@@ -153,6 +197,9 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 	if err := d.Set("property_id", propertyID); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
+	if err := d.Set("activated_includes", []string{}); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
 
 	network, err := networkAlias(d)
 	if err != nil {
@@ -212,17 +259,20 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 			return diag.FromErr(err)
 		}
 
-		create, err := client.CreateActivation(ctx, papi.CreateActivationRequest{
-			PropertyID: propertyID,
-			Activation: papi.Activation{
-				ActivationType:         papi.ActivationTypeActivate,
-				Network:                network,
-				PropertyVersion:        version,
-				NotifyEmails:           notify,
-				AcknowledgeAllWarnings: acknowledgeRuleWarnings,
-				Note:                   note,
-			},
-		})
+		newActivation := papi.Activation{
+			ActivationType:         papi.ActivationTypeActivate,
+			Network:                network,
+			PropertyVersion:        version,
+			NotifyEmails:           notify,
+			AcknowledgeAllWarnings: acknowledgeRuleWarnings,
+			Note:                   note,
+			UseFastFallback:        d.Get("use_fast_fallback").(bool),
+		}
+		if err := activateReferencedIncludes(ctx, d, client, propertyID, newActivation, logger); err != nil {
+			return diag.FromErr(err)
+		}
+
+		create, err := createActivation(ctx, client, inst.Executor(meta), d, propertyID, newActivation)
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("create activation failed: %w", err))
 		}
@@ -247,32 +297,14 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
 
-	for activation.Status != papi.ActivationStatusActive {
-		if activation.Status == papi.ActivationStatusAborted {
-			return diag.FromErr(fmt.Errorf("activation request aborted"))
-		}
-		if activation.Status == papi.ActivationStatusFailed {
-			return diag.FromErr(fmt.Errorf("activation request failed in downstream system"))
-		}
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, papi.GetActivationRequest{
-				ActivationID: activation.ActivationID,
-				PropertyID:   propertyID,
-			})
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act.Activation
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return diag.Diagnostics{DiagWarnActivationTimeout}
-			} else if errors.Is(ctx.Err(), context.Canceled) {
-				return diag.Diagnostics{DiagWarnActivationCanceled}
-			}
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+	activation, err = waitForActivation(ctx, d, client, propertyID, activation, "activation", false, logger)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return diag.Diagnostics{DiagWarnActivationTimeout}
+		} else if errors.Is(err, context.Canceled) {
+			return diag.Diagnostics{DiagWarnActivationCanceled}
 		}
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("status", string(activation.Status)); err != nil {
@@ -349,16 +381,13 @@ func resourcePropertyActivationDelete(ctx context.Context, d *schema.ResourceDat
 			return diag.FromErr(err)
 		}
 
-		deleteActivation, err := client.CreateActivation(ctx, papi.CreateActivationRequest{
-			PropertyID: propertyID,
-			Activation: papi.Activation{
-				ActivationType:         papi.ActivationTypeDeactivate,
-				Network:                network,
-				PropertyVersion:        version,
-				NotifyEmails:           notify,
-				AcknowledgeAllWarnings: acknowledgeRuleWarnings,
-				Note:                   note,
-			},
+		deleteActivation, err := createActivation(ctx, client, inst.Executor(meta), d, propertyID, papi.Activation{
+			ActivationType:         papi.ActivationTypeDeactivate,
+			Network:                network,
+			PropertyVersion:        version,
+			NotifyEmails:           notify,
+			AcknowledgeAllWarnings: acknowledgeRuleWarnings,
+			Note:                   note,
 		})
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("create deactivation failed: %w", err))
@@ -387,36 +416,143 @@ func resourcePropertyActivationDelete(ctx context.Context, d *schema.ResourceDat
 	}
 
 	// deactivations also use status Active for when they are fully processed
-	for activation.Status != papi.ActivationStatusActive {
-		if activation.Status == papi.ActivationStatusAborted {
-			return diag.FromErr(fmt.Errorf("deactivation request aborted"))
+	if _, err := waitForActivation(ctx, d, client, propertyID, activation, "deactivation", true, logger); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourcePropertyActivationImport adopts the activation of the property on the network, without activating anything.
+// The import ID is property_id:network, for the version active on the network, or property_id:network:version
+func resourcePropertyActivationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyActivationImport")
+	client := inst.Client(meta)
+
+	logger.Debug("Importing property activation")
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		return nil, fmt.Errorf("invalid property activation identifier: %q, expected property_id:network or property_id:network:version", d.Id())
+	}
+	propertyID := tools.AddPrefix(parts[0], "prp_")
+	alias, err := NetworkAlias(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid property activation identifier: %q: %w", d.Id(), err)
+	}
+	network := papi.ActivationNetwork(alias)
+
+	var version int
+	if len(parts) == 3 {
+		version, err = strconv.Atoi(strings.TrimPrefix(strings.ToLower(parts[2]), "v"))
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("invalid property activation identifier: %q: version must be a positive number, e.g. v5", d.Id())
+		}
+	} else {
+		resp, err := client.GetLatestVersion(ctx, papi.GetLatestVersionRequest{
+			PropertyID:  propertyID,
+			ActivatedOn: string(network),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("no version of property %s is active on %s: %w", propertyID, network, err)
 		}
-		if activation.Status == papi.ActivationStatusFailed {
-			return diag.FromErr(fmt.Errorf("deactivation request failed in downstream system"))
+		version = resp.Version.PropertyVersion
+	}
+
+	activation, err := lookupActivation(ctx, client, lookupActivationRequest{
+		propertyID: propertyID,
+		version:    version,
+		network:    network,
+		activationType: map[papi.ActivationType]struct{}{
+			papi.ActivationTypeActivate: {},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if activation == nil {
+		return nil, fmt.Errorf("version %d of property %s is not active on %s", version, propertyID, network)
+	}
+
+	attrs := map[string]interface{}{
+		"property_id":   propertyID,
+		"network":       string(network),
+		"version":       version,
+		"activation_id": activation.ActivationID,
+		"contact":       activation.NotifyEmails,
+		"note":          activation.Note,
+		"status":        string(activation.Status),
+		// the options of the activation, so that importing it plans no changes for the configuration which created it
+		"auto_acknowledge_rule_warnings": activation.AcknowledgeAllWarnings,
+		"fast_push":                      activation.FastPush,
+		"use_fast_fallback":              activation.UseFastFallback,
+		"ignore_http_errors":             activation.IgnoreHTTPErrors,
+		"activate_includes":              false,
+		"activated_includes":             []string{},
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return nil, err
+	}
+	d.SetId(propertyID + ":" + string(network))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitForActivation polls the (de)activation until it is fully processed
+func waitForActivation(ctx context.Context, d *schema.ResourceData, client papi.PAPI, propertyID string, activation *papi.Activation,
+	kind string, updateMessages bool, logger log.Interface) (*papi.Activation, error) {
+	checkStatus := func(activation *papi.Activation) (bool, error) {
+		switch activation.Status {
+		case papi.ActivationStatusActive:
+			return true, nil
+		case papi.ActivationStatusAborted:
+			return false, fmt.Errorf("%s request aborted", kind)
+		case papi.ActivationStatusFailed:
+			return false, fmt.Errorf("%s request failed in downstream system", kind)
 		}
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, papi.GetActivationRequest{
+		return false, nil
+	}
+	if done, err := checkStatus(activation); done || err != nil {
+		return activation, err
+	}
+
+	poller, err := tools.NewPoller("property "+kind, d, ActivationPollInterval, ActivationPollMinimum, logger)
+	if err != nil {
+		return nil, err
+	}
+	err = poller.Poll(ctx, func(ctx context.Context) (bool, error) {
+		var act *papi.GetActivationResponse
+		err := akamai.RetryTransient(ctx, akamai.PollRetryAttempts, akamai.PollRetryInterval, func() error {
+			var err error
+			act, err = client.GetActivation(ctx, papi.GetActivationRequest{
 				ActivationID: activation.ActivationID,
 				PropertyID:   propertyID,
 			})
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act.Activation
+			return err
+		})
+		if err != nil {
+			return false, err
+		}
+		activation = act.Activation
 
+		if updateMessages {
 			if err = setErrorsAndWarnings(d, flattenErrorArray(act.Errors), flattenErrorArray(act.Warnings)); err != nil {
-				return diag.FromErr(err)
+				return false, err
 			}
-
-		case <-ctx.Done():
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
 		}
+		return checkStatus(activation)
+	})
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return activation, fmt.Errorf("%s context terminated: %w", kind, err)
 	}
-
-	d.SetId("")
-
-	return nil
+	return activation, err
 }
 
 func flattenErrorArray(errors []*papi.Error) string {
@@ -603,17 +739,20 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 			notify = append(notify, cast.ToString(contact))
 		}
 
-		create, err := client.CreateActivation(ctx, papi.CreateActivationRequest{
-			PropertyID: propertyID,
-			Activation: papi.Activation{
-				ActivationType:         papi.ActivationTypeActivate,
-				Network:                network,
-				PropertyVersion:        version,
-				NotifyEmails:           notify,
-				AcknowledgeAllWarnings: acknowledgeRuleWarnings,
-				Note:                   note,
-			},
-		})
+		newActivation := papi.Activation{
+			ActivationType:         papi.ActivationTypeActivate,
+			Network:                network,
+			PropertyVersion:        version,
+			NotifyEmails:           notify,
+			AcknowledgeAllWarnings: acknowledgeRuleWarnings,
+			Note:                   note,
+			UseFastFallback:        d.Get("use_fast_fallback").(bool),
+		}
+		if err := activateReferencedIncludes(ctx, d, client, propertyID, newActivation, logger); err != nil {
+			return diag.FromErr(err)
+		}
+
+		create, err := createActivation(ctx, client, inst.Executor(meta), d, propertyID, newActivation)
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("create activation failed: %w", err))
 		}
@@ -640,31 +779,9 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("cannot update activation attribute note after creation")
 	}
 
-	for propertyActivation.Status != papi.ActivationStatusActive {
-		if propertyActivation.Status == papi.ActivationStatusAborted {
-			return diag.FromErr(fmt.Errorf("activation request aborted"))
-		}
-		if propertyActivation.Status == papi.ActivationStatusFailed {
-			return diag.FromErr(fmt.Errorf("activation request failed in downstream system"))
-		}
-		select {
-		case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, papi.GetActivationRequest{
-				ActivationID: propertyActivation.ActivationID,
-				PropertyID:   propertyID,
-			})
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			propertyActivation = act.Activation
-
-			if err = setErrorsAndWarnings(d, flattenErrorArray(act.Errors), flattenErrorArray(act.Warnings)); err != nil {
-				return diag.FromErr(err)
-			}
-
-		case <-ctx.Done():
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
-		}
+	propertyActivation, err = waitForActivation(ctx, d, client, propertyID, propertyActivation, "activation", true, logger)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("status", string(propertyActivation.Status)); err != nil {
@@ -684,6 +801,274 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 	return nil
 }
 
+// createActivation sends the activation or deactivation request with the compliance record and the fast push options of the resource.
+// The PAPI client can send neither the compliance record nor disabled fast push options, so such requests are sent with the executor
+func createActivation(ctx context.Context, client papi.PAPI, exec executor, d *schema.ResourceData, propertyID string, activation papi.Activation) (*papi.CreateActivationResponse, error) {
+	complianceRecord, err := tools.GetListValue("compliance_record", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, err
+	}
+	// Schema guarantees these types
+	fastPush := d.Get("fast_push").(bool)
+	ignoreHTTPErrors := d.Get("ignore_http_errors").(bool)
+
+	if len(complianceRecord) == 0 && fastPush && ignoreHTTPErrors {
+		return client.CreateActivation(ctx, papi.CreateActivationRequest{
+			PropertyID: propertyID,
+			Activation: activation,
+		})
+	}
+
+	body := struct {
+		papi.Activation
+		FastPush         bool        `json:"fastPush"`
+		IgnoreHTTPErrors bool        `json:"ignoreHttpErrors"`
+		ComplianceRecord interface{} `json:"complianceRecord,omitempty"`
+	}{
+		Activation:       activation,
+		FastPush:         fastPush,
+		IgnoreHTTPErrors: ignoreHTTPErrors,
+	}
+	if len(complianceRecord) > 0 {
+		body.ComplianceRecord = addComplianceRecord(complianceRecord, papi.ActivateOrDeactivateIncludeRequest{}).ComplianceRecord
+	}
+
+	uri := fmt.Sprintf("/papi/v1/properties/%s/activations", url.PathEscape(propertyID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", papi.ErrCreateActivation, err)
+	}
+	var create papi.CreateActivationResponse
+	resp, err := exec.Exec(req, &create, body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", papi.ErrCreateActivation, err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		apiErr := papi.Error{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
+			apiErr.Title = "Failed to unmarshal error body"
+			apiErr.Detail = err.Error()
+		}
+		return nil, fmt.Errorf("%s: %w", papi.ErrCreateActivation, &apiErr)
+	}
+	if create.ActivationID, err = papi.ResponseLinkParse(create.ActivationLink); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", papi.ErrCreateActivation, papi.ErrInvalidResponseLink, err)
+	}
+
+	return &create, nil
+}
+
+// complianceRecordCustomDiff checks the compliance record of a new activation, which is required on the production
+// network when the provider sets require_compliance_record
+func complianceRecordCustomDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChanges("version", "network", "property_id", "property") {
+		return nil
+	}
+	if !d.NewValueKnown("network") || !d.NewValueKnown("compliance_record") {
+		return nil
+	}
+	network, err := NetworkAlias(d.Get("network").(string))
+	if err != nil {
+		return err
+	}
+
+	complianceRecord, err := tools.GetListValue("compliance_record", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return err
+	}
+	if len(complianceRecord) == 0 {
+		if papi.ActivationNetwork(network) == papi.ActivationNetworkProduction && akamai.Meta(m).RequireComplianceRecord() {
+			return fmt.Errorf("compliance_record is required to activate on the %s network", papi.ActivationNetworkProduction)
+		}
+		return nil
+	}
+
+	record := addComplianceRecord(complianceRecord, papi.ActivateOrDeactivateIncludeRequest{}).ComplianceRecord
+	if v, ok := record.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("compliance_record: %s", err)
+		}
+	}
+	if none, ok := record.(*papi.ComplianceRecordNone); ok && !none.UnitTested && papi.ActivationNetwork(network) == papi.ActivationNetworkProduction {
+		return fmt.Errorf("compliance_record: unit_tested must be true for the %s noncompliance reason on the %s network",
+			papi.NoncomplianceReasonNone, papi.ActivationNetworkProduction)
+	}
+	return nil
+}
+
+// referencedIncludesCustomDiff warns when the plan of a new activation has a property version referencing includes which
+// are not active on the network, unless activate_includes is set to activate them first.
+// The plan does not fail, as the includes may be activated by an akamai_property_include_activation of the same apply
+func referencedIncludesCustomDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "referencedIncludesCustomDiff")
+
+	if d.Id() != "" {
+		if !d.HasChanges("version", "network") && !propertyIDChanged(d, "property_id") && !propertyIDChanged(d, "property") {
+			return nil
+		}
+		if err := d.SetNewComputed("activated_includes"); err != nil {
+			return err
+		}
+	}
+	if d.Get("activate_includes").(bool) {
+		return nil
+	}
+	// property_id and property are computed when not set, so the one which is set is known only when configured
+	propertyID, err := resolvePropertyID(d)
+	if errors.Is(err, tools.ErrNotFound) || !d.NewValueKnown("version") || !d.NewValueKnown("network") || !d.NewValueKnown("activate_includes") {
+		logger.Debug("referenced includes are checked when the activation is applied")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	network, err := NetworkAlias(d.Get("network").(string))
+	if err != nil {
+		return err
+	}
+	version := d.Get("version").(int)
+
+	includes, err := inactiveReferencedIncludes(ctx, inst.Client(meta), propertyID, version, papi.ActivationNetwork(network))
+	if err != nil {
+		return err
+	}
+	if len(includes) > 0 {
+		logger.Warnf("%s. The activation fails unless they are active when it is applied", includesNotActiveError(propertyID, version, papi.ActivationNetwork(network), includes))
+	}
+	return nil
+}
+
+// propertyIDChanged reports whether the property ID changes, comparing the IDs with the prefix they are stored with
+func propertyIDChanged(d *schema.ResourceDiff, key string) bool {
+	oldID, newID := d.GetChange(key)
+	return tools.AddPrefix(oldID.(string), "prp_") != tools.AddPrefix(newID.(string), "prp_")
+}
+
+// activateReferencedIncludes activates the includes referenced by the property version which are not active on the network
+// of the activation, and waits for them to be active, so that the property activation does not fail.
+// The versions are chosen by includeVersionToActivate.
+// It fails without activating anything when activate_includes is not set or a version cannot be chosen
+func activateReferencedIncludes(ctx context.Context, d *schema.ResourceData, client papi.PAPI, propertyID string, activation papi.Activation, logger log.Interface) error {
+	includes, err := inactiveReferencedIncludes(ctx, client, propertyID, activation.PropertyVersion, activation.Network)
+	if err != nil {
+		return err
+	}
+	if len(includes) > 0 && !d.Get("activate_includes").(bool) {
+		return includesNotActiveError(propertyID, activation.PropertyVersion, activation.Network, includes)
+	}
+
+	versions := make([]int, 0, len(includes))
+	var unstaged []papi.Include
+	for _, include := range includes {
+		version, ok := includeVersionToActivate(include, activation.Network)
+		if !ok {
+			unstaged = append(unstaged, include)
+		}
+		versions = append(versions, version)
+	}
+	if len(unstaged) > 0 {
+		return includesNotStagedError(propertyID, activation.PropertyVersion, unstaged)
+	}
+
+	complianceRecord, err := tools.GetListValue("compliance_record", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return err
+	}
+
+	activationIDs := make([]string, 0, len(includes))
+	for i, include := range includes {
+		request, err := addComplianceRecordToActivationByNetwork(string(activation.Network), complianceRecord, papi.ActivateIncludeRequest{
+			IncludeID:              include.IncludeID,
+			Version:                versions[i],
+			Network:                activation.Network,
+			Note:                   activation.Note,
+			NotifyEmails:           activation.NotifyEmails,
+			AcknowledgeAllWarnings: activation.AcknowledgeAllWarnings,
+		})
+		if err != nil {
+			return err
+		}
+		logger.Debugf("activating version %d of include %s referenced by property %s", versions[i], include.IncludeID, propertyID)
+		res, err := client.ActivateInclude(ctx, request)
+		if err != nil {
+			return fmt.Errorf("%w: activation of version %d of %s: %s", ErrPropertyInclude, versions[i], include.IncludeID, err)
+		}
+		activationIDs = append(activationIDs, res.ActivationID)
+	}
+
+	activated := make([]string, 0, len(includes))
+	for i, include := range includes {
+		if _, err := waitForPropertyIncludeOperation(ctx, d, client, activationIDs[i], include.IncludeID, "activation", logger); err != nil {
+			return err
+		}
+		activated = append(activated, fmt.Sprintf("%s@%d", include.IncludeID, versions[i]))
+	}
+
+	if err := d.Set("activated_includes", activated); err != nil {
+		return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
+// includeVersionToActivate returns the version of the include activated before the property on the network.
+// Production activations take the version active on staging, so that untested versions never reach production,
+// and fail when there is none. Staging activations take the version active on production, or else the latest version
+func includeVersionToActivate(include papi.Include, network papi.ActivationNetwork) (int, bool) {
+	if network == papi.ActivationNetworkProduction {
+		if include.StagingVersion == nil {
+			return 0, false
+		}
+		return *include.StagingVersion, true
+	}
+	if include.ProductionVersion != nil {
+		return *include.ProductionVersion, true
+	}
+	return include.LatestVersion, true
+}
+
+// inactiveReferencedIncludes returns the includes referenced by the property version which have no version active on the network
+func inactiveReferencedIncludes(ctx context.Context, client papi.PAPI, propertyID string, version int, network papi.ActivationNetwork) ([]papi.Include, error) {
+	referenced, err := client.ListReferencedIncludes(ctx, papi.ListReferencedIncludesRequest{
+		PropertyID:      propertyID,
+		PropertyVersion: version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var inactive []papi.Include
+	for _, include := range referenced.Includes.Items {
+		activeVersion := include.StagingVersion
+		if network == papi.ActivationNetworkProduction {
+			activeVersion = include.ProductionVersion
+		}
+		if activeVersion == nil {
+			inactive = append(inactive, include)
+		}
+	}
+	return inactive, nil
+}
+
+func includesNotActiveError(propertyID string, version int, network papi.ActivationNetwork, includes []papi.Include) error {
+	return fmt.Errorf("%w on the %s network: version %d of property %s references %s. Activate them with "+
+		"akamai_property_include_activation first or set activate_includes to true", ErrIncludesNotActive, network, version, propertyID, includeNames(includes))
+}
+
+func includesNotStagedError(propertyID string, version int, includes []papi.Include) error {
+	return fmt.Errorf("%w on the %s network: version %d of property %s references %s, which have no version active on the %s network "+
+		"for activate_includes to activate. Activate them on %s first", ErrIncludesNotActive, papi.ActivationNetworkProduction, version, propertyID,
+		includeNames(includes), papi.ActivationNetworkStaging, papi.ActivationNetworkStaging)
+}
+
+func includeNames(includes []papi.Include) string {
+	names := make([]string, 0, len(includes))
+	for _, include := range includes {
+		names = append(names, fmt.Sprintf("%s (%s)", include.IncludeID, include.IncludeName))
+	}
+	return strings.Join(names, ", ")
+}
+
 func resolveVersionStatus(ctx context.Context, client papi.PAPI, propertyID string, version int, network papi.ActivationNetwork) (papi.VersionStatus, error) {
 	var versionStatus papi.VersionStatus
 	propertyVersion, err := client.GetPropertyVersion(ctx, papi.GetPropertyVersionRequest{
@@ -734,7 +1119,7 @@ func setErrorsAndWarnings(d *schema.ResourceData, errors, warnings string) error
 	return nil
 }
 
-func resolvePropertyID(d *schema.ResourceData) (string, error) {
+func resolvePropertyID(d tools.ResourceDataFetcher) (string, error) {
 	propertyID, err := tools.GetStringValue("property_id", d)
 	if errors.Is(err, tools.ErrNotFound) {
 		// use legacy property as fallback option
@@ -0,0 +1,357 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/spf13/cast"
+)
+
+// resourcePropertyActivationRollback rolls the property back to a previous version on the network, using fast fallback
+// when the version active on the network can still fall back to it
+func resourcePropertyActivationRollback() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyActivationRollbackCreate,
+		ReadContext:   resourcePropertyActivationRollbackRead,
+		UpdateContext: resourcePropertyActivationRollbackUpdate,
+		DeleteContext: resourcePropertyActivationRollbackDelete,
+		CustomizeDiff: complianceRecordCustomDiff,
+		Schema:        akamaiPropertyActivationRollbackSchema,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PropertyResourceTimeout,
+		},
+	}
+}
+
+var akamaiPropertyActivationRollbackSchema = map[string]*schema.Schema{
+	"property_id": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		StateFunc:   addPrefixToState("prp_"),
+		Description: "the property to roll back",
+	},
+	"network": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     papi.ActivationNetworkStaging,
+		Description: "the network to roll back, STAGING or PRODUCTION. default is STAGING",
+	},
+	"version": {
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "the version to activate, by default the fallback version of the active one",
+	},
+	"fast_fallback_only": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "whether to fail instead of fully activating the version when fast fallback is not possible",
+	},
+	"contact": {
+		Type:     schema.TypeSet,
+		Required: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+	"note": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "assigns a log message to the activation request",
+	},
+	"auto_acknowledge_rule_warnings": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "automatically acknowledge all rule warnings for activation to continue. default is true",
+	},
+	"compliance_record": complianceRecordSchema(),
+	"fast_push": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "whether to push the activation to the edge servers as soon as possible. default is true",
+	},
+	"ignore_http_errors": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "whether to ignore HTTP errors when pushing the activation. default is true",
+	},
+	"rolled_back_version": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "the version which was active on the network before the rollback",
+	},
+	"fast_fallback": {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "whether the rollback used fast fallback",
+	},
+	"fallback_info": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "the fast fallback information of the activation which was rolled back",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"fast_fallback_attempted": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"fallback_version": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"can_fast_fallback": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"steady_state_time": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"fast_fallback_expiration_time": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"fast_fallback_recovery_state": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	},
+	"activation_id": {
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	"status": {
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	tools.PollIntervalKey: tools.PollIntervalSchema(ActivationPollInterval),
+	tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
+}
+
+func resourcePropertyActivationRollbackCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyActivationRollbackCreate")
+	client := inst.Client(meta)
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	propertyID := tools.AddPrefix(d.Get("property_id").(string), "prp_")
+	network, err := networkAlias(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current, err := lookupActiveActivation(ctx, client, propertyID, network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if current == nil {
+		return diag.Errorf("property %s has no version active on %s", propertyID, network)
+	}
+	// the activations list does not always hold the fallback information
+	act, err := client.GetActivation(ctx, papi.GetActivationRequest{
+		ActivationID: current.ActivationID,
+		PropertyID:   propertyID,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	current = act.Activation
+	fallbackInfo := current.FallbackInfo
+
+	version, err := tools.GetIntValue("version", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if version == 0 && fallbackInfo != nil {
+		version = fallbackInfo.FallbackVersion
+	}
+	if version == 0 {
+		return diag.Errorf("version is required: version %d active on %s has no fallback version", current.PropertyVersion, network)
+	}
+	if version == current.PropertyVersion {
+		return diag.Errorf("version %d of property %s is already active on %s", version, propertyID, network)
+	}
+
+	fastFallback := canFastFallback(fallbackInfo, version, time.Now())
+	if !fastFallback && d.Get("fast_fallback_only").(bool) {
+		return diag.Errorf("version %d active on %s cannot fast fall back to version %d", current.PropertyVersion, network, version)
+	}
+	logger.Debugf("rolling back property %s on %s from version %d to %d, fast fallback: %t", propertyID, network, current.PropertyVersion, version, fastFallback)
+
+	notifySet, err := tools.GetSetValue("contact", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var notify []string
+	for _, contact := range notifySet.List() {
+		notify = append(notify, cast.ToString(contact))
+	}
+	note, err := tools.GetStringValue("note", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	create, err := createActivation(ctx, client, inst.Executor(meta), d, propertyID, papi.Activation{
+		ActivationType:         papi.ActivationTypeActivate,
+		Network:                network,
+		PropertyVersion:        version,
+		NotifyEmails:           notify,
+		AcknowledgeAllWarnings: d.Get("auto_acknowledge_rule_warnings").(bool),
+		Note:                   note,
+		UseFastFallback:        fastFallback,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("create rollback failed: %w", err))
+	}
+	d.SetId(fmt.Sprintf("%s:%s:%s", propertyID, network, create.ActivationID))
+
+	attrs := map[string]interface{}{
+		"property_id":         propertyID,
+		"version":             version,
+		"rolled_back_version": current.PropertyVersion,
+		"fast_fallback":       fastFallback,
+		"fallback_info":       flattenFallbackInfo(fallbackInfo),
+		"activation_id":       create.ActivationID,
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	act, err = client.GetActivation(ctx, papi.GetActivationRequest{
+		ActivationID: create.ActivationID,
+		PropertyID:   propertyID,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	activation, err := waitForActivation(ctx, d, client, propertyID, act.Activation, "rollback", false, logger)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return diag.Diagnostics{DiagWarnActivationTimeout}
+		} else if errors.Is(err, context.Canceled) {
+			return diag.Diagnostics{DiagWarnActivationCanceled}
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("status", string(activation.Status)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+func resourcePropertyActivationRollbackRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyActivationRollbackRead")
+	client := inst.Client(meta)
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	act, err := client.GetActivation(ctx, papi.GetActivationRequest{
+		ActivationID: d.Get("activation_id").(string),
+		PropertyID:   tools.AddPrefix(d.Get("property_id").(string), "prp_"),
+	})
+	if err != nil {
+		if akamai.RemoveIfNotFound(d, err, logger) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if err := d.Set("status", string(act.Activation.Status)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+// resourcePropertyActivationRollbackUpdate only stores the new values, the rollback is done
+func resourcePropertyActivationRollbackUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePropertyActivationRollbackRead(ctx, d, m)
+}
+
+// resourcePropertyActivationRollbackDelete removes the rollback from the state, keeping the version active
+func resourcePropertyActivationRollbackDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyActivationRollbackDelete")
+	logger.Debugf("removing rollback %s from the state, version %d stays active", d.Id(), d.Get("version").(int))
+
+	d.SetId("")
+	return nil
+}
+
+// lookupActiveActivation returns the activation of the version active on the network, or nil if no version is active
+func lookupActiveActivation(ctx context.Context, client papi.PAPI, propertyID string, network papi.ActivationNetwork) (*papi.Activation, error) {
+	activations, err := client.GetActivations(ctx, papi.GetActivationsRequest{
+		PropertyID: propertyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *papi.Activation
+	var latestSubmitDate time.Time
+	for _, a := range activations.Activations.Items {
+		if a.Network != network || a.Status != papi.ActivationStatusActive {
+			continue
+		}
+		submitDate, err := tools.ParseDate(tools.DateTimeFormat, a.SubmitDate)
+		if err != nil {
+			return nil, err
+		}
+		if latest == nil || latestSubmitDate.Before(submitDate) {
+			latest = a
+			latestSubmitDate = submitDate
+		}
+	}
+	if latest == nil || latest.ActivationType != papi.ActivationTypeActivate {
+		return nil, nil
+	}
+	return latest, nil
+}
+
+// canFastFallback returns true if the activation with the fallback information can still fall back to the version
+func canFastFallback(info *papi.ActivationFallbackInfo, version int, now time.Time) bool {
+	if info == nil || !info.CanFastFallback || info.FallbackVersion != version {
+		return false
+	}
+	return now.Before(time.Unix(int64(info.FastFallbackExpirationTime), 0))
+}
+
+func flattenFallbackInfo(info *papi.ActivationFallbackInfo) []interface{} {
+	if info == nil {
+		return []interface{}{}
+	}
+	var recoveryState string
+	if info.FastFallbackRecoveryState != nil {
+		recoveryState = *info.FastFallbackRecoveryState
+	}
+	return []interface{}{map[string]interface{}{
+		"fast_fallback_attempted":       info.FastFallbackAttempted,
+		"fallback_version":              info.FallbackVersion,
+		"can_fast_fallback":             info.CanFastFallback,
+		"steady_state_time":             formatEpoch(info.SteadyStateTime),
+		"fast_fallback_expiration_time": formatEpoch(info.FastFallbackExpirationTime),
+		"fast_fallback_recovery_state":  recoveryState,
+	}}
+}
+
+// formatEpoch formats the seconds since the epoch in RFC 3339, or returns an empty string for 0
+func formatEpoch(seconds int) string {
+	if seconds == 0 {
+		return ""
+	}
+	return time.Unix(int64(seconds), 0).UTC().Format(time.RFC3339)
+}
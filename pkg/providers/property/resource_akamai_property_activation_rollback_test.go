@@ -0,0 +1,244 @@
+package property
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResourcePropertyActivationRollback(t *testing.T) {
+	activations := papi.GetActivationsResponse{
+		Activations: papi.ActivationsItems{Items: []*papi.Activation{
+			{
+				ActivationID:    "atv_2",
+				ActivationType:  papi.ActivationTypeActivate,
+				PropertyID:      "prp_test",
+				PropertyVersion: 2,
+				Network:         papi.ActivationNetworkProduction,
+				Status:          papi.ActivationStatusActive,
+				SubmitDate:      "2020-10-28T16:04:05Z",
+			},
+			{
+				ActivationID:    "atv_1",
+				ActivationType:  papi.ActivationTypeActivate,
+				PropertyID:      "prp_test",
+				PropertyVersion: 1,
+				Network:         papi.ActivationNetworkProduction,
+				Status:          papi.ActivationStatusActive,
+				SubmitDate:      "2020-10-28T15:04:05Z",
+			},
+			{
+				ActivationID:    "atv_3",
+				ActivationType:  papi.ActivationTypeActivate,
+				PropertyID:      "prp_test",
+				PropertyVersion: 3,
+				Network:         papi.ActivationNetworkStaging,
+				Status:          papi.ActivationStatusActive,
+				SubmitDate:      "2020-10-28T17:04:05Z",
+			},
+		}},
+	}
+	inWindow := int(time.Now().Add(time.Hour).Unix())
+	expired := int(time.Now().Add(-time.Hour).Unix())
+
+	expectGetActivationWithFallback := func(m *papi.Mock, activationID string, version int, status papi.ActivationStatus, fallbackInfo *papi.ActivationFallbackInfo) *mock.Call {
+		return m.On("GetActivation", mock.Anything, papi.GetActivationRequest{PropertyID: "prp_test", ActivationID: activationID}).
+			Return(&papi.GetActivationResponse{Activation: &papi.Activation{
+				ActivationID:    activationID,
+				ActivationType:  papi.ActivationTypeActivate,
+				PropertyID:      "prp_test",
+				PropertyVersion: version,
+				Network:         papi.ActivationNetworkProduction,
+				Status:          status,
+				FallbackInfo:    fallbackInfo,
+			}}, nil)
+	}
+	expectRollback := func(m *papi.Mock, version int, fastFallback bool) *mock.Call {
+		return m.On("CreateActivation", mock.Anything, papi.CreateActivationRequest{
+			PropertyID: "prp_test",
+			Activation: papi.Activation{
+				ActivationType:         papi.ActivationTypeActivate,
+				Network:                papi.ActivationNetworkProduction,
+				PropertyVersion:        version,
+				NotifyEmails:           []string{"user@example.com"},
+				AcknowledgeAllWarnings: true,
+				Note:                   "roll back",
+				UseFastFallback:        fastFallback,
+			},
+		}).Return(&papi.CreateActivationResponse{ActivationID: "atv_rollback"}, nil)
+	}
+
+	tests := map[string]struct {
+		init  func(*papi.Mock)
+		steps []resource.TestStep
+	}{
+		"fast fallback to the fallback version": {
+			init: func(m *papi.Mock) {
+				m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{PropertyID: "prp_test"}).Return(&activations, nil).Once()
+				expectGetActivationWithFallback(m, "atv_2", 2, papi.ActivationStatusActive, &papi.ActivationFallbackInfo{
+					CanFastFallback:            true,
+					FallbackVersion:            1,
+					SteadyStateTime:            1603897445,
+					FastFallbackExpirationTime: inWindow,
+				}).Once()
+				expectRollback(m, 1, true).Once()
+				expectGetActivationWithFallback(m, "atv_rollback", 1, papi.ActivationStatusActive, nil)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("testdata/TestPropertyActivationRollback/rollback.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_property_activation_rollback.test", "id", "prp_test:PRODUCTION:atv_rollback"),
+						resource.TestCheckResourceAttr("akamai_property_activation_rollback.test", "version", "1"),
+						resource.TestCheckResourceAttr("akamai_property_activation_rollback.test", "rolled_back_version", "2"),
+						resource.TestCheckResourceAttr("akamai_property_activation_rollback.test", "fast_fallback", "true"),
+						resource.TestCheckResourceAttr("akamai_property_activation_rollback.test", "status", "ACTIVE"),
+						resource.TestCheckResourceAttr("akamai_property_activation_rollback.test", "activation_id", "atv_rollback"),
+						resource.TestCheckResourceAttr("akamai_property_activation_rollback.test", "fallback_info.0.fallback_version", "1"),
+						resource.TestCheckResourceAttr("akamai_property_activation_rollback.test", "fallback_info.0.can_fast_fallback", "true"),
+						resource.TestCheckResourceAttr("akamai_property_activation_rollback.test", "fallback_info.0.steady_state_time", "2020-10-28T15:04:05Z"),
+						resource.TestCheckResourceAttr("akamai_property_activation_rollback.test", "fallback_info.0.fast_fallback_expiration_time",
+							time.Unix(int64(inWindow), 0).UTC().Format(time.RFC3339)),
+					),
+				},
+			},
+		},
+		"rollback removed from the state when not found": {
+			init: func(m *papi.Mock) {
+				m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{PropertyID: "prp_test"}).Return(&activations, nil).Once()
+				expectGetActivationWithFallback(m, "atv_2", 2, papi.ActivationStatusActive, &papi.ActivationFallbackInfo{
+					CanFastFallback:            true,
+					FallbackVersion:            1,
+					FastFallbackExpirationTime: inWindow,
+				}).Once()
+				expectRollback(m, 1, true).Once()
+				expectGetActivationWithFallback(m, "atv_rollback", 1, papi.ActivationStatusActive, nil).Once()
+				m.On("GetActivation", mock.Anything, papi.GetActivationRequest{PropertyID: "prp_test", ActivationID: "atv_rollback"}).
+					Return(nil, &papi.Error{StatusCode: http.StatusNotFound, Title: "Not Found"})
+			},
+			steps: []resource.TestStep{
+				{
+					Config:             loadFixtureString("testdata/TestPropertyActivationRollback/rollback.tf"),
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		},
+		"full activation after the fast fallback window": {
+			init: func(m *papi.Mock) {
+				m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{PropertyID: "prp_test"}).Return(&activations, nil).Once()
+				expectGetActivationWithFallback(m, "atv_2", 2, papi.ActivationStatusActive, &papi.ActivationFallbackInfo{
+					CanFastFallback:            true,
+					FallbackVersion:            1,
+					FastFallbackExpirationTime: expired,
+				}).Once()
+				expectRollback(m, 1, false).Once()
+				expectGetActivationWithFallback(m, "atv_rollback", 1, papi.ActivationStatusActive, nil)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("testdata/TestPropertyActivationRollback/version.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_property_activation_rollback.test", "version", "1"),
+						resource.TestCheckResourceAttr("akamai_property_activation_rollback.test", "fast_fallback", "false"),
+					),
+				},
+			},
+		},
+		"fast fallback only after the window": {
+			init: func(m *papi.Mock) {
+				m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{PropertyID: "prp_test"}).Return(&activations, nil).Once()
+				expectGetActivationWithFallback(m, "atv_2", 2, papi.ActivationStatusActive, &papi.ActivationFallbackInfo{
+					CanFastFallback:            true,
+					FallbackVersion:            1,
+					FastFallbackExpirationTime: expired,
+				}).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      loadFixtureString("testdata/TestPropertyActivationRollback/fast_fallback_only.tf"),
+					ExpectError: regexp.MustCompile("version 2 active on PRODUCTION cannot fast fall back to version 1"),
+				},
+			},
+		},
+		"no fallback version": {
+			init: func(m *papi.Mock) {
+				m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{PropertyID: "prp_test"}).Return(&activations, nil).Once()
+				expectGetActivationWithFallback(m, "atv_2", 2, papi.ActivationStatusActive, nil).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      loadFixtureString("testdata/TestPropertyActivationRollback/rollback.tf"),
+					ExpectError: regexp.MustCompile("version is required: version 2 active on PRODUCTION has no fallback version"),
+				},
+			},
+		},
+		"nothing active": {
+			init: func(m *papi.Mock) {
+				m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{PropertyID: "prp_test"}).Return(&papi.GetActivationsResponse{}, nil).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      loadFixtureString("testdata/TestPropertyActivationRollback/rollback.tf"),
+					ExpectError: regexp.MustCompile("property prp_test has no version active on PRODUCTION"),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &papi.Mock{}
+			if test.init != nil {
+				test.init(client)
+			}
+			useClient(client, nil, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps:     test.steps,
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCanFastFallback(t *testing.T) {
+	now := time.Unix(1603900000, 0)
+	tests := map[string]struct {
+		info     *papi.ActivationFallbackInfo
+		version  int
+		expected bool
+	}{
+		"in window": {
+			info:     &papi.ActivationFallbackInfo{CanFastFallback: true, FallbackVersion: 1, FastFallbackExpirationTime: 1603900001},
+			version:  1,
+			expected: true,
+		},
+		"expired": {
+			info:    &papi.ActivationFallbackInfo{CanFastFallback: true, FallbackVersion: 1, FastFallbackExpirationTime: 1603900000},
+			version: 1,
+		},
+		"other version": {
+			info:    &papi.ActivationFallbackInfo{CanFastFallback: true, FallbackVersion: 1, FastFallbackExpirationTime: 1603900001},
+			version: 3,
+		},
+		"cannot fast fallback": {
+			info:    &papi.ActivationFallbackInfo{FallbackVersion: 1, FastFallbackExpirationTime: 1603900001},
+			version: 1,
+		},
+		"no fallback info": {
+			version: 1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, canFastFallback(test.info, test.version, now))
+		})
+	}
+}
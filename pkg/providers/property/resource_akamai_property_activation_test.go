@@ -1,14 +1,21 @@
 package property
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestResourcePAPIPropertyActivation(t *testing.T) {
@@ -95,6 +102,29 @@ func TestResourcePAPIPropertyActivation(t *testing.T) {
 				},
 			},
 		},
+		"property activation with provider defaults - OK": {
+			init: func(m *papi.Mock) {
+				// create
+				expectGetRuleTree(m, "prp_test", 1, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", papi.GetActivationsResponse{}, nil).Once()
+				expectCreateActivation(m, "prp_test", papi.ActivationTypeActivate, 1, "STAGING",
+					[]string{"user@example.com"}, "[terraform] property activation note for creating", "atv_activation1", nil).Once()
+				expectGetActivation(m, "prp_test", "atv_activation1", 1, "STAGING", papi.ActivationStatusActive, nil).Once()
+				// read and delete
+				expectGetActivations(m, "prp_test", activationsResponseDeactivated, nil).Twice()
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("./testdata/TestPropertyActivation/defaults/resource_property_activation.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "id", "prp_test:STAGING"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "contact.#", "1"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "contact.0", "user@example.com"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "note", "[terraform] property activation note for creating"),
+					),
+				},
+			},
+		},
 		"schema with `property` instead of `property_id` - OK": {
 			init: func(m *papi.Mock) {
 				// create
@@ -143,7 +173,7 @@ func TestResourcePAPIPropertyActivation(t *testing.T) {
 			steps: []resource.TestStep{
 				{
 					Config:      loadFixtureString("./testdata/TestPropertyActivation/no_contact/resource_property_activation.tf"),
-					ExpectError: regexp.MustCompile("Missing required argument"),
+					ExpectError: regexp.MustCompile(`"contact" is required: value must be set in the resource or in the provider defaults block`),
 				},
 			},
 		},
@@ -206,6 +236,230 @@ func TestResourcePAPIPropertyActivation(t *testing.T) {
 				},
 			},
 		},
+		"compliance record not required on staging - OK": {
+			init: func(m *papi.Mock) {
+				// create
+				expectGetRuleTree(m, "prp_test", 1, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", activationsResponseActivated, nil).Once()
+				// read and delete
+				expectGetActivations(m, "prp_test", activationsResponseDeactivated, nil).Twice()
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("./testdata/TestPropertyActivation/compliance_record/staging.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "id", "prp_test:STAGING"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "fast_push", "true"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "use_fast_fallback", "false"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "ignore_http_errors", "true"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "compliance_record.#", "0"),
+					),
+				},
+			},
+		},
+		"compliance record required on production": {
+			steps: []resource.TestStep{
+				{
+					Config:      loadFixtureString("./testdata/TestPropertyActivation/compliance_record/required.tf"),
+					ExpectError: regexp.MustCompile("compliance_record is required to activate on the PRODUCTION network"),
+				},
+			},
+		},
+		"compliance record without other noncompliance reason": {
+			steps: []resource.TestStep{
+				{
+					Config:      loadFixtureString("./testdata/TestPropertyActivation/compliance_record/other_without_reason.tf"),
+					ExpectError: regexp.MustCompile("compliance_record: OtherNoncomplianceReason: cannot be blank"),
+				},
+			},
+		},
+		"compliance record not unit tested": {
+			steps: []resource.TestStep{
+				{
+					Config:      loadFixtureString("./testdata/TestPropertyActivation/compliance_record/none_not_unit_tested.tf"),
+					ExpectError: regexp.MustCompile("unit_tested must be true for the NONE noncompliance reason on the PRODUCTION network"),
+				},
+			},
+		},
+		"referenced include not active": {
+			init: func(m *papi.Mock) {
+				// the plan only warns, as the include may be activated by another resource of the apply
+				expectListReferencedIncludes(m, "prp_test", 1, includeInactive, includeActive)
+				expectGetRuleTree(m, "prp_test", 1, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", papi.GetActivationsResponse{}, nil).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      loadFixtureString("./testdata/TestPropertyActivation/ok/resource_property_activation.tf"),
+					ExpectError: regexp.MustCompile(`(?s)referenced includes are not active on the STAGING network: version 1.*of property prp_test references inc_1 \(shared include\)`),
+				},
+			},
+		},
+		"activate referenced includes - OK": {
+			init: func(m *papi.Mock) {
+				// create
+				expectGetRuleTree(m, "prp_test", 1, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", papi.GetActivationsResponse{}, nil).Once()
+				expectListReferencedIncludes(m, "prp_test", 1, includeInactive, includeActive).Once()
+				expectActivateInclude(m, "inc_1", 3, "atv_include", nil).Once()
+				expectGetActivatedInclude(m, "inc_1", "atv_include").Once()
+				expectCreateActivation(m, "prp_test", papi.ActivationTypeActivate, 1, "STAGING",
+					[]string{"user@example.com"}, "property activation note for creating", "atv_activation1", nil).Once()
+				expectGetActivation(m, "prp_test", "atv_activation1", 1, "STAGING", papi.ActivationStatusActive, nil).Once()
+				// read and delete
+				expectGetActivations(m, "prp_test", activationsResponseDeactivated, nil).Twice()
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("./testdata/TestPropertyActivation/includes/activate_includes.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "id", "prp_test:STAGING"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "activate_includes", "true"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "activated_includes.#", "1"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "activated_includes.0", "inc_1@3"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "activation_id", "atv_activation1"),
+					),
+				},
+			},
+		},
+		"activate referenced includes on production without staged version": {
+			init: func(m *papi.Mock) {
+				expectGetRuleTree(m, "prp_test", 1, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", papi.GetActivationsResponse{}, nil).Once()
+				expectListReferencedIncludes(m, "prp_test", 1, includeInactive, includeActive).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      loadFixtureString("./testdata/TestPropertyActivation/includes/activate_includes_production.tf"),
+					ExpectError: regexp.MustCompile(`(?s)references inc_1 \(shared include\), which have no version active on the STAGING network.*Activate them on STAGING first`),
+				},
+			},
+		},
+		"activation of referenced include fails": {
+			init: func(m *papi.Mock) {
+				expectGetRuleTree(m, "prp_test", 1, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", papi.GetActivationsResponse{}, nil).Once()
+				expectListReferencedIncludes(m, "prp_test", 1, includeInactive).Once()
+				expectActivateInclude(m, "inc_1", 3, "", fmt.Errorf("oops")).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      loadFixtureString("./testdata/TestPropertyActivation/includes/activate_includes.tf"),
+					ExpectError: regexp.MustCompile("property include: activation of version 3 of inc_1: oops"),
+				},
+			},
+		},
+		"import property activation - OK": {
+			init: func(m *papi.Mock) {
+				// import
+				m.On("GetLatestVersion", mock.Anything, papi.GetLatestVersionRequest{PropertyID: "prp_test", ActivatedOn: "STAGING"}).
+					Return(&papi.GetPropertyVersionsResponse{Version: papi.PropertyVersionGetItem{PropertyVersion: 1}}, nil).Once()
+				expectGetActivations(m, "prp_test", activationsResponseImported, nil).Once()
+				// read
+				expectGetActivations(m, "prp_test", activationsResponseImported, nil).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config:        loadFixtureString("./testdata/TestPropertyActivation/ok/resource_property_activation.tf"),
+					ImportState:   true,
+					ResourceName:  "akamai_property_activation.test",
+					ImportStateId: "test:staging",
+					ImportStateCheck: func(states []*terraform.InstanceState) error {
+						if len(states) != 1 {
+							return fmt.Errorf("expected one imported state, got %d", len(states))
+						}
+						expected := map[string]string{
+							"id":                             "prp_test:STAGING",
+							"property_id":                    "prp_test",
+							"network":                        "STAGING",
+							"version":                        "1",
+							"activation_id":                  "atv_activation1",
+							"contact.#":                      "1",
+							"note":                           "property activation note for creating",
+							"status":                         "ACTIVE",
+							"auto_acknowledge_rule_warnings": "true",
+						}
+						for key, value := range expected {
+							if states[0].Attributes[key] != value {
+								return fmt.Errorf("%s: expected %q, got %q", key, value, states[0].Attributes[key])
+							}
+						}
+						return nil
+					},
+				},
+			},
+		},
+		"import property activation with version - OK": {
+			init: func(m *papi.Mock) {
+				// import and read
+				expectGetActivations(m, "prp_test", activationsResponseImported, nil).Twice()
+			},
+			steps: []resource.TestStep{
+				{
+					Config:        loadFixtureString("./testdata/TestPropertyActivation/ok/resource_property_activation.tf"),
+					ImportState:   true,
+					ResourceName:  "akamai_property_activation.test",
+					ImportStateId: "prp_test:STAGING:v1",
+					ImportStateCheck: func(states []*terraform.InstanceState) error {
+						if len(states) != 1 || states[0].Attributes["activation_id"] != "atv_activation1" || states[0].Attributes["version"] != "1" {
+							return fmt.Errorf("unexpected imported state: %v", states)
+						}
+						return nil
+					},
+				},
+			},
+		},
+		"import property activation plans no changes": {
+			init: func(m *papi.Mock) {
+				// create
+				expectGetRuleTree(m, "prp_test", 1, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", papi.GetActivationsResponse{}, nil).Once()
+				expectCreateActivation(m, "prp_test", papi.ActivationTypeActivate, 1, "STAGING",
+					[]string{"user@example.com"}, "property activation note for creating", "atv_activation1", nil).Once()
+				expectGetActivation(m, "prp_test", "atv_activation1", 1, "STAGING", papi.ActivationStatusActive, nil).Once()
+				// read, import and read
+				expectGetActivations(m, "prp_test", activationsResponseImported, nil).Times(3)
+				// delete
+				expectGetActivations(m, "prp_test", activationsResponseDeactivated, nil).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("./testdata/TestPropertyActivation/ok/resource_property_activation.tf"),
+				},
+				{
+					Config:            loadFixtureString("./testdata/TestPropertyActivation/ok/resource_property_activation.tf"),
+					ImportState:       true,
+					ResourceName:      "akamai_property_activation.test",
+					ImportStateId:     "prp_test:STAGING:v1",
+					ImportStateVerify: true,
+				},
+			},
+		},
+		"import property activation of inactive version": {
+			init: func(m *papi.Mock) {
+				expectGetActivations(m, "prp_test", activationsResponseImported, nil).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config:        loadFixtureString("./testdata/TestPropertyActivation/ok/resource_property_activation.tf"),
+					ImportState:   true,
+					ResourceName:  "akamai_property_activation.test",
+					ImportStateId: "prp_test:STAGING:v2",
+					ExpectError:   regexp.MustCompile("version 2 of property prp_test is not active on STAGING"),
+				},
+			},
+		},
+		"import property activation with invalid ID": {
+			steps: []resource.TestStep{
+				{
+					Config:        loadFixtureString("./testdata/TestPropertyActivation/ok/resource_property_activation.tf"),
+					ImportState:   true,
+					ResourceName:  "akamai_property_activation.test",
+					ImportStateId: "prp_test",
+					ExpectError:   regexp.MustCompile("expected property_id:network or property_id:network:version"),
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -214,6 +468,7 @@ func TestResourcePAPIPropertyActivation(t *testing.T) {
 			if test.init != nil {
 				test.init(client)
 			}
+			client.On("ListReferencedIncludes", mock.Anything, mock.Anything).Return(&papi.ListReferencedIncludesResponse{}, nil).Maybe()
 			useClient(client, nil, func() {
 				resource.UnitTest(t, resource.TestCase{
 					Providers:  testAccProviders,
@@ -271,6 +526,26 @@ var (
 			SubmitDate:      "2020-10-28T15:04:05Z",
 		}}},
 	}
+	activationsResponseImported = papi.GetActivationsResponse{
+		Activations: papi.ActivationsItems{Items: []*papi.Activation{{
+			AccountID:       "act_1-6JHGX",
+			ActivationID:    "atv_activation1",
+			ActivationType:  "ACTIVATE",
+			GroupID:         "grp_91533",
+			PropertyName:    "test",
+			PropertyID:      "prp_test",
+			PropertyVersion: 1,
+			Network:         "STAGING",
+			Status:          "ACTIVE",
+			SubmitDate:      "2020-10-28T15:04:05Z",
+			NotifyEmails:    []string{"user@example.com"},
+			Note:            "property activation note for creating",
+			// the options of an activation created with the default attributes
+			AcknowledgeAllWarnings: true,
+			FastPush:               true,
+			IgnoreHTTPErrors:       true,
+		}}},
+	}
 	activationsResponseDeactivated = papi.GetActivationsResponse{
 		Activations: papi.ActivationsItems{Items: []*papi.Activation{{
 			AccountID:       "act_1-6JHGX",
@@ -396,4 +671,176 @@ var (
 			},
 		}, nil)
 	}
+	includeInactive = papi.Include{IncludeID: "inc_1", IncludeName: "shared include", LatestVersion: 3}
+	includeActive   = papi.Include{IncludeID: "inc_2", IncludeName: "active include", LatestVersion: 2, StagingVersion: tools.IntPtr(2)}
+
+	expectListReferencedIncludes = func(m *papi.Mock, propertyID string, version int, includes ...papi.Include) *mock.Call {
+		return m.On(
+			"ListReferencedIncludes",
+			mock.Anything,
+			papi.ListReferencedIncludesRequest{PropertyID: propertyID, PropertyVersion: version},
+		).Return(&papi.ListReferencedIncludesResponse{Includes: papi.IncludeItems{Items: includes}}, nil)
+	}
+
+	expectActivateInclude = func(m *papi.Mock, includeID string, version int, activationID string, err error) *mock.Call {
+		call := m.On(
+			"ActivateInclude",
+			mock.Anything,
+			papi.ActivateIncludeRequest{
+				IncludeID:              includeID,
+				Version:                version,
+				Network:                papi.ActivationNetworkStaging,
+				Note:                   "property activation note for creating",
+				NotifyEmails:           []string{"user@example.com"},
+				AcknowledgeAllWarnings: true,
+			},
+		)
+		if err != nil {
+			return call.Return(nil, err)
+		}
+		return call.Return(&papi.ActivationIncludeResponse{ActivationID: activationID}, nil)
+	}
+
+	expectGetActivatedInclude = func(m *papi.Mock, includeID, activationID string) *mock.Call {
+		return m.On(
+			"GetIncludeActivation",
+			mock.Anything,
+			papi.GetIncludeActivationRequest{IncludeID: includeID, ActivationID: activationID},
+		).Return(&papi.GetIncludeActivationResponse{
+			Activation: papi.IncludeActivation{
+				ActivationID: activationID,
+				IncludeID:    includeID,
+				Network:      papi.ActivationNetworkStaging,
+				Status:       papi.ActivationStatusActive,
+			},
+		}, nil)
+	}
 )
+
+func TestCreateActivation(t *testing.T) {
+	activation := papi.Activation{
+		ActivationType:  papi.ActivationTypeActivate,
+		Network:         papi.ActivationNetworkProduction,
+		PropertyVersion: 1,
+		NotifyEmails:    []string{"user@example.com"},
+	}
+	complianceRecord := []interface{}{map[string]interface{}{
+		"noncompliance_reason": papi.NoncomplianceReasonNone,
+		"ticket_id":            "CHG-123",
+		"customer_email":       "user@example.com",
+		"peer_reviewed_by":     "reviewer@example.com",
+		"unit_tested":          true,
+	}}
+
+	tests := map[string]struct {
+		attrs           map[string]interface{}
+		status          int
+		response        string
+		expectedRequest string
+		expectedID      string
+		withError       string
+	}{
+		"compliance record": {
+			attrs:    map[string]interface{}{"compliance_record": complianceRecord},
+			status:   http.StatusCreated,
+			response: `{"activationLink":"/papi/v1/properties/prp_test/activations/atv_1?contractId=ctr_1&groupId=grp_1"}`,
+			expectedRequest: `{"activationType":"ACTIVATE","useFastFallback":false,` +
+				`"acknowledgeAllWarnings":false,"propertyVersion":1,"network":"PRODUCTION","notifyEmails":["user@example.com"],` +
+				`"fastPush":true,"ignoreHttpErrors":true,"complianceRecord":{"customerEmail":"user@example.com",` +
+				`"peerReviewedBy":"reviewer@example.com","unitTested":true,"ticketId":"CHG-123","noncomplianceReason":"NONE"}}`,
+			expectedID: "atv_1",
+		},
+		"fast push disabled": {
+			attrs:    map[string]interface{}{"fast_push": false, "ignore_http_errors": false},
+			status:   http.StatusCreated,
+			response: `{"activationLink":"/papi/v1/properties/prp_test/activations/atv_2"}`,
+			expectedRequest: `{"activationType":"ACTIVATE","useFastFallback":false,` +
+				`"acknowledgeAllWarnings":false,"propertyVersion":1,"network":"PRODUCTION","notifyEmails":["user@example.com"],` +
+				`"fastPush":false,"ignoreHttpErrors":false}`,
+			expectedID: "atv_2",
+		},
+		"API error": {
+			attrs:     map[string]interface{}{"compliance_record": complianceRecord},
+			status:    http.StatusBadRequest,
+			response:  `{"type":"/papi/v1/errors/compliance_record_required","title":"Compliance record required"}`,
+			withError: "Compliance record required",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			exec := &mockExecutor{}
+			body := mock.Anything
+			if test.expectedRequest != "" {
+				body = test.expectedRequest
+			}
+			exec.On("Exec", http.MethodPost, "/papi/v1/properties/prp_test/activations", body).
+				Return(test.status, test.response, nil).Once()
+			d := schema.TestResourceDataRaw(t, akamaiPropertyActivationSchema, test.attrs)
+
+			create, err := createActivation(context.Background(), &papi.Mock{}, exec, d, "prp_test", activation)
+			exec.AssertExpectations(t)
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedID, create.ActivationID)
+		})
+	}
+
+	t.Run("default options", func(t *testing.T) {
+		client := &papi.Mock{}
+		client.On("CreateActivation", mock.Anything, papi.CreateActivationRequest{PropertyID: "prp_test", Activation: activation}).
+			Return(&papi.CreateActivationResponse{ActivationID: "atv_3"}, nil).Once()
+		exec := &mockExecutor{}
+		d := schema.TestResourceDataRaw(t, akamaiPropertyActivationSchema, map[string]interface{}{})
+
+		create, err := createActivation(context.Background(), client, exec, d, "prp_test", activation)
+		require.NoError(t, err)
+		assert.Equal(t, "atv_3", create.ActivationID)
+		client.AssertExpectations(t)
+		exec.AssertExpectations(t)
+	})
+}
+
+func TestIncludeVersionToActivate(t *testing.T) {
+	tests := map[string]struct {
+		include  papi.Include
+		network  papi.ActivationNetwork
+		expected int
+		ok       bool
+	}{
+		"staging takes the latest version": {
+			include:  papi.Include{LatestVersion: 3},
+			network:  papi.ActivationNetworkStaging,
+			expected: 3,
+			ok:       true,
+		},
+		"staging takes the version active on production": {
+			include:  papi.Include{LatestVersion: 3, ProductionVersion: tools.IntPtr(2)},
+			network:  papi.ActivationNetworkStaging,
+			expected: 2,
+			ok:       true,
+		},
+		"production takes the version active on staging": {
+			include:  papi.Include{LatestVersion: 3, StagingVersion: tools.IntPtr(2)},
+			network:  papi.ActivationNetworkProduction,
+			expected: 2,
+			ok:       true,
+		},
+		"production without version active on staging": {
+			include: papi.Include{LatestVersion: 3},
+			network: papi.ActivationNetworkProduction,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, ok := includeVersionToActivate(test.include, test.network)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, version)
+		})
+	}
+}
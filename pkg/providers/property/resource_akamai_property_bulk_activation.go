@@ -0,0 +1,223 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/spf13/cast"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+)
+
+// resourcePropertyBulkActivation activates many property versions with a single bulk activation
+func resourcePropertyBulkActivation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyBulkActivationCreate,
+		ReadContext:   schema.NoopContext,
+		UpdateContext: schema.NoopContext,
+		DeleteContext: resourcePropertyBulkActivationDelete,
+		CustomizeDiff: complianceRecordCustomDiff,
+		Schema: map[string]*schema.Schema{
+			"network": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     papi.ActivationNetworkStaging,
+				Description: "The network to activate on, STAGING or PRODUCTION. default is STAGING",
+			},
+			"property": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "The property versions to activate",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"version": {
+							Type:     schema.TypeInt,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			"contact": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"note": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "assigns a log message to the activation of every version",
+			},
+			"auto_acknowledge_rule_warnings": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "automatically acknowledge all rule warnings for activation to continue. default is true",
+			},
+			"fast_push": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "whether to push the activations to the edge servers as soon as possible. default is true",
+			},
+			"use_fast_fallback": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "whether to use fast fallback to the previous versions",
+			},
+			"compliance_record": complianceRecordSchema(),
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The result of the activation of each property version",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id":   {Type: schema.TypeString, Computed: true},
+						"version":       {Type: schema.TypeInt, Computed: true},
+						"network":       {Type: schema.TypeString, Computed: true},
+						"activation_id": {Type: schema.TypeString, Computed: true},
+						"status":        {Type: schema.TypeString, Computed: true},
+						"failure":       {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+			"failed_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of property versions which could not be activated",
+			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(bulkPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PropertyResourceTimeout,
+		},
+	}
+}
+
+func resourcePropertyBulkActivationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyBulkActivationCreate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	bulk := newBulkClient(inst.Executor(meta), logger, func() (*tools.Poller, error) {
+		return tools.NewPoller("property bulk activation", d, bulkPollInterval, bulkPollMinimum, logger)
+	})
+	network, err := networkAlias(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	note, err := tools.GetStringValue("note", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	notifySet, err := tools.GetSetValue("contact", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var notify []string
+	for _, contact := range notifySet.List() {
+		notify = append(notify, cast.ToString(contact))
+	}
+	sort.Strings(notify)
+
+	properties, err := tools.GetListValue("property", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	versions := make([]bulkVersion, 0, len(properties))
+	ids := make([]string, 0, len(properties))
+	for _, p := range properties {
+		property := p.(map[string]interface{})
+		v := bulkVersion{
+			PropertyID:      tools.AddPrefix(property["property_id"].(string), "prp_"),
+			PropertyVersion: property["version"].(int),
+			Network:         string(network),
+			Note:            note,
+		}
+		versions = append(versions, v)
+		ids = append(ids, v.PropertyID+"@"+strconv.Itoa(v.PropertyVersion))
+	}
+
+	complianceRecord, err := tools.GetListValue("compliance_record", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	// Schema guarantees these types
+	settings := bulkActivationSettings{
+		NotifyEmails:           notify,
+		AcknowledgeAllWarnings: d.Get("auto_acknowledge_rule_warnings").(bool),
+		FastPush:               d.Get("fast_push").(bool),
+		UseFastFallback:        d.Get("use_fast_fallback").(bool),
+	}
+	if len(complianceRecord) > 0 {
+		settings.ComplianceRecord = addComplianceRecord(complianceRecord, papi.ActivateOrDeactivateIncludeRequest{}).ComplianceRecord
+	}
+	activated, err := bulk.activate(ctx, settings, versions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var failed int
+	results := make([]interface{}, 0, len(activated))
+	for _, v := range activated {
+		if v.Status != bulkStatusComplete {
+			failed++
+		}
+		results = append(results, map[string]interface{}{
+			"property_id":   v.PropertyID,
+			"version":       v.PropertyVersion,
+			"network":       v.Network,
+			"activation_id": v.ActivationID,
+			"status":        v.Status,
+			"failure":       v.FatalError,
+		})
+	}
+	if err := tools.SetAttrs(d, map[string]interface{}{"results": results, "failed_count": failed}); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s:%s", network, strings.Join(ids, ",")))
+
+	if failed > 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%d of %d property versions could not be activated", failed, len(activated)),
+			Detail:   "See the status and failure of the results",
+		}}
+	}
+	return nil
+}
+
+// resourcePropertyBulkActivationDelete removes the bulk activation from the state, the versions stay active
+func resourcePropertyBulkActivationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyBulkActivationDelete")
+	logger.Debugf("removing bulk activation %s from the state", d.Id())
+
+	d.SetId("")
+	return nil
+}
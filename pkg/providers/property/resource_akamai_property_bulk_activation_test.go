@@ -0,0 +1,125 @@
+package property
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResPropertyBulkActivation(t *testing.T) {
+	expectedRequests := []string{
+		`POST /papi/v1/bulk/activations {"defaultActivationSettings":{"notifyEmails":["user@example.com"],"acknowledgeAllWarnings":true,"fastPush":true,"useFastFallback":false},` +
+			`"activatePropertyVersions":[{"propertyId":"prp_1","propertyVersion":3,"network":"PRODUCTION","note":"bulk activation"},` +
+			`{"propertyId":"prp_2","propertyVersion":5,"network":"PRODUCTION","note":"bulk activation"}]}`,
+		"GET /papi/v1/bulk/activations/8",
+	}
+
+	completeActivation := map[string]bulkResponse{
+		"POST /papi/v1/bulk/activations": {status: http.StatusAccepted, body: `{"bulkActivationLink": "/papi/v1/bulk/activations/8"}`},
+		"GET /papi/v1/bulk/activations/8": {status: http.StatusOK, body: `{
+  "bulkActivationStatus": "COMPLETE",
+  "activatePropertyVersions": [
+    {"propertyId": "prp_1", "propertyVersion": 3, "network": "PRODUCTION", "status": "COMPLETE", "activationId": "atv_1"},
+    {"propertyId": "prp_2", "propertyVersion": 5, "network": "PRODUCTION", "status": "COMPLETE", "activationId": "atv_2"}
+  ]
+}`},
+	}
+
+	tests := map[string]struct {
+		configPath string
+		responses  map[string]bulkResponse
+		checks     resource.TestCheckFunc
+		withError  *regexp.Regexp
+		requests   []string
+	}{
+		"activate all versions": {
+			responses: completeActivation,
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "id", "PRODUCTION:prp_1@3,prp_2@5"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "failed_count", "0"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "results.#", "2"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "results.0.property_id", "prp_1"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "results.0.version", "3"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "results.0.network", "PRODUCTION"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "results.0.activation_id", "atv_1"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "results.0.status", "COMPLETE"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "results.1.property_id", "prp_2"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "results.1.activation_id", "atv_2"),
+			),
+			requests: expectedRequests,
+		},
+		"failed activation is reported": {
+			responses: map[string]bulkResponse{
+				"POST /papi/v1/bulk/activations": {status: http.StatusAccepted, body: `{"bulkActivationLink": "/papi/v1/bulk/activations/8"}`},
+				"GET /papi/v1/bulk/activations/8": {status: http.StatusOK, body: `{
+  "bulkActivationStatus": "COMPLETE",
+  "activatePropertyVersions": [
+    {"propertyId": "prp_1", "propertyVersion": 3, "network": "PRODUCTION", "status": "COMPLETE", "activationId": "atv_1"},
+    {"propertyId": "prp_2", "propertyVersion": 5, "network": "PRODUCTION", "status": "SUBMISSION_ERROR", "fatalError": "The version has validation errors"}
+  ]
+}`},
+			},
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "failed_count", "1"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "results.0.status", "COMPLETE"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "results.1.status", "SUBMISSION_ERROR"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "results.1.failure", "The version has validation errors"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "results.1.activation_id", ""),
+			),
+			requests: expectedRequests,
+		},
+		"activation status not readable": {
+			responses: map[string]bulkResponse{
+				"POST /papi/v1/bulk/activations":  {status: http.StatusAccepted, body: `{"bulkActivationLink": "/papi/v1/bulk/activations/8"}`},
+				"GET /papi/v1/bulk/activations/8": {status: http.StatusInternalServerError, body: `{"title": "Internal Server Error", "status": 500}`},
+			},
+			withError: regexp.MustCompile("(?s)bulk operation: activation: .*Internal Server Error"),
+			requests:  expectedRequests,
+		},
+		"compliance record is sent": {
+			configPath: "testdata/TestResPropertyBulkActivation/compliance_record.tf",
+			responses:  completeActivation,
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "failed_count", "0"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_activation.test", "compliance_record.0.noncompliance_reason", "NO_PRODUCTION_TRAFFIC"),
+			),
+			requests: []string{
+				`POST /papi/v1/bulk/activations {"defaultActivationSettings":{"notifyEmails":["user@example.com"],"acknowledgeAllWarnings":true,"fastPush":true,"useFastFallback":false,` +
+					`"complianceRecord":{"ticketId":"JIRA-1","noncomplianceReason":"NO_PRODUCTION_TRAFFIC"}},` +
+					`"activatePropertyVersions":[{"propertyId":"prp_1","propertyVersion":3,"network":"PRODUCTION","note":"bulk activation"},` +
+					`{"propertyId":"prp_2","propertyVersion":5,"network":"PRODUCTION","note":"bulk activation"}]}`,
+				"GET /papi/v1/bulk/activations/8",
+			},
+		},
+		"compliance record required on production": {
+			configPath: "testdata/TestResPropertyBulkActivation/compliance_record_required.tf",
+			withError:  regexp.MustCompile("compliance_record is required to activate on the PRODUCTION network"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			configPath := test.configPath
+			if configPath == "" {
+				configPath = "testdata/TestResPropertyBulkActivation/activation.tf"
+			}
+			sess := &bulkSession{responses: test.responses}
+			useExecutor(&papi.Mock{}, sess, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers:  testAccProviders,
+					IsUnitTest: true,
+					Steps: []resource.TestStep{{
+						Config:      loadFixtureString(configPath),
+						Check:       test.checks,
+						ExpectError: test.withError,
+					}},
+				})
+			})
+			assert.Equal(t, test.requests, sess.requests)
+		})
+	}
+}
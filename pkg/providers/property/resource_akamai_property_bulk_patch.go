@@ -0,0 +1,306 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+)
+
+// resourcePropertyBulkPatch creates a new version of every property matching the bulk search and patches its rule tree
+func resourcePropertyBulkPatch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyBulkPatchCreate,
+		ReadContext:   schema.NoopContext,
+		UpdateContext: schema.NoopContext,
+		DeleteContext: resourcePropertyBulkPatchDelete,
+		Schema: map[string]*schema.Schema{
+			"contract_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Limits the patch to the properties of the contract",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Limits the patch to the properties of the group",
+			},
+			"match": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "JSONPath expression selecting the parts of the rule trees to patch, e.g. $..behaviors[?(@.name == 'origin')]",
+				ValidateDiagFunc: tools.IsNotBlank,
+			},
+			"patch": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "JSON Patch operations applied to every match, in order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"op": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ForceNew:         true,
+							Description:      "The operation, one of add, remove, replace, move, copy or test",
+							ValidateDiagFunc: tools.ValidateStringInSlice([]string{"add", "remove", "replace", "move", "copy", "test"}),
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "JSON pointer relative to the match, e.g. /options/hostname. Empty for the match itself",
+						},
+						"from": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "JSON pointer relative to the match of the value to move or copy",
+						},
+						"value": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							Description:      "JSON encoded value of the add, replace and test operations",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+						},
+					},
+				},
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The result of the patch of each matching property",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id":   {Type: schema.TypeString, Computed: true},
+						"property_name": {Type: schema.TypeString, Computed: true},
+						"from_version":  {Type: schema.TypeInt, Computed: true},
+						"version":       {Type: schema.TypeInt, Computed: true},
+						"match_locations": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"status":  {Type: schema.TypeString, Computed: true},
+						"failure": {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+			"failed_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of properties which could not be versioned or patched",
+			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(bulkPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PropertyResourceTimeout,
+		},
+	}
+}
+
+// bulkStatusNotLatest is the status of the properties whose latest version does not match, which are not patched
+const bulkStatusNotLatest = "NOT_LATEST"
+
+// bulkPatchResult is the outcome of the patch of one property
+type bulkPatchResult struct {
+	bulkSearchResult
+	version int
+	status  string
+	failure string
+}
+
+func resourcePropertyBulkPatchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyBulkPatchCreate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	client := inst.Client(meta)
+
+	bulk := newBulkClient(inst.Executor(meta), logger, func() (*tools.Poller, error) {
+		return tools.NewPoller("property bulk patch", d, bulkPollInterval, bulkPollMinimum, logger)
+	})
+	contractID, groupID, match, err := getBulkSearchQuery(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	patches, err := getBulkPatchOperations(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	matches, err := bulk.search(ctx, contractID, groupID, match)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debugf("patching %d properties", len(matches))
+	results := make([]*bulkPatchResult, 0, len(matches))
+	latest := make([]*bulkPatchResult, 0, len(matches))
+	for _, found := range matches {
+		r := &bulkPatchResult{bulkSearchResult: found}
+		// a version created from an older matching version would drop the changes of the latest one
+		if !found.IsLatest {
+			r.status = bulkStatusNotLatest
+			r.failure = fmt.Sprintf("version %d matches, but the latest version of the property does not", found.PropertyVersion)
+		} else {
+			latest = append(latest, r)
+		}
+		results = append(results, r)
+	}
+
+	if len(latest) > 0 {
+		if err := bulkPatchProperties(ctx, client, bulk, latest, patches); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	var failed int
+	flattened := make([]interface{}, 0, len(results))
+	for _, r := range results {
+		if r.status != bulkStatusComplete {
+			failed++
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"property_id":     r.PropertyID,
+			"property_name":   r.PropertyName,
+			"from_version":    r.PropertyVersion,
+			"version":         r.version,
+			"match_locations": r.MatchLocations,
+			"status":          r.status,
+			"failure":         r.failure,
+		})
+	}
+	if err := tools.SetAttrs(d, map[string]interface{}{"results": flattened, "failed_count": failed}); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s:%s:%s", contractID, groupID, match))
+
+	if failed > 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%d of %d properties could not be patched", failed, len(results)),
+			Detail:   "See the status and failure of the results",
+		}}
+	}
+	return nil
+}
+
+// bulkPatchProperties creates the new versions of the matching properties and patches them, recording the outcome in the results
+func bulkPatchProperties(ctx context.Context, client papi.PAPI, bulk *bulkClient, results []*bulkPatchResult, patches []bulkPatchOperation) error {
+	byProperty := make(map[string]*bulkPatchResult, len(results))
+	versions := make([]bulkVersion, 0, len(results))
+	for _, r := range results {
+		byProperty[r.PropertyID] = r
+		versions = append(versions, bulkVersion{PropertyID: r.PropertyID, CreateFromVersion: r.PropertyVersion})
+	}
+	created, err := bulk.createVersions(ctx, versions)
+	if err != nil {
+		return err
+	}
+
+	toPatch := make([]bulkVersion, 0, len(created))
+	for _, v := range created {
+		r, ok := byProperty[v.PropertyID]
+		if !ok {
+			continue
+		}
+		r.status, r.failure, r.version = v.Status, v.FatalError, v.PropertyVersion
+		if v.Status != bulkStatusComplete {
+			continue
+		}
+		rules, err := client.GetRuleTree(ctx, papi.GetRuleTreeRequest{
+			PropertyID:      v.PropertyID,
+			PropertyVersion: v.PropertyVersion,
+		})
+		if err != nil {
+			r.status, r.failure = "FAILED", err.Error()
+			continue
+		}
+		toPatch = append(toPatch, bulkVersion{
+			PropertyID:      v.PropertyID,
+			PropertyVersion: v.PropertyVersion,
+			Etag:            rules.Etag,
+			Patches:         patchesAtLocations(patches, r.MatchLocations),
+		})
+	}
+	if len(toPatch) == 0 {
+		return nil
+	}
+
+	patched, err := bulk.patch(ctx, toPatch)
+	if err != nil {
+		return err
+	}
+	for _, v := range patched {
+		if r, ok := byProperty[v.PropertyID]; ok {
+			r.status, r.failure = v.Status, v.FatalError
+		}
+	}
+	return nil
+}
+
+// patchesAtLocations applies the operations relative to the matches to every match location
+func patchesAtLocations(patches []bulkPatchOperation, locations []string) []bulkPatchOperation {
+	located := make([]bulkPatchOperation, 0, len(patches)*len(locations))
+	for _, location := range locations {
+		location = strings.TrimSuffix(location, "/")
+		for _, p := range patches {
+			p.Path = location + p.Path
+			if p.From != "" {
+				p.From = location + p.From
+			}
+			located = append(located, p)
+		}
+	}
+	return located
+}
+
+func getBulkPatchOperations(d *schema.ResourceData) ([]bulkPatchOperation, error) {
+	patches, err := tools.GetListValue("patch", d)
+	if err != nil {
+		return nil, err
+	}
+	operations := make([]bulkPatchOperation, 0, len(patches))
+	for i, p := range patches {
+		patch := p.(map[string]interface{})
+		op := bulkPatchOperation{
+			Op:   patch["op"].(string),
+			Path: patch["path"].(string),
+			From: patch["from"].(string),
+		}
+		if value := patch["value"].(string); value != "" {
+			op.Value = json.RawMessage(value)
+		} else if op.Op == "add" || op.Op == "replace" || op.Op == "test" {
+			return nil, fmt.Errorf("patch.%d: value is required for the %s operation", i, op.Op)
+		}
+		if (op.Op == "move" || op.Op == "copy") && op.From == "" {
+			return nil, fmt.Errorf("patch.%d: from is required for the %s operation", i, op.Op)
+		}
+		operations = append(operations, op)
+	}
+	return operations, nil
+}
+
+// resourcePropertyBulkPatchDelete removes the patch from the state, the patched versions are kept
+func resourcePropertyBulkPatchDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyBulkPatchDelete")
+	logger.Debugf("removing bulk patch %s from the state", d.Id())
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,236 @@
+package property
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResPropertyBulkPatch(t *testing.T) {
+	searchResponses := map[string]bulkResponse{
+		"POST /papi/v1/bulk/rules-search-requests": {status: http.StatusAccepted, body: `{"bulkSearchLink": "/papi/v1/bulk/rules-search-requests/5"}`},
+		"GET /papi/v1/bulk/rules-search-requests/5": {status: http.StatusOK, body: `{
+  "bulkSearchId": 5,
+  "searchTargetStatus": "COMPLETE",
+  "results": [
+    {"propertyId": "prp_1", "propertyName": "first", "propertyVersion": 3, "isLatest": true, "matchLocations": ["/rules/behaviors/0"]},
+    {"propertyId": "prp_2", "propertyName": "second", "propertyVersion": 1, "isLatest": true, "matchLocations": ["/rules/behaviors/1", "/rules/children/0/behaviors/0"]}
+  ]
+}`},
+	}
+	versionResponses := map[string]bulkResponse{
+		"POST /papi/v1/bulk/property-version-creations": {status: http.StatusAccepted, body: `{"bulkCreateVersionLink": "/papi/v1/bulk/property-version-creations/6"}`},
+		"GET /papi/v1/bulk/property-version-creations/6": {status: http.StatusOK, body: `{
+  "bulkCreateVersionsStatus": "COMPLETE",
+  "createPropertyVersions": [
+    {"propertyId": "prp_1", "createFromVersion": 3, "propertyVersion": 4, "status": "COMPLETE"},
+    {"propertyId": "prp_2", "createFromVersion": 1, "propertyVersion": 2, "status": "COMPLETE"}
+  ]
+}`},
+	}
+	withResponses := func(responses ...map[string]bulkResponse) map[string]bulkResponse {
+		all := make(map[string]bulkResponse)
+		for _, r := range responses {
+			for k, v := range r {
+				all[k] = v
+			}
+		}
+		return all
+	}
+	expectedRequests := []string{
+		`POST /papi/v1/bulk/rules-search-requests?contractId=ctr_1&groupId=grp_2 {"bulkSearchQuery":{"match":"$..behaviors[?(@.name == 'origin')]","syntax":"JSONPATH"}}`,
+		"GET /papi/v1/bulk/rules-search-requests/5",
+		`POST /papi/v1/bulk/property-version-creations {"createPropertyVersions":[{"propertyId":"prp_1","createFromVersion":3},{"propertyId":"prp_2","createFromVersion":1}]}`,
+		"GET /papi/v1/bulk/property-version-creations/6",
+		`POST /papi/v1/bulk/rules-patch-requests {"patchPropertyVersions":[` +
+			`{"propertyId":"prp_1","propertyVersion":4,"etag":"etag1","patches":[{"op":"replace","path":"/rules/behaviors/0/options/hostname","value":"origin.example.com"}]},` +
+			`{"propertyId":"prp_2","propertyVersion":2,"etag":"etag2","patches":[{"op":"replace","path":"/rules/behaviors/1/options/hostname","value":"origin.example.com"},` +
+			`{"op":"replace","path":"/rules/children/0/behaviors/0/options/hostname","value":"origin.example.com"}]}]}`,
+		"GET /papi/v1/bulk/rules-patch-requests/7",
+	}
+
+	tests := map[string]struct {
+		config           string
+		responses        map[string]bulkResponse
+		ruleTrees        []int
+		expectedRequests []string
+		checks           resource.TestCheckFunc
+		withError        *regexp.Regexp
+	}{
+		"patch all matches": {
+			config: "patch.tf",
+			responses: withResponses(searchResponses, versionResponses, map[string]bulkResponse{
+				"POST /papi/v1/bulk/rules-patch-requests": {status: http.StatusAccepted, body: `{"bulkPatchLink": "/papi/v1/bulk/rules-patch-requests/7"}`},
+				"GET /papi/v1/bulk/rules-patch-requests/7": {status: http.StatusOK, body: `{
+  "bulkPatchStatus": "COMPLETE",
+  "patchPropertyVersions": [
+    {"propertyId": "prp_1", "propertyVersion": 4, "status": "COMPLETE"},
+    {"propertyId": "prp_2", "propertyVersion": 2, "status": "COMPLETE"}
+  ]
+}`},
+			}),
+			ruleTrees:        []int{1, 2},
+			expectedRequests: expectedRequests,
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "id", "ctr_1:grp_2:$..behaviors[?(@.name == 'origin')]"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "failed_count", "0"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.#", "2"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.0.property_id", "prp_1"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.0.property_name", "first"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.0.from_version", "3"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.0.version", "4"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.0.status", "COMPLETE"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.property_id", "prp_2"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.version", "2"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.match_locations.#", "2"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.status", "COMPLETE"),
+			),
+		},
+		"failed patch is reported": {
+			config: "patch.tf",
+			responses: withResponses(searchResponses, versionResponses, map[string]bulkResponse{
+				"POST /papi/v1/bulk/rules-patch-requests": {status: http.StatusAccepted, body: `{"bulkPatchLink": "/papi/v1/bulk/rules-patch-requests/7"}`},
+				"GET /papi/v1/bulk/rules-patch-requests/7": {status: http.StatusOK, body: `{
+  "bulkPatchStatus": "COMPLETE",
+  "patchPropertyVersions": [
+    {"propertyId": "prp_1", "propertyVersion": 4, "status": "COMPLETE"},
+    {"propertyId": "prp_2", "propertyVersion": 2, "status": "SUBMISSION_ERROR", "fatalError": "The etag does not match"}
+  ]
+}`},
+			}),
+			ruleTrees:        []int{1, 2},
+			expectedRequests: expectedRequests,
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "failed_count", "1"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.0.status", "COMPLETE"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.status", "SUBMISSION_ERROR"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.failure", "The etag does not match"),
+			),
+		},
+		"failed version is not patched": {
+			config: "patch.tf",
+			responses: withResponses(searchResponses, versionResponses, map[string]bulkResponse{
+				"GET /papi/v1/bulk/property-version-creations/6": {status: http.StatusOK, body: `{
+  "bulkCreateVersionsStatus": "COMPLETE",
+  "createPropertyVersions": [
+    {"propertyId": "prp_1", "createFromVersion": 3, "propertyVersion": 4, "status": "COMPLETE"},
+    {"propertyId": "prp_2", "createFromVersion": 1, "status": "SUBMISSION_ERROR", "fatalError": "The property is locked"}
+  ]
+}`},
+				"POST /papi/v1/bulk/rules-patch-requests": {status: http.StatusAccepted, body: `{"bulkPatchLink": "/papi/v1/bulk/rules-patch-requests/7"}`},
+				"GET /papi/v1/bulk/rules-patch-requests/7": {status: http.StatusOK, body: `{
+  "bulkPatchStatus": "COMPLETE",
+  "patchPropertyVersions": [{"propertyId": "prp_1", "propertyVersion": 4, "status": "COMPLETE"}]
+}`},
+			}),
+			expectedRequests: []string{
+				expectedRequests[0], expectedRequests[1], expectedRequests[2], expectedRequests[3],
+				`POST /papi/v1/bulk/rules-patch-requests {"patchPropertyVersions":[` +
+					`{"propertyId":"prp_1","propertyVersion":4,"etag":"etag1","patches":[{"op":"replace","path":"/rules/behaviors/0/options/hostname","value":"origin.example.com"}]}]}`,
+				expectedRequests[5],
+			},
+			ruleTrees: []int{1},
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "failed_count", "1"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.0.status", "COMPLETE"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.status", "SUBMISSION_ERROR"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.version", "0"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.failure", "The property is locked"),
+			),
+		},
+		"match of an older version is not patched": {
+			config: "patch.tf",
+			responses: withResponses(searchResponses, versionResponses, map[string]bulkResponse{
+				"GET /papi/v1/bulk/rules-search-requests/5": {status: http.StatusOK, body: `{
+  "bulkSearchId": 5,
+  "searchTargetStatus": "COMPLETE",
+  "results": [
+    {"propertyId": "prp_1", "propertyName": "first", "propertyVersion": 3, "isLatest": true, "matchLocations": ["/rules/behaviors/0"]},
+    {"propertyId": "prp_2", "propertyName": "second", "propertyVersion": 1, "isLatest": false, "productionStatus": "ACTIVE", "matchLocations": ["/rules/behaviors/1"]}
+  ]
+}`},
+				"GET /papi/v1/bulk/property-version-creations/6": {status: http.StatusOK, body: `{
+  "bulkCreateVersionsStatus": "COMPLETE",
+  "createPropertyVersions": [{"propertyId": "prp_1", "createFromVersion": 3, "propertyVersion": 4, "status": "COMPLETE"}]
+}`},
+				"POST /papi/v1/bulk/rules-patch-requests": {status: http.StatusAccepted, body: `{"bulkPatchLink": "/papi/v1/bulk/rules-patch-requests/7"}`},
+				"GET /papi/v1/bulk/rules-patch-requests/7": {status: http.StatusOK, body: `{
+  "bulkPatchStatus": "COMPLETE",
+  "patchPropertyVersions": [{"propertyId": "prp_1", "propertyVersion": 4, "status": "COMPLETE"}]
+}`},
+			}),
+			expectedRequests: []string{
+				expectedRequests[0], expectedRequests[1],
+				`POST /papi/v1/bulk/property-version-creations {"createPropertyVersions":[{"propertyId":"prp_1","createFromVersion":3}]}`,
+				expectedRequests[3],
+				`POST /papi/v1/bulk/rules-patch-requests {"patchPropertyVersions":[` +
+					`{"propertyId":"prp_1","propertyVersion":4,"etag":"etag1","patches":[{"op":"replace","path":"/rules/behaviors/0/options/hostname","value":"origin.example.com"}]}]}`,
+				expectedRequests[5],
+			},
+			ruleTrees: []int{1},
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "failed_count", "1"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.0.status", "COMPLETE"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.status", "NOT_LATEST"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.from_version", "1"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.version", "0"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.failure", "version 1 matches, but the latest version of the property does not"),
+			),
+		},
+		"no matches": {
+			config: "patch.tf",
+			responses: map[string]bulkResponse{
+				"POST /papi/v1/bulk/rules-search-requests":  {status: http.StatusAccepted, body: `{"bulkSearchLink": "/papi/v1/bulk/rules-search-requests/5"}`},
+				"GET /papi/v1/bulk/rules-search-requests/5": {status: http.StatusOK, body: `{"bulkSearchId": 5, "searchTargetStatus": "COMPLETE", "results": []}`},
+			},
+			expectedRequests: expectedRequests[:2],
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "failed_count", "0"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.#", "0"),
+			),
+		},
+		"version creation rejected": {
+			config: "patch.tf",
+			responses: withResponses(searchResponses, map[string]bulkResponse{
+				"POST /papi/v1/bulk/property-version-creations": {status: http.StatusForbidden, body: `{"title": "Forbidden", "status": 403}`},
+			}),
+			expectedRequests: expectedRequests[:3],
+			withError:        regexp.MustCompile("(?s)bulk operation: versioning: .*Forbidden"),
+		},
+		"missing value": {
+			config:    "missing_value.tf",
+			withError: regexp.MustCompile("patch.0: value is required for the replace operation"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &papi.Mock{}
+			for _, n := range test.ruleTrees {
+				version := map[int]int{1: 4, 2: 2}[n]
+				client.On("GetRuleTree", mock.Anything, papi.GetRuleTreeRequest{PropertyID: fmt.Sprintf("prp_%d", n), PropertyVersion: version}).
+					Return(&papi.GetRuleTreeResponse{PropertyID: fmt.Sprintf("prp_%d", n), PropertyVersion: version, Etag: fmt.Sprintf("etag%d", n)}, nil).Once()
+			}
+			sess := &bulkSession{responses: test.responses}
+			useExecutor(client, sess, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers:  testAccProviders,
+					IsUnitTest: true,
+					Steps: []resource.TestStep{{
+						Config:      loadFixtureString("testdata/TestResPropertyBulkPatch/%s", test.config),
+						Check:       test.checks,
+						ExpectError: test.withError,
+					}},
+				})
+			})
+			client.AssertExpectations(t)
+			assert.Equal(t, test.expectedRequests, sess.requests)
+		})
+	}
+}
@@ -0,0 +1,453 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/spf13/cast"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+)
+
+// resourcePropertyHostnameBucket manages hostnames of a property using hostname buckets, which are activated
+// per network without new property versions
+func resourcePropertyHostnameBucket() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyHostnameBucketCreate,
+		ReadContext:   resourcePropertyHostnameBucketRead,
+		UpdateContext: resourcePropertyHostnameBucketUpdate,
+		DeleteContext: resourcePropertyHostnameBucketDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePropertyHostnameBucketImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("prp_"),
+				Description: "The property using hostname buckets",
+			},
+			"contract_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("ctr_"),
+				Description: "The contract of the property",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("grp_"),
+				Description: "The group of the property",
+			},
+			"network": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  papi.ActivationNetworkStaging,
+				ValidateDiagFunc: tools.ValidateStringInSlice([]string{
+					string(papi.ActivationNetworkStaging), string(papi.ActivationNetworkProduction),
+				}),
+				Description: "The network the hostnames are activated on, STAGING or PRODUCTION. default is STAGING",
+			},
+			"hostnames": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The hostnames of the bucket managed by the resource. Other hostnames of the bucket are left untouched",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cname_from": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: tools.IsNotBlank,
+							Description:      "The hostname the end users request",
+						},
+						"cname_to": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: tools.IsNotBlank,
+							Description:      "The edge hostname the hostname points to",
+						},
+						"cert_provisioning_type": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "CPS_MANAGED",
+							ValidateDiagFunc: tools.ValidateStringInSlice([]string{"CPS_MANAGED", "DEFAULT"}),
+							Description:      "The certificate provisioning type of the hostname, CPS_MANAGED or DEFAULT. default is CPS_MANAGED",
+						},
+					},
+				},
+			},
+			"notify_emails": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The email addresses to notify about the hostname activations",
+			},
+			"note": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The note of the hostname activations",
+			},
+			"activation_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the last hostname activation",
+			},
+			"activation_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the last hostname activation",
+			},
+			"hostname_status": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The activation status of each hostname, ACTIVE once it serves on the network, otherwise the status of the hostname activation adding it",
+			},
+			tools.PollIntervalKey: tools.PollIntervalSchema(hostnameBucketPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PropertyResourceTimeout,
+		},
+	}
+}
+
+func resourcePropertyHostnameBucketCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyHostnameBucketCreate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	bucket, network, err := hostnameBucketFromData(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hostnames, err := tools.GetSetValue("hostnames", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Debugf("Adding %d hostnames to %s on %s", hostnames.Len(), bucket.propertyID, network)
+	if err := applyHostnameBucketPatch(ctx, d, logger, bucket, network, hostnameChanges(hostnames.List()), nil); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePropertyHostnameBucketRead(ctx, d, m)
+}
+
+func resourcePropertyHostnameBucketRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyHostnameBucketRead")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	bucket, network, err := hostnameBucketFromData(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hostnames, err := bucket.hostnames(ctx)
+	if err != nil {
+		if akamai.RemoveIfNotFound(d, err, logger) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// the hostnames of the last activation are still managed while the activation is in progress,
+	// the ones of a failed activation are dropped so that they are added again
+	var activation *hostnameActivation
+	if activationID, ok := d.GetOk("activation_id"); ok {
+		if activation, err = bucket.activation(ctx, activationID.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	pending := make(map[string]bucketHostnameChange)
+	if activation != nil && activation.isPending() {
+		for _, h := range activation.Hostnames {
+			if h.Action == "ADD" {
+				pending[h.CnameFrom] = h
+			}
+		}
+	}
+
+	// only the hostnames managed by the resource are read, even when none of them is left,
+	// so that the other hostnames of the bucket are never removed by the resource
+	managed := make(map[string]struct{})
+	if set, err := tools.GetSetValue("hostnames", d); err == nil {
+		for _, h := range set.List() {
+			managed[h.(map[string]interface{})["cname_from"].(string)] = struct{}{}
+		}
+	}
+
+	attrs := make([]interface{}, 0, len(managed))
+	statuses := make(map[string]interface{})
+	for _, h := range hostnames {
+		if _, ok := managed[h.CnameFrom]; !ok {
+			continue
+		}
+		if cnameTo, certType, ok := h.onNetwork(network); ok {
+			attrs = append(attrs, bucketHostnameAttrs(h.CnameFrom, cnameTo, certType))
+			statuses[h.CnameFrom] = hostnameActivationStatusActive
+			delete(pending, h.CnameFrom)
+		}
+	}
+	for _, h := range pending {
+		if _, ok := managed[h.CnameFrom]; !ok {
+			continue
+		}
+		attrs = append(attrs, bucketHostnameAttrs(h.CnameFrom, h.CnameTo, h.CertProvisioningType))
+		statuses[h.CnameFrom] = activation.Status
+	}
+
+	values := map[string]interface{}{
+		"hostnames":       attrs,
+		"hostname_status": statuses,
+	}
+	if activation != nil {
+		values["activation_status"] = activation.Status
+	}
+	if err := tools.SetAttrs(d, values); err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debugf("Read %d hostnames of %s", len(attrs), d.Id())
+	return nil
+}
+
+func resourcePropertyHostnameBucketUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyHostnameBucketUpdate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	if !d.HasChange("hostnames") {
+		return resourcePropertyHostnameBucketRead(ctx, d, m)
+	}
+	bucket, network, err := hostnameBucketFromData(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// changed hostnames are added again with their new edge hostname, removed ones are not configured anymore
+	o, n := d.GetChange("hostnames")
+	oldSet, newSet := o.(*schema.Set), n.(*schema.Set)
+	add := hostnameChanges(newSet.Difference(oldSet).List())
+	configured := make(map[string]struct{})
+	for _, h := range newSet.List() {
+		configured[h.(map[string]interface{})["cname_from"].(string)] = struct{}{}
+	}
+	var remove []string
+	for _, h := range oldSet.List() {
+		cnameFrom := h.(map[string]interface{})["cname_from"].(string)
+		if _, ok := configured[cnameFrom]; !ok {
+			remove = append(remove, cnameFrom)
+		}
+	}
+	sort.Strings(remove)
+
+	logger.Debugf("Adding %d and removing %d hostnames of %s", len(add), len(remove), d.Id())
+	if err := applyHostnameBucketPatch(ctx, d, logger, bucket, network, add, remove); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePropertyHostnameBucketRead(ctx, d, m)
+}
+
+func resourcePropertyHostnameBucketDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyHostnameBucketDelete")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	bucket, network, err := hostnameBucketFromData(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hostnames, err := tools.GetSetValue("hostnames", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	// no hostnames are left in the state when the activation adding them failed
+	if hostnames.Len() == 0 {
+		logger.Debugf("No hostnames of %s to remove", d.Id())
+		d.SetId("")
+		return nil
+	}
+	remove := make([]string, 0, hostnames.Len())
+	for _, h := range hostnames.List() {
+		remove = append(remove, h.(map[string]interface{})["cname_from"].(string))
+	}
+	sort.Strings(remove)
+
+	logger.Debugf("Removing %d hostnames of %s", len(remove), d.Id())
+	if err := applyHostnameBucketPatch(ctx, d, logger, bucket, network, nil, remove); err != nil {
+		if !akamai.IsAPINotFound(err) {
+			return diag.FromErr(err)
+		}
+		logger.Warnf("Property %s not found, its hostnames are already removed", bucket.propertyID)
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourcePropertyHostnameBucketImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyHostnameBucketImport")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	logger.Debugf("Importing hostname bucket %s", d.Id())
+
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) < 3 || len(parts) > 4 {
+		return nil, fmt.Errorf("%w: import ID has to be property_id:contract_id:group_id[:network]", ErrHostnameBucket)
+	}
+	network := string(papi.ActivationNetworkStaging)
+	if len(parts) == 4 {
+		alias, err := NetworkAlias(parts[3])
+		if err != nil {
+			return nil, err
+		}
+		network = alias
+	}
+	propertyID := tools.AddPrefix(parts[0], "prp_")
+	if err := tools.SetAttrs(d, map[string]interface{}{
+		"property_id": propertyID,
+		"contract_id": tools.AddPrefix(parts[1], "ctr_"),
+		"group_id":    tools.AddPrefix(parts[2], "grp_"),
+		"network":     network,
+	}); err != nil {
+		return nil, err
+	}
+
+	// all hostnames of the network are managed by the imported resource
+	bucket, _, err := hostnameBucketFromData(d, meta)
+	if err != nil {
+		return nil, err
+	}
+	hostnames, err := bucket.hostnames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	attrs := make([]interface{}, 0, len(hostnames))
+	for _, h := range hostnames {
+		if cnameTo, certType, ok := h.onNetwork(network); ok {
+			attrs = append(attrs, bucketHostnameAttrs(h.CnameFrom, cnameTo, certType))
+		}
+	}
+	if err := d.Set("hostnames", attrs); err != nil {
+		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err)
+	}
+	d.SetId(fmt.Sprintf("%s:%s", propertyID, network))
+	return []*schema.ResourceData{d}, nil
+}
+
+// hostnameBucketFromData returns the bucket client of the configured property and the network
+func hostnameBucketFromData(d *schema.ResourceData, meta akamai.OperationMeta) (*bucketClient, string, error) {
+	propertyID, err := tools.GetStringValue("property_id", d)
+	if err != nil {
+		return nil, "", err
+	}
+	contractID, err := tools.GetStringValue("contract_id", d)
+	if err != nil {
+		return nil, "", err
+	}
+	groupID, err := tools.GetStringValue("group_id", d)
+	if err != nil {
+		return nil, "", err
+	}
+	network, err := tools.GetStringValue("network", d)
+	if err != nil {
+		return nil, "", err
+	}
+	bucket := newBucketClient(inst.Executor(meta), tools.AddPrefix(propertyID, "prp_"), tools.AddPrefix(contractID, "ctr_"), tools.AddPrefix(groupID, "grp_"))
+	return bucket, network, nil
+}
+
+// applyHostnameBucketPatch adds and removes the hostnames and waits until the hostname activation is active
+func applyHostnameBucketPatch(ctx context.Context, d *schema.ResourceData, logger log.Interface, bucket *bucketClient, network string, add []bucketHostnameChange, remove []string) error {
+	note, err := tools.GetStringValue("note", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return err
+	}
+	var notify []string
+	if emails, err := tools.GetSetValue("notify_emails", d); err == nil {
+		for _, email := range emails.List() {
+			notify = append(notify, cast.ToString(email))
+		}
+		sort.Strings(notify)
+	}
+	if add == nil {
+		add = []bucketHostnameChange{}
+	}
+	if remove == nil {
+		remove = []string{}
+	}
+
+	activationID, err := bucket.patch(ctx, bucketPatch{
+		Network:      network,
+		Note:         note,
+		NotifyEmails: notify,
+		Add:          add,
+		Remove:       remove,
+	})
+	if err != nil {
+		return err
+	}
+	// the ID is set once the changes are accepted, so that the resource stays in the state when the activation fails
+	if d.Id() == "" {
+		d.SetId(fmt.Sprintf("%s:%s", bucket.propertyID, network))
+	}
+	if err := d.Set("activation_id", activationID); err != nil {
+		return fmt.Errorf("%w: %s", tools.ErrValueSet, err)
+	}
+
+	activation, err := bucket.activation(ctx, activationID)
+	if err != nil {
+		return err
+	}
+	poller, err := tools.NewPoller("hostname bucket activation", d, hostnameBucketPollInterval, hostnameBucketPollMinimum, logger)
+	if err != nil {
+		return err
+	}
+	activation, err = bucket.waitForActivation(ctx, *activation, poller, logger)
+	if activation.Status != "" {
+		if err := d.Set("activation_status", activation.Status); err != nil {
+			return fmt.Errorf("%w: %s", tools.ErrValueSet, err)
+		}
+	}
+	return err
+}
+
+// bucketHostnameAttrs returns the hostnames element of the hostname
+func bucketHostnameAttrs(cnameFrom, cnameTo, certType string) map[string]interface{} {
+	return map[string]interface{}{
+		"cname_from":             cnameFrom,
+		"cname_to":               cnameTo,
+		"cert_provisioning_type": certType,
+	}
+}
+
+// hostnameChanges returns the hostnames of the schema set to add to the bucket
+func hostnameChanges(hostnames []interface{}) []bucketHostnameChange {
+	changes := make([]bucketHostnameChange, 0, len(hostnames))
+	for _, h := range hostnames {
+		hostname := h.(map[string]interface{})
+		changes = append(changes, bucketHostnameChange{
+			CnameType:            "EDGE_HOSTNAME",
+			CnameFrom:            hostname["cname_from"].(string),
+			CnameTo:              hostname["cname_to"].(string),
+			CertProvisioningType: hostname["cert_provisioning_type"].(string),
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].CnameFrom < changes[j].CnameFrom
+	})
+	return changes
+}
@@ -0,0 +1,317 @@
+package property
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// hostnameBucketSession replaces the responses with the next ones after every hostname bucket change
+type hostnameBucketSession struct {
+	*bulkSession
+	afterPatch []map[string]bulkResponse
+}
+
+func (s *hostnameBucketSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	resp, err := s.bulkSession.Exec(r, out, in...)
+	if err == nil && r.Method == http.MethodPatch && len(s.afterPatch) > 0 {
+		for request, response := range s.afterPatch[0] {
+			s.responses[request] = response
+		}
+		s.afterPatch = s.afterPatch[1:]
+	}
+	return resp, err
+}
+
+func TestResPropertyHostnameBucket(t *testing.T) {
+	const (
+		patchLink    = `{"activationLink": "/papi/v1/properties/prp_1/hostname-activations/atv_1?contractId=ctr_1&groupId=grp_2"}`
+		noHostnames  = `{"hostnames": {"items": [], "totalItems": 0}}`
+		twoHostnames = `{"hostnames": {"items": [
+  {"cnameFrom": "a.example.com", "cnameType": "EDGE_HOSTNAME", "stagingCnameTo": "example.com.edgekey.net", "stagingCertType": "CPS_MANAGED"},
+  {"cnameFrom": "b.example.com", "cnameType": "EDGE_HOSTNAME", "stagingCnameTo": "example.com.edgekey.net", "stagingCertType": "CPS_MANAGED"},
+  {"cnameFrom": "other.example.com", "cnameType": "EDGE_HOSTNAME", "productionCnameTo": "example.com.edgekey.net", "productionCertType": "CPS_MANAGED"}
+], "totalItems": 3}}`
+		updatedHostnames = `{"hostnames": {"items": [
+  {"cnameFrom": "a.example.com", "cnameType": "EDGE_HOSTNAME", "stagingCnameTo": "example.com.edgekey.net", "stagingCertType": "CPS_MANAGED"},
+  {"cnameFrom": "c.example.com", "cnameType": "EDGE_HOSTNAME", "stagingCnameTo": "example.com.edgesuite.net", "stagingCertType": "DEFAULT"}
+], "totalItems": 2}}`
+	)
+	activation := func(status string) bulkResponse {
+		return bulkResponse{status: http.StatusOK, body: `{"hostnameActivation": {"activationId": "atv_1", "network": "STAGING", "status": "` + status + `", "hostnames": []}}`}
+	}
+	// afterPatch returns the responses after a hostname bucket change
+	afterPatch := func(hostnames, status string) map[string]bulkResponse {
+		return map[string]bulkResponse{
+			"GET /papi/v1/properties/prp_1/hostnames":                  {status: http.StatusOK, body: hostnames},
+			"GET /papi/v1/properties/prp_1/hostname-activations/atv_1": activation(status),
+		}
+	}
+
+	// changes returns the requests which are not reads
+	changes := func(requests []string) []string {
+		var result []string
+		for _, request := range requests {
+			if !strings.HasPrefix(request, http.MethodGet) {
+				result = append(result, request)
+			}
+		}
+		return result
+	}
+
+	t.Run("add, change and remove hostnames", func(t *testing.T) {
+		sess := &hostnameBucketSession{
+			bulkSession: &bulkSession{responses: map[string]bulkResponse{
+				"PATCH /papi/v1/properties/prp_1/hostnames":                {status: http.StatusAccepted, body: patchLink},
+				"GET /papi/v1/properties/prp_1/hostnames":                  {status: http.StatusOK, body: noHostnames},
+				"GET /papi/v1/properties/prp_1/hostname-activations/atv_1": activation("ACTIVE"),
+			}},
+			afterPatch: []map[string]bulkResponse{
+				afterPatch(twoHostnames, "ACTIVE"),
+				afterPatch(updatedHostnames, "ACTIVE"),
+				afterPatch(noHostnames, "ACTIVE"),
+			},
+		}
+		useExecutor(&papi.Mock{}, sess, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers:  testAccProviders,
+				IsUnitTest: true,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResPropertyHostnameBucket/create.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "id", "prp_1:STAGING"),
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "activation_id", "atv_1"),
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "activation_status", "ACTIVE"),
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "hostnames.#", "2"),
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "hostname_status.%", "2"),
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "hostname_status.a.example.com", "ACTIVE"),
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "hostname_status.b.example.com", "ACTIVE"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResPropertyHostnameBucket/update.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "hostnames.#", "2"),
+							resource.TestCheckTypeSetElemNestedAttrs("akamai_property_hostname_bucket.test", "hostnames.*", map[string]string{
+								"cname_from":             "c.example.com",
+								"cname_to":               "example.com.edgesuite.net",
+								"cert_provisioning_type": "DEFAULT",
+							}),
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "hostname_status.c.example.com", "ACTIVE"),
+						),
+					},
+				},
+			})
+		})
+		assert.Equal(t, []string{
+			`PATCH /papi/v1/properties/prp_1/hostnames?contractId=ctr_1&groupId=grp_2 {"network":"STAGING","note":"onboard customers","notifyEmails":["user@example.com"],` +
+				`"add":[{"cnameType":"EDGE_HOSTNAME","cnameFrom":"a.example.com","cnameTo":"example.com.edgekey.net","certProvisioningType":"CPS_MANAGED"},` +
+				`{"cnameType":"EDGE_HOSTNAME","cnameFrom":"b.example.com","cnameTo":"example.com.edgekey.net","certProvisioningType":"CPS_MANAGED"}],"remove":[]}`,
+			`PATCH /papi/v1/properties/prp_1/hostnames?contractId=ctr_1&groupId=grp_2 {"network":"STAGING","note":"onboard customers","notifyEmails":["user@example.com"],` +
+				`"add":[{"cnameType":"EDGE_HOSTNAME","cnameFrom":"c.example.com","cnameTo":"example.com.edgesuite.net","certProvisioningType":"DEFAULT"}],"remove":["b.example.com"]}`,
+			`PATCH /papi/v1/properties/prp_1/hostnames?contractId=ctr_1&groupId=grp_2 {"network":"STAGING","note":"onboard customers","notifyEmails":["user@example.com"],` +
+				`"add":[],"remove":["a.example.com","c.example.com"]}`,
+		}, changes(sess.requests))
+	})
+
+	t.Run("import hostnames of the network", func(t *testing.T) {
+		sess := &hostnameBucketSession{
+			bulkSession: &bulkSession{responses: map[string]bulkResponse{
+				"PATCH /papi/v1/properties/prp_1/hostnames":                {status: http.StatusAccepted, body: patchLink},
+				"GET /papi/v1/properties/prp_1/hostnames":                  {status: http.StatusOK, body: noHostnames},
+				"GET /papi/v1/properties/prp_1/hostname-activations/atv_1": activation("ACTIVE"),
+			}},
+			afterPatch: []map[string]bulkResponse{
+				afterPatch(twoHostnames, "ACTIVE"),
+				afterPatch(noHostnames, "ACTIVE"),
+			},
+		}
+		useExecutor(&papi.Mock{}, sess, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers:  testAccProviders,
+				IsUnitTest: true,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResPropertyHostnameBucket/create.tf"),
+					},
+					{
+						ImportState:             true,
+						ImportStateId:           "1:ctr_1:grp_2:STAGING",
+						ResourceName:            "akamai_property_hostname_bucket.test",
+						ImportStateVerify:       true,
+						ImportStateVerifyIgnore: []string{"activation_id", "activation_status", "notify_emails", "note"},
+					},
+				},
+			})
+		})
+	})
+
+	t.Run("failed hostname activation", func(t *testing.T) {
+		sess := &hostnameBucketSession{
+			bulkSession: &bulkSession{responses: map[string]bulkResponse{
+				"PATCH /papi/v1/properties/prp_1/hostnames":                {status: http.StatusAccepted, body: patchLink},
+				"GET /papi/v1/properties/prp_1/hostnames":                  {status: http.StatusOK, body: noHostnames},
+				"GET /papi/v1/properties/prp_1/hostname-activations/atv_1": activation("FAILED"),
+			}},
+			afterPatch: []map[string]bulkResponse{
+				afterPatch(noHostnames, "FAILED"),
+				afterPatch(noHostnames, "ACTIVE"),
+			},
+		}
+		useExecutor(&papi.Mock{}, sess, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers:  testAccProviders,
+				IsUnitTest: true,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestResPropertyHostnameBucket/create.tf"),
+						ExpectError: regexp.MustCompile("hostname bucket: prp_1: activation atv_1: FAILED"),
+					},
+				},
+			})
+		})
+	})
+
+	t.Run("hostnames of a failed activation are added again", func(t *testing.T) {
+		sess := &hostnameBucketSession{
+			bulkSession: &bulkSession{responses: map[string]bulkResponse{
+				"PATCH /papi/v1/properties/prp_1/hostnames":                {status: http.StatusAccepted, body: patchLink},
+				"GET /papi/v1/properties/prp_1/hostnames":                  {status: http.StatusOK, body: noHostnames},
+				"GET /papi/v1/properties/prp_1/hostname-activations/atv_1": activation("ACTIVE"),
+			}},
+			afterPatch: []map[string]bulkResponse{
+				afterPatch(twoHostnames, "ACTIVE"),
+				{
+					"GET /papi/v1/properties/prp_1/hostnames": {status: http.StatusOK, body: twoHostnames},
+					"GET /papi/v1/properties/prp_1/hostname-activations/atv_1": {status: http.StatusOK, body: `{"hostnameActivation": {"activationId": "atv_1", "network": "STAGING", "status": "FAILED", "hostnames": [
+  {"action": "ADD", "cnameType": "EDGE_HOSTNAME", "cnameFrom": "c.example.com", "cnameTo": "example.com.edgesuite.net", "certProvisioningType": "DEFAULT"},
+  {"action": "REMOVE", "cnameType": "EDGE_HOSTNAME", "cnameFrom": "b.example.com"}
+]}}`},
+				},
+				afterPatch(updatedHostnames, "ACTIVE"),
+				afterPatch(noHostnames, "ACTIVE"),
+			},
+		}
+		useExecutor(&papi.Mock{}, sess, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers:  testAccProviders,
+				IsUnitTest: true,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResPropertyHostnameBucket/create.tf"),
+					},
+					{
+						Config:      loadFixtureString("testdata/TestResPropertyHostnameBucket/update.tf"),
+						ExpectError: regexp.MustCompile("hostname bucket: prp_1: activation atv_1: FAILED"),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResPropertyHostnameBucket/update.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "activation_status", "ACTIVE"),
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "hostnames.#", "2"),
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "hostname_status.c.example.com", "ACTIVE"),
+						),
+					},
+				},
+			})
+		})
+		// c.example.com is not pending after the failed activation, so it is added again
+		addC := `PATCH /papi/v1/properties/prp_1/hostnames?contractId=ctr_1&groupId=grp_2 {"network":"STAGING","note":"onboard customers","notifyEmails":["user@example.com"],` +
+			`"add":[{"cnameType":"EDGE_HOSTNAME","cnameFrom":"c.example.com","cnameTo":"example.com.edgesuite.net","certProvisioningType":"DEFAULT"}],"remove":[]}`
+		assert.Equal(t, addC, changes(sess.requests)[2])
+	})
+
+	t.Run("refresh after a failed activation keeps other hostnames of the bucket", func(t *testing.T) {
+		const (
+			otherHostnames = `{"hostnames": {"items": [
+  {"cnameFrom": "x.example.com", "cnameType": "EDGE_HOSTNAME", "stagingCnameTo": "example.com.edgekey.net", "stagingCertType": "CPS_MANAGED"}
+], "totalItems": 1}}`
+			createdHostnames = `{"hostnames": {"items": [
+  {"cnameFrom": "a.example.com", "cnameType": "EDGE_HOSTNAME", "stagingCnameTo": "example.com.edgekey.net", "stagingCertType": "CPS_MANAGED"},
+  {"cnameFrom": "b.example.com", "cnameType": "EDGE_HOSTNAME", "stagingCnameTo": "example.com.edgekey.net", "stagingCertType": "CPS_MANAGED"},
+  {"cnameFrom": "x.example.com", "cnameType": "EDGE_HOSTNAME", "stagingCnameTo": "example.com.edgekey.net", "stagingCertType": "CPS_MANAGED"}
+], "totalItems": 3}}`
+			updatedHostnames = `{"hostnames": {"items": [
+  {"cnameFrom": "a.example.com", "cnameType": "EDGE_HOSTNAME", "stagingCnameTo": "example.com.edgekey.net", "stagingCertType": "CPS_MANAGED"},
+  {"cnameFrom": "c.example.com", "cnameType": "EDGE_HOSTNAME", "stagingCnameTo": "example.com.edgesuite.net", "stagingCertType": "DEFAULT"},
+  {"cnameFrom": "x.example.com", "cnameType": "EDGE_HOSTNAME", "stagingCnameTo": "example.com.edgekey.net", "stagingCertType": "CPS_MANAGED"}
+], "totalItems": 3}}`
+		)
+		sess := &hostnameBucketSession{
+			bulkSession: &bulkSession{responses: map[string]bulkResponse{
+				"PATCH /papi/v1/properties/prp_1/hostnames":                {status: http.StatusAccepted, body: patchLink},
+				"GET /papi/v1/properties/prp_1/hostnames":                  {status: http.StatusOK, body: otherHostnames},
+				"GET /papi/v1/properties/prp_1/hostname-activations/atv_1": activation("ACTIVE"),
+			}},
+			afterPatch: []map[string]bulkResponse{
+				afterPatch(createdHostnames, "ACTIVE"),
+				// the managed hostnames were removed outside of Terraform while the update failed
+				afterPatch(otherHostnames, "FAILED"),
+				afterPatch(updatedHostnames, "ACTIVE"),
+				afterPatch(otherHostnames, "ACTIVE"),
+			},
+		}
+		useExecutor(&papi.Mock{}, sess, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers:  testAccProviders,
+				IsUnitTest: true,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResPropertyHostnameBucket/create.tf"),
+					},
+					{
+						Config:      loadFixtureString("testdata/TestResPropertyHostnameBucket/update.tf"),
+						ExpectError: regexp.MustCompile("hostname bucket: prp_1: activation atv_1: FAILED"),
+					},
+					{
+						Config:             loadFixtureString("testdata/TestResPropertyHostnameBucket/update.tf"),
+						PlanOnly:           true,
+						ExpectNonEmptyPlan: true,
+					},
+					{
+						Config: loadFixtureString("testdata/TestResPropertyHostnameBucket/update.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_hostname_bucket.test", "hostnames.#", "2"),
+							resource.TestCheckNoResourceAttr("akamai_property_hostname_bucket.test", "hostname_status.x.example.com"),
+						),
+					},
+				},
+			})
+		})
+		// x.example.com is not managed by the resource, so it is never removed
+		assert.Equal(t, []string{
+			`PATCH /papi/v1/properties/prp_1/hostnames?contractId=ctr_1&groupId=grp_2 {"network":"STAGING","note":"onboard customers","notifyEmails":["user@example.com"],` +
+				`"add":[{"cnameType":"EDGE_HOSTNAME","cnameFrom":"a.example.com","cnameTo":"example.com.edgekey.net","certProvisioningType":"CPS_MANAGED"},` +
+				`{"cnameType":"EDGE_HOSTNAME","cnameFrom":"b.example.com","cnameTo":"example.com.edgekey.net","certProvisioningType":"CPS_MANAGED"}],"remove":[]}`,
+			`PATCH /papi/v1/properties/prp_1/hostnames?contractId=ctr_1&groupId=grp_2 {"network":"STAGING","note":"onboard customers","notifyEmails":["user@example.com"],` +
+				`"add":[{"cnameType":"EDGE_HOSTNAME","cnameFrom":"c.example.com","cnameTo":"example.com.edgesuite.net","certProvisioningType":"DEFAULT"}],"remove":["b.example.com"]}`,
+			`PATCH /papi/v1/properties/prp_1/hostnames?contractId=ctr_1&groupId=grp_2 {"network":"STAGING","note":"onboard customers","notifyEmails":["user@example.com"],` +
+				`"add":[{"cnameType":"EDGE_HOSTNAME","cnameFrom":"a.example.com","cnameTo":"example.com.edgekey.net","certProvisioningType":"CPS_MANAGED"},` +
+				`{"cnameType":"EDGE_HOSTNAME","cnameFrom":"c.example.com","cnameTo":"example.com.edgesuite.net","certProvisioningType":"DEFAULT"}],"remove":[]}`,
+			`PATCH /papi/v1/properties/prp_1/hostnames?contractId=ctr_1&groupId=grp_2 {"network":"STAGING","note":"onboard customers","notifyEmails":["user@example.com"],` +
+				`"add":[],"remove":["a.example.com","c.example.com"]}`,
+		}, changes(sess.requests))
+	})
+
+	t.Run("property not found", func(t *testing.T) {
+		sess := &hostnameBucketSession{bulkSession: &bulkSession{responses: map[string]bulkResponse{}}}
+		useExecutor(&papi.Mock{}, sess, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers:  testAccProviders,
+				IsUnitTest: true,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestResPropertyHostnameBucket/create.tf"),
+						ExpectError: regexp.MustCompile("(?s)hostname bucket: prp_1: .*Not Found"),
+					},
+				},
+			})
+		})
+	})
+}
@@ -25,6 +25,7 @@ func resourcePropertyInclude() *schema.Resource {
 		ReadContext:   resourcePropertyIncludeRead,
 		UpdateContext: resourcePropertyIncludeUpdate,
 		DeleteContext: resourcePropertyIncludeDelete,
+		CustomizeDiff: ruleFormatSchemaCustomDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcePropertyIncludeImport,
 		},
@@ -74,7 +75,7 @@ func resourcePropertyInclude() *schema.Resource {
 				Optional:         true,
 				Computed:         true,
 				Description:      "Property Rules as JSON",
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				ValidateDiagFunc: tools.AggregateValidations(validation.ToDiagFunc(validation.StringIsJSON), validateRulesSchema),
 				DiffSuppressFunc: tools.ComposeDiffSuppress(suppressDefaultRules, diffSuppressRules),
 			},
 			"rule_errors": {
@@ -166,7 +167,7 @@ func resourcePropertyIncludeCreate(ctx context.Context, rd *schema.ResourceData,
 		return diag.Errorf("%s update: %s", ErrPropertyInclude, err)
 	}
 
-	return resourcePropertyIncludeRead(ctx, rd, m)
+	return append(ruleFormatSchemaWarnings(rd), resourcePropertyIncludeRead(ctx, rd, m)...)
 }
 
 func resourcePropertyIncludeRead(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -195,6 +196,9 @@ func resourcePropertyIncludeRead(ctx context.Context, rd *schema.ResourceData, m
 		ContractID: contractID,
 	})
 	if err != nil {
+		if akamai.RemoveIfNotFound(rd, err, logger) {
+			return nil
+		}
 		return diag.Errorf("%s read: %s", ErrPropertyInclude, err)
 	}
 
@@ -323,7 +327,7 @@ func resourcePropertyIncludeUpdate(ctx context.Context, rd *schema.ResourceData,
 		return diag.Errorf("%s update: %s", ErrPropertyInclude, err)
 	}
 
-	return resourcePropertyIncludeRead(ctx, rd, m)
+	return append(ruleFormatSchemaWarnings(rd), resourcePropertyIncludeRead(ctx, rd, m)...)
 }
 
 func resourcePropertyIncludeDelete(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
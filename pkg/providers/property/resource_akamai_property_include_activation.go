@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
 	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+	"github.com/apex/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -88,47 +90,9 @@ func resourcePropertyIncludeActivation() *schema.Resource {
 				Computed:    true,
 				Description: "The validation information in JSON format",
 			},
-			"compliance_record": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				Description: "Provides an audit record when activating on a production network",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"noncompliance_reason": {
-							Type:             schema.TypeString,
-							Required:         true,
-							Description:      fmt.Sprintf("Specifies the reason for the expedited activation on production network. Valid noncompliance reasons are: %s", strings.Join(validComplianceRecords, ", ")),
-							ValidateDiagFunc: tools.ValidateStringInSlice(validComplianceRecords),
-						},
-						"ticket_id": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Identifies the ticket that describes the need for the activation",
-						},
-						"other_noncompliance_reason": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Describes the reason why the activation must occur immediately, out of compliance with the standard procedure",
-						},
-						"customer_email": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Identifies the customer",
-						},
-						"peer_reviewed_by": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Identifies person who has independently approved the activation request",
-						},
-						"unit_tested": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Description: "Whether the metadata to activate has been fully tested",
-						},
-					},
-				},
-			},
+			"compliance_record":   complianceRecordSchema(),
+			tools.PollIntervalKey: tools.PollIntervalSchema(activationPollInterval),
+			tools.PollTimeoutKey:  tools.PollTimeoutSchema(),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: &includeActivationTimeout,
@@ -143,6 +107,51 @@ var (
 	validComplianceRecords   = []string{papi.NoncomplianceReasonNone, papi.NoncomplianceReasonOther, papi.NoncomplianceReasonNoProductionTraffic, papi.NoncomplianceReasonEmergency}
 )
 
+// complianceRecordSchema returns the schema of the compliance_record block of production activations
+func complianceRecordSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Provides an audit record when activating on a production network",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"noncompliance_reason": {
+					Type:             schema.TypeString,
+					Required:         true,
+					Description:      fmt.Sprintf("Specifies the reason for the expedited activation on production network. Valid noncompliance reasons are: %s", strings.Join(validComplianceRecords, ", ")),
+					ValidateDiagFunc: tools.ValidateStringInSlice(validComplianceRecords),
+				},
+				"ticket_id": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Identifies the ticket that describes the need for the activation",
+				},
+				"other_noncompliance_reason": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Describes the reason why the activation must occur immediately, out of compliance with the standard procedure",
+				},
+				"customer_email": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Identifies the customer",
+				},
+				"peer_reviewed_by": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Identifies person who has independently approved the activation request",
+				},
+				"unit_tested": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Whether the metadata to activate has been fully tested",
+				},
+			},
+		},
+	}
+}
+
 func resourcePropertyIncludeActivationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	meta := akamai.Meta(m)
 	logger := meta.Log("PAPI", "resourcePropertyIncludeActivationCreate")
@@ -150,7 +159,7 @@ func resourcePropertyIncludeActivationCreate(ctx context.Context, d *schema.Reso
 	client := inst.Client(meta)
 	logger.Debug("Create property include activation")
 
-	err := resourcePropertyIncludeActivationUpsert(ctx, d, client)
+	err := resourcePropertyIncludeActivationUpsert(ctx, d, client, logger)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -183,7 +192,7 @@ func resourcePropertyIncludeActivationRead(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	activation, err := waitForPropertyIncludeOperation(ctx, client, activationID, includeID, "activation")
+	activation, err := waitForPropertyIncludeOperation(ctx, d, client, activationID, includeID, "activation", logger)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -229,7 +238,7 @@ func resourcePropertyIncludeActivationUpdate(ctx context.Context, d *schema.Reso
 			"'compliance_record' cannot be updated after resource creation without 'version' attribute modification"))
 	}
 
-	err := resourcePropertyIncludeActivationUpsert(ctx, d, client)
+	err := resourcePropertyIncludeActivationUpsert(ctx, d, client, logger)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -291,20 +300,23 @@ func resourcePropertyIncludeActivationDelete(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
-	_, err = waitForPropertyIncludeOperation(ctx, client, deactivation.ActivationID, includeID, "deactivation")
+	_, err = waitForPropertyIncludeOperation(ctx, d, client, deactivation.ActivationID, includeID, "deactivation", logger)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourcePropertyIncludeActivationImport(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+// resourcePropertyIncludeActivationImport adopts the latest activation of the include on the network.
+// The import ID is contract_id:group_id:include_id:network, optionally followed by the version which must be the active one
+func resourcePropertyIncludeActivationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	meta := akamai.Meta(m)
 	logger := meta.Log("PAPI", "resourcePropertyIncludeActivationImport")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
 	logger.Debug("Importing property include activation")
 
 	id := strings.Split(d.Id(), ":")
-	if len(id) < 4 {
+	if len(id) < 4 || len(id) > 5 {
 		return nil, fmt.Errorf("invalid include activation identifier: %s", d.Id())
 	}
 	contractID, groupID, includeID, network := id[0], id[1], id[2], id[3]
@@ -312,6 +324,33 @@ func resourcePropertyIncludeActivationImport(_ context.Context, d *schema.Resour
 	if contractID == "" || groupID == "" || includeID == "" || network == "" {
 		return nil, fmt.Errorf("contract, group, include IDs and network must have non empty values")
 	}
+	network, err := NetworkAlias(network)
+	if err != nil {
+		return nil, fmt.Errorf("invalid include activation identifier: %s: %w", d.Id(), err)
+	}
+
+	if len(id) == 5 {
+		version, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(id[4]), "v"))
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("invalid include activation identifier: %s: version must be a positive number, e.g. v5", d.Id())
+		}
+		activations, err := inst.Client(meta).ListIncludeActivations(ctx, papi.ListIncludeActivationsRequest{
+			IncludeID:  tools.AddPrefix(includeID, "inc_"),
+			GroupID:    tools.AddPrefix(groupID, "grp_"),
+			ContractID: tools.AddPrefix(contractID, "ctr_"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		activation, err := findLatestIncludeActivation(filterIncludeActivationsByNetwork(activations.Activations.Items, network))
+		if err != nil {
+			return nil, fmt.Errorf("version %d of include %s is not active on %s: %w", version, includeID, network, err)
+		}
+		if activation.IncludeVersion != version {
+			return nil, fmt.Errorf("version %d of include %s is not active on %s, the active version is %d", version, includeID, network, activation.IncludeVersion)
+		}
+	}
+	d.SetId(fmt.Sprintf("%s:%s:%s:%s", contractID, groupID, includeID, network))
 
 	// it is impossible to fetch auto_acknowledge_rule_warnings from server
 	if err := d.Set("auto_acknowledge_rule_warnings", false); err != nil {
@@ -321,7 +360,7 @@ func resourcePropertyIncludeActivationImport(_ context.Context, d *schema.Resour
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourcePropertyIncludeActivationUpsert(ctx context.Context, d *schema.ResourceData, client papi.PAPI) error {
+func resourcePropertyIncludeActivationUpsert(ctx context.Context, d *schema.ResourceData, client papi.PAPI, logger log.Interface) error {
 	includeID, err := tools.GetStringValue("include_id", d)
 	if err != nil {
 		return err
@@ -382,7 +421,7 @@ func resourcePropertyIncludeActivationUpsert(ctx context.Context, d *schema.Reso
 	}
 
 	// here is used temporary activationID
-	if _, err := waitForPropertyIncludeOperation(ctx, client, activationRes.ActivationID, includeID, "activation"); err != nil {
+	if _, err := waitForPropertyIncludeOperation(ctx, d, client, activationRes.ActivationID, includeID, "activation", logger); err != nil {
 		return err
 	}
 
@@ -400,68 +439,67 @@ func getLatestIncludeActivationID(versions *papi.ListIncludeActivationsResponse,
 	return act.ActivationID, nil
 }
 
-// waitForPropertyIncludeOperation adds timeout for activation and deactivation include operations
-func waitForPropertyIncludeOperation(ctx context.Context, client papi.PAPI, activationID, includeID, operationType string) (*papi.GetIncludeActivationResponse, error) {
-	activation, err := client.GetIncludeActivation(ctx, papi.GetIncludeActivationRequest{
-		IncludeID:    includeID,
-		ActivationID: activationID,
-	})
-	if err != nil {
-		// it can take a few seconds to fetch include activation/deactivation right after activation/deactivation request
-		if strings.Contains(err.Error(), papi.ErrNotFound.Error()) && strings.Contains(err.Error(), papi.ErrGetIncludeActivation.Error()) {
-			select {
-			case <-time.After(getActivationInterval):
-				return waitForPropertyIncludeOperation(ctx, client, activationID, includeID, operationType)
-			case <-ctx.Done():
-				return nil, terminateProcess(ctx, operationType)
+// waitForPropertyIncludeOperation waits for the include activation or deactivation to reach a terminal state
+func waitForPropertyIncludeOperation(ctx context.Context, d *schema.ResourceData, client papi.PAPI, activationID, includeID, operationType string, logger log.Interface) (*papi.GetIncludeActivationResponse, error) {
+	var activation *papi.GetIncludeActivationResponse
+	checkStatus := func(ctx context.Context) (bool, error) {
+		var res *papi.GetIncludeActivationResponse
+		err := akamai.RetryTransient(ctx, akamai.PollRetryAttempts, akamai.PollRetryInterval, func() error {
+			var err error
+			res, err = client.GetIncludeActivation(ctx, papi.GetIncludeActivationRequest{
+				IncludeID:    includeID,
+				ActivationID: activationID,
+			})
+			return err
+		})
+		if err != nil {
+			// it can take a few seconds to fetch include activation/deactivation right after activation/deactivation request
+			if errors.Is(err, papi.ErrNotFound) && strings.Contains(err.Error(), papi.ErrGetIncludeActivation.Error()) {
+				return false, nil
 			}
+			return false, err
 		}
-		return nil, err
+		activation = res
+		switch res.Activation.Status {
+		case papi.ActivationStatusActive:
+			return true, nil
+		case papi.ActivationStatusFailed:
+			return false, fmt.Errorf("%s request failed for property include %v", operationType, includeID)
+		case papi.ActivationStatusAborted:
+			return false, fmt.Errorf("pending %s request aborted for property include %v", operationType, includeID)
+		}
+		return false, nil
 	}
-	for activation != nil && activation.Activation.Status != papi.ActivationStatusActive {
-		actStatus := activation.Activation.Status
 
-		if actStatus == papi.ActivationStatusFailed {
-			return nil, fmt.Errorf("%s request failed for property include %v", operationType, includeID)
-		}
-		if actStatus == papi.ActivationStatusAborted {
-			return nil, fmt.Errorf("pending %s request aborted for property include %v", operationType, includeID)
-		}
+	done, err := checkStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if done {
+		return activation, nil
+	}
 
-		select {
-		case <-time.After(activationPollInterval):
-			activation, err = client.GetIncludeActivation(ctx, papi.GetIncludeActivationRequest{
-				IncludeID:    includeID,
-				ActivationID: activationID,
-			})
-			if err != nil {
-				// it can take a few seconds to fetch include activation/deactivation right after activation/deactivation request
-				if strings.Contains(err.Error(), papi.ErrNotFound.Error()) && strings.Contains(err.Error(), papi.ErrGetIncludeActivation.Error()) {
-					select {
-					case <-time.After(getActivationInterval):
-						return waitForPropertyIncludeOperation(ctx, client, activationID, includeID, operationType)
-					}
-				}
-				return nil, err
-			}
-			if err != nil {
-				return nil, err
-			}
-		case <-ctx.Done():
-			return nil, terminateProcess(ctx, operationType)
+	poller, err := tools.NewPoller("property include "+operationType, d, activationPollInterval, getActivationInterval, logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := poller.Poll(ctx, checkStatus); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return nil, terminateProcess(err, operationType)
 		}
+		return nil, err
 	}
 	return activation, nil
 }
 
-func terminateProcess(ctx context.Context, operationType string) error {
-	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+func terminateProcess(err error, operationType string) error {
+	if errors.Is(err, context.DeadlineExceeded) {
 		return fmt.Errorf("timeout waiting for %s status", operationType)
 	}
-	if errors.Is(ctx.Err(), context.Canceled) {
+	if errors.Is(err, context.Canceled) {
 		return fmt.Errorf("operation canceled while waiting for %s status", operationType)
 	}
-	return fmt.Errorf("%s context terminated: %w", operationType, ctx.Err())
+	return fmt.Errorf("%s context terminated: %w", operationType, err)
 }
 
 func addComplianceRecordToActivationByNetwork(network string, complianceRecord []interface{}, activateIncludeRequest papi.ActivateIncludeRequest) (papi.ActivateIncludeRequest, error) {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -456,6 +457,68 @@ func TestResourcePropertyIncludeActivation(t *testing.T) {
 		})
 		client.AssertExpectations(t)
 	})
+
+	t.Run("import with version", func(t *testing.T) {
+		client := new(papi.Mock)
+
+		// import
+		expectListIncludeActivations(client)
+
+		// read
+		activations := expectListIncludeActivations(client)
+		actID, err := getLatestIncludeActivationID(activations, networkStaging)
+		require.NoError(t, err)
+		expectGetIncludeActivation(client, actID, papi.ActivationNetworkStaging)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:        loadFixtureString(fmt.Sprintf("%s/property_include_activation.tf", testDir)),
+						ImportState:   true,
+						ImportStateId: "ctr_test_contract:grp_test_group:inc_12345:staging:v3",
+						ResourceName:  "akamai_property_include_activation.activation",
+						ImportStateCheck: func(states []*terraform.InstanceState) error {
+							if len(states) != 1 {
+								return fmt.Errorf("expected one imported state, got %d", len(states))
+							}
+							if id := states[0].Attributes["id"]; id != "ctr_test_contract:grp_test_group:inc_12345:STAGING" {
+								return fmt.Errorf("unexpected id: %s", id)
+							}
+							if v := states[0].Attributes["version"]; v != "3" {
+								return fmt.Errorf("unexpected version: %s", v)
+							}
+							return nil
+						},
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("import with inactive version", func(t *testing.T) {
+		client := new(papi.Mock)
+
+		expectListIncludeActivations(client)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:        loadFixtureString(fmt.Sprintf("%s/property_include_activation.tf", testDir)),
+						ImportState:   true,
+						ImportStateId: "ctr_test_contract:grp_test_group:inc_12345:STAGING:v2",
+						ResourceName:  "akamai_property_include_activation.activation",
+						ExpectError:   regexp.MustCompile("version 2 of include inc_12345 is not active on STAGING, the active version is 3"),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
 }
 
 func Test_addComplianceRecordByNetwork(t *testing.T) {
@@ -701,12 +701,12 @@ func TestResourcePropertyInclude(t *testing.T) {
 					ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found`),
 				},
 				{
-					Config:      loadFixtureString("%s/validation_required_errors.tf", workdir),
-					ExpectError: regexp.MustCompile(`The argument "group_id" is required, but no definition was found`),
+					Config:      loadFixtureString("%s/group_missing.tf", workdir),
+					ExpectError: regexp.MustCompile(`"group_id" is required: value must be set in the resource or in the provider defaults block`),
 				},
 				{
-					Config:      loadFixtureString("%s/validation_required_errors.tf", workdir),
-					ExpectError: regexp.MustCompile(`The argument "contract_id" is required, but no definition was found`),
+					Config:      loadFixtureString("%s/contract_group_missing.tf", workdir),
+					ExpectError: regexp.MustCompile(`"contract_id" is required: value must be set in the resource or in the provider defaults block`),
 				},
 				{
 					Config:      loadFixtureString("%s/validation_required_errors.tf", workdir),
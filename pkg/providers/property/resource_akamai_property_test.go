@@ -763,9 +763,9 @@ func TestResProperty(t *testing.T) {
 
 		t.Run("Schema Configuration Error: name not given", AssertConfigError(t, "name not given", `"name" is required`))
 		t.Run("Schema Configuration Error: neither contract nor contract_id given", AssertConfigError(t, "neither contract nor contract_id given", `one of .contract,contract_id. must be specified`))
-		t.Run("Schema Configuration Error: both contract and contract_id given", AssertConfigError(t, "both contract and contract_id given", `only one of .contract,contract_id. can be specified`))
+		t.Run("Schema Configuration Error: both contract and contract_id given", AssertConfigError(t, "both contract and contract_id given", `"contract_id": conflicts with contract`))
 		t.Run("Schema Configuration Error: neither group nor group_id given", AssertConfigError(t, "neither group nor group_id given", `one of .group,group_id. must be specified`))
-		t.Run("Schema Configuration Error: both group and group_id given", AssertConfigError(t, "both group and group_id given", `only one of .group,group_id. can be specified`))
+		t.Run("Schema Configuration Error: both group and group_id given", AssertConfigError(t, "both group and group_id given", `"group_id": conflicts with group`))
 		t.Run("Schema Configuration Error: neither product nor product_id given", AssertConfigError(t, "neither product nor product_id given", `one of .product,product_id. must be specified`))
 		t.Run("Schema Configuration Error: both product and product_id given", AssertConfigError(t, "both product and product_id given", `only one of .product,product_id. can be specified`))
 		t.Run("Schema Configuration Error: invalid json rules", AssertConfigError(t, "invalid json rules", `rules are not valid JSON`))
@@ -0,0 +1,610 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v3/pkg/tools"
+)
+
+const (
+	// RuleFormatSchemaDirEnv is the environment variable holding the directory of the rule format schemas
+	RuleFormatSchemaDirEnv = "AKAMAI_RULE_FORMAT_SCHEMA_DIR"
+
+	// maxRuleSchemaErrors limits the number of errors reported for a rule tree
+	maxRuleSchemaErrors = 20
+)
+
+var (
+	// ErrRuleFormatSchemaNotFound is returned when no schema of the rule format is available
+	ErrRuleFormatSchemaNotFound = errors.New("rule format schema not found")
+	// ErrRuleFormatSchema is returned when the rule format schema cannot be read
+	ErrRuleFormatSchema = errors.New("rule format schema")
+	// ErrRulesSchemaValidation is returned when the rules do not match the rule format schema
+	ErrRulesSchemaValidation = errors.New("rules do not match the rule format schema")
+
+	ruleFormatSchemas sync.Map
+)
+
+type (
+	// ruleFormatSchema is a rule format JSON schema, as returned by the PAPI schemas API
+	ruleFormatSchema struct {
+		root map[string]interface{}
+	}
+
+	// ruleSchemaError is a mismatch between the rules and the schema, at the JSON pointer of the value
+	ruleSchemaError struct {
+		path    string
+		message string
+	}
+
+	ruleSchemaErrors []ruleSchemaError
+)
+
+func (e ruleSchemaError) Error() string {
+	return fmt.Sprintf("%s: %s", e.path, e.message)
+}
+
+func (e ruleSchemaErrors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ruleFormatSchemaDir returns the directory holding the rule format schemas, <user cache dir>/terraform-provider-akamai/rule-formats
+// unless set in the AKAMAI_RULE_FORMAT_SCHEMA_DIR environment variable
+func ruleFormatSchemaDir() string {
+	if dir := os.Getenv(RuleFormatSchemaDirEnv); dir != "" {
+		return dir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "terraform-provider-akamai", "rule-formats")
+}
+
+// ruleFormatSchemaPaths returns the files the schema is looked up in, the product schema first
+func ruleFormatSchemaPaths(dir, productID, ruleFormat string) []string {
+	var paths []string
+	if productID != "" {
+		paths = append(paths, filepath.Join(dir, tools.AddPrefix(productID, "prd_"), ruleFormat+".json"))
+	}
+	return append(paths, filepath.Join(dir, ruleFormat+".json"))
+}
+
+// loadRuleFormatSchema reads the schema of the rule format from the schema directory
+func loadRuleFormatSchema(dir, productID, ruleFormat string) (*ruleFormatSchema, error) {
+	if dir == "" || ruleFormat == "" || ruleFormat == "latest" {
+		return nil, ErrRuleFormatSchemaNotFound
+	}
+	for _, path := range ruleFormatSchemaPaths(dir, productID, ruleFormat) {
+		if s, ok := ruleFormatSchemas.Load(path); ok {
+			return s.(*ruleFormatSchema), nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrRuleFormatSchema, err)
+		}
+		s, err := parseRuleFormatSchema(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %s", ErrRuleFormatSchema, path, err)
+		}
+		ruleFormatSchemas.Store(path, s)
+		return s, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrRuleFormatSchemaNotFound, ruleFormat)
+}
+
+// fetchRuleFormatSchema downloads the schema of the product rule format from PAPI and caches it in the schema directory
+func fetchRuleFormatSchema(ctx context.Context, exec executor, dir, productID, ruleFormat string) (*ruleFormatSchema, error) {
+	if dir == "" || productID == "" {
+		return nil, ErrRuleFormatSchemaNotFound
+	}
+	productID = tools.AddPrefix(productID, "prd_")
+	uri := fmt.Sprintf("/papi/v1/schemas/products/%s/%s", url.PathEscape(productID), url.PathEscape(ruleFormat))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRuleFormatSchema, err)
+	}
+	var data json.RawMessage
+	resp, err := exec.Exec(req, &data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRuleFormatSchema, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: fetching %s returned status %d", ErrRuleFormatSchema, uri, resp.StatusCode)
+	}
+	s, err := parseRuleFormatSchema(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRuleFormatSchema, err)
+	}
+
+	path := ruleFormatSchemaPaths(dir, productID, ruleFormat)[0]
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRuleFormatSchema, err)
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRuleFormatSchema, err)
+	}
+	ruleFormatSchemas.Store(path, s)
+	return s, nil
+}
+
+func parseRuleFormatSchema(data []byte) (*ruleFormatSchema, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	return &ruleFormatSchema{root: root}, nil
+}
+
+// validateRulesJSON validates the rule tree JSON, returning ruleSchemaErrors if it does not match the schema
+func (s *ruleFormatSchema) validateRulesJSON(rules string) error {
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(rules), &doc); err != nil {
+		return err
+	}
+	// the rule format of exported rule trees is not part of the request
+	delete(doc, "ruleFormat")
+
+	errs := s.validate("", doc, s.root)
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].path < errs[j].path })
+	if len(errs) > maxRuleSchemaErrors {
+		errs = append(errs[:maxRuleSchemaErrors], ruleSchemaError{path: "...", message: fmt.Sprintf("%d more errors", len(errs)-maxRuleSchemaErrors)})
+	}
+	return errs
+}
+
+// validate checks the value against the draft-04 subset of JSON schema used by the rule format schemas
+func (s *ruleFormatSchema) validate(path string, value interface{}, sch map[string]interface{}) ruleSchemaErrors {
+	sch, err := s.resolve(sch)
+	if err != nil {
+		return ruleSchemaErrors{{path: pathOrRoot(path), message: err.Error()}}
+	}
+	if sch == nil {
+		return nil
+	}
+
+	if types, ok := sch["type"]; ok && !matchesType(value, types) {
+		return ruleSchemaErrors{{path: pathOrRoot(path), message: fmt.Sprintf("expected %s, got %s", formatTypes(types), jsonType(value))}}
+	}
+	if enum, ok := sch["enum"].([]interface{}); ok && !inEnum(value, enum) {
+		return ruleSchemaErrors{{path: pathOrRoot(path), message: fmt.Sprintf("value %s is not one of %s", formatDiffValue(value), formatDiffValue(enum))}}
+	}
+
+	var errs ruleSchemaErrors
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		alternatives, ok := sch[key].([]interface{})
+		if !ok {
+			continue
+		}
+		errs = append(errs, s.validateAlternatives(path, key, value, alternatives)...)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		errs = append(errs, s.validateObject(path, v, sch)...)
+	case []interface{}:
+		errs = append(errs, s.validateArray(path, v, sch)...)
+	case string:
+		errs = append(errs, validateString(path, v, sch)...)
+	case float64:
+		errs = append(errs, validateNumber(path, v, sch)...)
+	}
+	return errs
+}
+
+func (s *ruleFormatSchema) validateAlternatives(path, keyword string, value interface{}, alternatives []interface{}) ruleSchemaErrors {
+	schemas := make([]map[string]interface{}, 0, len(alternatives))
+	for _, alt := range alternatives {
+		if m, ok := alt.(map[string]interface{}); ok {
+			schemas = append(schemas, m)
+		}
+	}
+	if keyword == "allOf" {
+		var errs ruleSchemaErrors
+		for _, sch := range schemas {
+			errs = append(errs, s.validate(path, value, sch)...)
+		}
+		return errs
+	}
+
+	// behaviors and criteria are alternatives told apart by their name, so that only the named one is validated
+	if sch, name, ok := s.alternativeByName(value, schemas); ok {
+		if sch == nil {
+			return ruleSchemaErrors{{path: pathOrRoot(path), message: fmt.Sprintf("unknown %s %q", itemKind(path), name)}}
+		}
+		return s.validate(path, value, sch)
+	}
+
+	var matched int
+	var firstErrs ruleSchemaErrors
+	for _, sch := range schemas {
+		errs := s.validate(path, value, sch)
+		if len(errs) == 0 {
+			matched++
+			continue
+		}
+		if firstErrs == nil {
+			firstErrs = errs
+		}
+	}
+	switch {
+	case matched == 0 && len(schemas) == 1:
+		return firstErrs
+	case matched == 0:
+		return ruleSchemaErrors{{path: pathOrRoot(path), message: "value does not match any of the allowed schemas"}}
+	case keyword == "oneOf" && matched > 1:
+		return ruleSchemaErrors{{path: pathOrRoot(path), message: "value matches more than one of the allowed schemas"}}
+	}
+	return nil
+}
+
+// alternativeByName returns the alternative whose name property is the name of the value, if the alternatives are
+// distinguished by name, or a nil schema if none is
+func (s *ruleFormatSchema) alternativeByName(value interface{}, schemas []map[string]interface{}) (map[string]interface{}, string, bool) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, "", false
+	}
+	name, ok := obj["name"].(string)
+	if !ok || len(schemas) == 0 {
+		return nil, "", false
+	}
+	var found map[string]interface{}
+	for _, alt := range schemas {
+		sch, err := s.resolve(alt)
+		if err != nil || sch == nil {
+			return nil, "", false
+		}
+		props, _ := sch["properties"].(map[string]interface{})
+		nameSchema, _ := props["name"].(map[string]interface{})
+		enum, ok := nameSchema["enum"].([]interface{})
+		if !ok {
+			return nil, "", false
+		}
+		if found == nil && inEnum(name, enum) {
+			found = alt
+		}
+	}
+	return found, name, true
+}
+
+func (s *ruleFormatSchema) validateObject(path string, obj map[string]interface{}, sch map[string]interface{}) ruleSchemaErrors {
+	var errs ruleSchemaErrors
+	if required, ok := sch["required"].([]interface{}); ok {
+		for _, r := range required {
+			key, ok := r.(string)
+			if !ok {
+				continue
+			}
+			if _, ok := obj[key]; !ok {
+				errs = append(errs, ruleSchemaError{path: pathOrRoot(path), message: fmt.Sprintf("missing required property %q", key)})
+			}
+		}
+	}
+
+	props, _ := sch["properties"].(map[string]interface{})
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		keyPath := path + "/" + escapePointer(key)
+		if propSchema, ok := props[key].(map[string]interface{}); ok {
+			errs = append(errs, s.validate(keyPath, obj[key], propSchema)...)
+			continue
+		}
+		switch additional := sch["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				errs = append(errs, ruleSchemaError{path: keyPath, message: fmt.Sprintf("unknown property %q", key)})
+			}
+		case map[string]interface{}:
+			errs = append(errs, s.validate(keyPath, obj[key], additional)...)
+		}
+	}
+	return errs
+}
+
+func (s *ruleFormatSchema) validateArray(path string, arr []interface{}, sch map[string]interface{}) ruleSchemaErrors {
+	var errs ruleSchemaErrors
+	if min, ok := sch["minItems"].(float64); ok && float64(len(arr)) < min {
+		errs = append(errs, ruleSchemaError{path: pathOrRoot(path), message: fmt.Sprintf("expected at least %v items, got %d", min, len(arr))})
+	}
+	if max, ok := sch["maxItems"].(float64); ok && float64(len(arr)) > max {
+		errs = append(errs, ruleSchemaError{path: pathOrRoot(path), message: fmt.Sprintf("expected at most %v items, got %d", max, len(arr))})
+	}
+	if items, ok := sch["items"].(map[string]interface{}); ok {
+		for i, item := range arr {
+			errs = append(errs, s.validate(fmt.Sprintf("%s/%d", path, i), item, items)...)
+		}
+	}
+	return errs
+}
+
+func validateString(path, str string, sch map[string]interface{}) ruleSchemaErrors {
+	var errs ruleSchemaErrors
+	length := float64(len([]rune(str)))
+	if min, ok := sch["minLength"].(float64); ok && length < min {
+		errs = append(errs, ruleSchemaError{path: pathOrRoot(path), message: fmt.Sprintf("expected at least %v characters", min)})
+	}
+	if max, ok := sch["maxLength"].(float64); ok && length > max {
+		errs = append(errs, ruleSchemaError{path: pathOrRoot(path), message: fmt.Sprintf("expected at most %v characters", max)})
+	}
+	if pattern, ok := sch["pattern"].(string); ok {
+		// patterns which Go cannot compile are not checked
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(str) {
+			errs = append(errs, ruleSchemaError{path: pathOrRoot(path), message: fmt.Sprintf("value %q does not match %s", str, pattern)})
+		}
+	}
+	return errs
+}
+
+func validateNumber(path string, num float64, sch map[string]interface{}) ruleSchemaErrors {
+	var errs ruleSchemaErrors
+	if min, ok := sch["minimum"].(float64); ok && num < min {
+		errs = append(errs, ruleSchemaError{path: pathOrRoot(path), message: fmt.Sprintf("value %v is less than %v", num, min)})
+	}
+	if max, ok := sch["maximum"].(float64); ok && num > max {
+		errs = append(errs, ruleSchemaError{path: pathOrRoot(path), message: fmt.Sprintf("value %v is greater than %v", num, max)})
+	}
+	return errs
+}
+
+// resolve follows the local $ref of the schema, remote references are not checked and resolve to nil
+func (s *ruleFormatSchema) resolve(sch map[string]interface{}) (map[string]interface{}, error) {
+	for i := 0; i < 32; i++ {
+		ref, ok := sch["$ref"].(string)
+		if !ok {
+			return sch, nil
+		}
+		if !strings.HasPrefix(ref, "#") {
+			return nil, nil
+		}
+		pointer, err := url.PathUnescape(strings.TrimPrefix(ref, "#"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema reference %q", ref)
+		}
+		var node interface{} = s.root
+		for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+			if token == "" {
+				continue
+			}
+			token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+			m, ok := node.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid schema reference %q", ref)
+			}
+			if node, ok = m[token]; !ok {
+				return nil, fmt.Errorf("invalid schema reference %q", ref)
+			}
+		}
+		if sch, ok = node.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("invalid schema reference %q", ref)
+		}
+	}
+	return nil, fmt.Errorf("schema references nested too deep")
+}
+
+func matchesType(value interface{}, types interface{}) bool {
+	switch t := types.(type) {
+	case string:
+		return matchesSingleType(value, t)
+	case []interface{}:
+		for _, typ := range t {
+			if s, ok := typ.(string); ok && matchesSingleType(value, s) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchesSingleType(value interface{}, typ string) bool {
+	switch typ {
+	case "integer":
+		num, ok := value.(float64)
+		return ok && num == math.Trunc(num)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	}
+	return jsonType(value) == typ
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func formatTypes(types interface{}) string {
+	if list, ok := types.([]interface{}); ok {
+		names := make([]string, 0, len(list))
+		for _, t := range list {
+			names = append(names, fmt.Sprint(t))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(types)
+}
+
+func inEnum(value interface{}, enum []interface{}) bool {
+	for _, e := range enum {
+		if formatDiffValue(e) == formatDiffValue(value) && jsonType(e) == jsonType(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// itemKind names the kind of the rule tree item at the path, from the array holding it
+func itemKind(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) >= 2 {
+		switch parts[len(parts)-2] {
+		case "behaviors":
+			return "behavior"
+		case "criteria":
+			return "criterion"
+		}
+	}
+	return "item"
+}
+
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// validateRulesSchema validates the rules against the rule format schema, if it is available, at validation time.
+// The rules are validated only if they declare their ruleFormat, as the rule_format attribute is not known here
+func validateRulesSchema(val interface{}, path cty.Path) diag.Diagnostics {
+	rules, ok := val.(string)
+	if !ok || rules == "" {
+		return nil
+	}
+	var doc struct {
+		RuleFormat string `json:"ruleFormat"`
+	}
+	if err := json.Unmarshal([]byte(rules), &doc); err != nil || doc.RuleFormat == "" {
+		return nil
+	}
+	s, err := loadRuleFormatSchema(ruleFormatSchemaDir(), "", doc.RuleFormat)
+	if errors.Is(err, ErrRuleFormatSchemaNotFound) {
+		return nil
+	}
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       err.Error(),
+			AttributePath: path,
+		}}
+	}
+	if err := s.validateRulesJSON(rules); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("%s %s", ErrRulesSchemaValidation, doc.RuleFormat),
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+// validateLatestRuleFormat warns that the rules are not validated against a schema with the latest rule format,
+// which is resolved by PAPI only when the rules are updated
+func validateLatestRuleFormat(v interface{}, _ cty.Path) diag.Diagnostics {
+	if ruleFormat, ok := v.(string); ok && ruleFormat == "latest" {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "rules are not validated against the rule format schema",
+			Detail:   `the "latest" rule format has no schema, set a rule_format of the form vYYYY-MM-DD to validate the rules when planning`,
+		}}
+	}
+	return nil
+}
+
+// ruleFormatSchemaCustomDiff validates the rules against the schema of the rule format when planning.
+// A schema missing from the schema directory is fetched from PAPI once, if the product is known,
+// and the validation is skipped if no schema is available, which the apply warns about.
+// A schema in the schema directory which cannot be read or parsed fails the plan
+func ruleFormatSchemaCustomDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "ruleFormatSchemaCustomDiff")
+
+	if !d.NewValueKnown("rules") || !d.NewValueKnown("rule_format") || !d.NewValueKnown("product_id") {
+		return nil
+	}
+	rules, _ := d.Get("rules").(string)
+	ruleFormat, _ := d.Get("rule_format").(string)
+	productID, _ := d.Get("product_id").(string)
+	if rules == "" || ruleFormat == "" || ruleFormat == "latest" {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("rules", "rule_format") {
+		return nil
+	}
+
+	dir := ruleFormatSchemaDir()
+	s, err := loadRuleFormatSchema(dir, productID, ruleFormat)
+	if errors.Is(err, ErrRuleFormatSchemaNotFound) {
+		if s, err = fetchRuleFormatSchema(ctx, inst.Executor(meta), dir, productID, ruleFormat); err != nil {
+			logger.Warnf("rules are not validated against the %s schema: %s", ruleFormat, err)
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateRulesJSON(rules); err != nil {
+		return fmt.Errorf("%w %s:\n%s", ErrRulesSchemaValidation, ruleFormat, err)
+	}
+	return nil
+}
+
+// ruleFormatSchemaWarnings warns that the rules were not validated when planning because no schema of the rule format is available
+func ruleFormatSchemaWarnings(d *schema.ResourceData) diag.Diagnostics {
+	rules, _ := d.Get("rules").(string)
+	ruleFormat, _ := d.Get("rule_format").(string)
+	productID, _ := d.Get("product_id").(string)
+	if rules == "" || ruleFormat == "" || ruleFormat == "latest" {
+		return nil
+	}
+	if _, err := loadRuleFormatSchema(ruleFormatSchemaDir(), productID, ruleFormat); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "rules are not validated against the rule format schema",
+			Detail:   fmt.Sprintf("%s. Add the schema to the %s directory or set product_id to fetch it from PAPI", err, RuleFormatSchemaDirEnv),
+		}}
+	}
+	return nil
+}
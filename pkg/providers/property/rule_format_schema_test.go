@@ -0,0 +1,254 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+const testRuleFormatSchemaDir = "testdata/TestRuleFormatSchema"
+
+func TestValidateRulesJSON(t *testing.T) {
+	tests := map[string]struct {
+		productID string
+		rules     string
+		expected  []string
+	}{
+		"valid rules": {
+			rules: `{"rules":{"name":"default","behaviors":[{"name":"origin","options":{"hostname":"origin.example.com","httpPort":80}}],
+				"children":[{"name":"Static","criteriaMustSatisfy":"any","criteria":[{"name":"fileExtension","options":{"values":["css"]}}],
+				"behaviors":[{"name":"caching","options":{"behavior":"MAX_AGE","ttl":"7d"}}]}]}}`,
+		},
+		"declared rule format": {
+			rules: `{"ruleFormat":"v2023-01-05","rules":{"name":"default"}}`,
+		},
+		"unknown behavior and bad option type": {
+			rules: `{"rules":{"name":"default","behaviors":[{"name":"origin","options":{"hostname":"origin.example.com","httpPort":"80"}},{"name":"cachng","options":{}}]}}`,
+			expected: []string{
+				"/rules/behaviors/0/options/httpPort: expected integer, got string",
+				`/rules/behaviors/1: unknown behavior "cachng"`,
+			},
+		},
+		"nested rule errors": {
+			rules: `{"rules":{"name":"default","children":[{"name":"Static","criteria":[{"name":"path","options":{}}],
+				"behaviors":[{"name":"caching","options":{"ttl":"7 days","maxAge":1}}]}]}}`,
+			expected: []string{
+				`/rules/children/0/behaviors/0/options: missing required property "behavior"`,
+				`/rules/children/0/behaviors/0/options/maxAge: unknown property "maxAge"`,
+				`/rules/children/0/behaviors/0/options/ttl: value "7 days" does not match ^[0-9]+[smhd]$`,
+				`/rules/children/0/criteria/0: unknown criterion "path"`,
+			},
+		},
+		"missing rules": {
+			rules:    `{"comments":"no rules"}`,
+			expected: []string{`/: missing required property "rules"`},
+		},
+		"product specific behavior": {
+			rules:    `{"rules":{"name":"default","behaviors":[{"name":"prefreshCache","options":{"enabled":true}}]}}`,
+			expected: []string{`/rules/behaviors/0: unknown behavior "prefreshCache"`},
+		},
+		"product schema": {
+			productID: "prd_Fresca",
+			rules:     `{"rules":{"name":"default","behaviors":[{"name":"prefreshCache","options":{"enabled":true}}]}}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := loadRuleFormatSchema(testRuleFormatSchemaDir, test.productID, "v2023-01-05")
+			require.NoError(t, err)
+
+			err = s.validateRulesJSON(test.rules)
+			if test.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var errs ruleSchemaErrors
+			require.True(t, errors.As(err, &errs), "unexpected error: %s", err)
+			var messages []string
+			for _, e := range errs {
+				messages = append(messages, e.Error())
+			}
+			assert.Equal(t, test.expected, messages)
+		})
+	}
+}
+
+func TestLoadRuleFormatSchema(t *testing.T) {
+	tests := map[string]struct {
+		productID  string
+		ruleFormat string
+		withError  error
+	}{
+		"rule format schema": {
+			ruleFormat: "v2023-01-05",
+		},
+		"product without schema": {
+			productID:  "prd_SPM",
+			ruleFormat: "v2023-01-05",
+		},
+		"missing rule format": {
+			ruleFormat: "v2020-03-04",
+			withError:  ErrRuleFormatSchemaNotFound,
+		},
+		"latest rule format": {
+			ruleFormat: "latest",
+			withError:  ErrRuleFormatSchemaNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := loadRuleFormatSchema(testRuleFormatSchemaDir, test.productID, test.ruleFormat)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, s)
+		})
+	}
+}
+
+func TestFetchRuleFormatSchema(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join(testRuleFormatSchemaDir, "v2023-01-05.json"))
+	require.NoError(t, err)
+	exec := &mockExecutor{}
+	exec.On("Exec", http.MethodGet, "/papi/v1/schemas/products/prd_Fresca/v2023-01-05", "").
+		Return(http.StatusOK, string(data), nil).Once()
+	dir := t.TempDir()
+
+	s, err := fetchRuleFormatSchema(context.Background(), exec, dir, "Fresca", "v2023-01-05")
+	require.NoError(t, err)
+	assert.NotNil(t, s)
+	exec.AssertExpectations(t)
+
+	cached, err := loadRuleFormatSchema(dir, "prd_Fresca", "v2023-01-05")
+	require.NoError(t, err)
+	assert.Equal(t, s, cached)
+	_, err = ioutil.ReadFile(filepath.Join(dir, "prd_Fresca", "v2023-01-05.json"))
+	assert.NoError(t, err)
+
+	_, err = fetchRuleFormatSchema(context.Background(), exec, dir, "", "v2023-01-05")
+	assert.True(t, errors.Is(err, ErrRuleFormatSchemaNotFound))
+
+	exec = &mockExecutor{}
+	exec.On("Exec", http.MethodGet, "/papi/v1/schemas/products/prd_Fresca/v2022-10-18", "").
+		Return(http.StatusNotFound, `{"title": "Not Found", "status": 404}`, nil).Once()
+	_, err = fetchRuleFormatSchema(context.Background(), exec, dir, "prd_Fresca", "v2022-10-18")
+	assert.True(t, errors.Is(err, ErrRuleFormatSchema))
+	exec.AssertExpectations(t)
+}
+
+func TestRulesSchemaValidation(t *testing.T) {
+	tests := map[string]struct {
+		configPath string
+		withError  string
+	}{
+		"property rules": {
+			configPath: "property_invalid_rules.tf",
+			withError:  `(?s)rules do not match the rule format schema v2023-01-05.*httpPort: expected integer, got string.*unknown behavior "cachng"`,
+		},
+		"include rules": {
+			configPath: "include_invalid_rules.tf",
+			withError:  `(?s)rules do not match the rule format schema v2023-01-05.*/rules/children/0/behaviors/0/options/ttl`,
+		},
+		"rules declaring their rule format": {
+			configPath: "property_invalid_rules_declared_format.tf",
+			withError:  `unknown criterion "path"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(RuleFormatSchemaDirEnv, testRuleFormatSchemaDir)
+			client := papi.Mock{}
+			useClient(&client, nil, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config:      loadFixtureString(filepath.Join(testRuleFormatSchemaDir, test.configPath)),
+							ExpectError: regexp.MustCompile(test.withError),
+						},
+					},
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRulesSchemaValidation_invalidSchema(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "v2023-01-05.json"), []byte(`{"definitions":`), 0644))
+	t.Setenv(RuleFormatSchemaDirEnv, dir)
+
+	client := papi.Mock{}
+	useClient(&client, nil, func() {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      loadFixtureString(filepath.Join(testRuleFormatSchemaDir, "property_invalid_rules.tf")),
+					ExpectError: regexp.MustCompile(`rule format schema: .*v2023-01-05.json: unexpected end of JSON input`),
+				},
+			},
+		})
+	})
+	client.AssertExpectations(t)
+}
+
+func TestValidateLatestRuleFormat(t *testing.T) {
+	diags := validateLatestRuleFormat("latest", nil)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Nil(t, validateLatestRuleFormat("v2023-01-05", nil))
+}
+
+func TestRuleFormatSchemaWarnings(t *testing.T) {
+	tests := map[string]struct {
+		ruleFormat string
+		warning    bool
+	}{
+		"schema available": {
+			ruleFormat: "v2023-01-05",
+		},
+		"schema missing": {
+			ruleFormat: "v2020-03-04",
+			warning:    true,
+		},
+		"latest rule format is warned about when validating": {
+			ruleFormat: "latest",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(RuleFormatSchemaDirEnv, testRuleFormatSchemaDir)
+			d := schema.TestResourceDataRaw(t, resourcePropertyInclude().Schema, map[string]interface{}{
+				"rule_format": test.ruleFormat,
+				"rules":       `{"rules": {"name": "default"}}`,
+			})
+			diags := ruleFormatSchemaWarnings(d)
+			if !test.warning {
+				assert.Empty(t, diags)
+				return
+			}
+			require.Len(t, diags, 1)
+			assert.Equal(t, diag.Warning, diags[0].Severity)
+			assert.Contains(t, diags[0].Detail, "rule format schema not found: v2020-03-04")
+		})
+	}
+}
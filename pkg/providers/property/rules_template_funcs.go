@@ -0,0 +1,272 @@
+package property
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// omittedValue is rendered in place of a snippet which was not included, and removed together with its comma afterwards
+const omittedValue = `"#omitted#"`
+
+var (
+	includeIfRegexp = regexp.MustCompile(`"#includeIf:env\.(\w+):([^"]+)"`)
+	forEachRegexp   = regexp.MustCompile(`"#forEach:env\.(\w+):([^"]+)"`)
+	funcRegexp      = regexp.MustCompile(`"\${(lower|join|default|toJson) ([^"}]+)}"`)
+	funcArgRegexp   = regexp.MustCompile(`'[^']*'|\S+`)
+	envRefRegexp    = regexp.MustCompile(`^env\.(\w+)((\.\w+)*)$`)
+	literalRegexp   = regexp.MustCompile(`^(-?\d+(\.\d+)?|true|false)$`)
+
+	omittedFirstRegexp = regexp.MustCompile(regexp.QuoteMeta(omittedValue) + `\s*,\s*`)
+	omittedRegexp      = regexp.MustCompile(`,?\s*` + regexp.QuoteMeta(omittedValue))
+)
+
+var (
+	// ErrTemplateFunc is used to specify an invalid call of a template function.
+	ErrTemplateFunc = errors.New("template function")
+)
+
+// templateObject is an object from a jsonBlock list, with fields accessible from the template and JSON encoded values
+type templateObject map[string]interface{}
+
+// String returns the object as JSON, so that it is printed as such in the template
+func (o templateObject) String() string {
+	value, err := decodeTemplateValue(o)
+	if err != nil {
+		return "null"
+	}
+	str, err := marshalValue(value)
+	if err != nil {
+		return "null"
+	}
+	return str
+}
+
+// templateFuncs returns the functions available in property snippets.
+// Variables are held in the template as JSON, so the functions accept and return JSON values
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"lower":    templateLower,
+		"join":     templateJoin,
+		"default":  templateDefault,
+		"toJson":   templateToJSON,
+		"isTrue":   templateIsTrue,
+		"items":    templateItems,
+		"withItem": templateWithItem,
+		"omit":     func() string { return omittedValue },
+	}
+}
+
+// templateLower returns the string value in lower case
+func templateLower(val interface{}) (string, error) {
+	decoded, err := decodeTemplateValue(val)
+	if err != nil {
+		return "", err
+	}
+	str, ok := decoded.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: lower: expected string, got %s", ErrTemplateFunc, valueJSONType(decoded))
+	}
+	return marshalValue(strings.ToLower(str))
+}
+
+// templateJoin joins elements of the list value with the given separator into a single string
+func templateJoin(sep, list interface{}) (string, error) {
+	decodedSep, err := decodeTemplateValue(sep)
+	if err != nil {
+		return "", err
+	}
+	sepStr, ok := decodedSep.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: join: expected string separator, got %s", ErrTemplateFunc, valueJSONType(decodedSep))
+	}
+	decoded, err := decodeTemplateValue(list)
+	if err != nil {
+		return "", err
+	}
+	elements, ok := decoded.([]interface{})
+	if !ok {
+		return "", fmt.Errorf("%w: join: expected array, got %s", ErrTemplateFunc, valueJSONType(decoded))
+	}
+	values := make([]string, 0, len(elements))
+	for _, element := range elements {
+		if str, ok := element.(string); ok {
+			values = append(values, str)
+			continue
+		}
+		str, err := marshalValue(element)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, str)
+	}
+	return marshalValue(strings.Join(values, sepStr))
+}
+
+// templateDefault returns the given default when the value is null or an empty string
+func templateDefault(def, val interface{}) (string, error) {
+	decoded, err := decodeTemplateValue(val)
+	if err != nil {
+		return "", err
+	}
+	if decoded == nil || decoded == "" {
+		return templateText(def)
+	}
+	return templateText(val)
+}
+
+// templateToJSON returns the value encoded as a JSON string, e.g. {"a":1} becomes "{\"a\":1}"
+func templateToJSON(val interface{}) (string, error) {
+	decoded, err := decodeTemplateValue(val)
+	if err != nil {
+		return "", err
+	}
+	str, err := marshalValue(decoded)
+	if err != nil {
+		return "", err
+	}
+	return marshalValue(str)
+}
+
+// templateIsTrue returns the boolean value, treating null as false
+func templateIsTrue(val interface{}) (bool, error) {
+	decoded, err := decodeTemplateValue(val)
+	if err != nil {
+		return false, err
+	}
+	switch v := decoded.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	}
+	return false, fmt.Errorf("%w: #includeIf: expected bool, got %s", ErrTemplateFunc, valueJSONType(decoded))
+}
+
+// templateItems returns elements of the list value, treating null as an empty list
+func templateItems(val interface{}) ([]interface{}, error) {
+	decoded, err := decodeTemplateValue(val)
+	if err != nil {
+		return nil, err
+	}
+	if decoded == nil {
+		return nil, nil
+	}
+	elements, ok := decoded.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: #forEach: expected array, got %s", ErrTemplateFunc, valueJSONType(decoded))
+	}
+	items := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		item, err := templateItem(element)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// templateWithItem returns a copy of the variables with 'item' set to the given element of a list
+func templateWithItem(vars map[string]interface{}, item interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(vars)+1)
+	for name, value := range vars {
+		result[name] = value
+	}
+	result["item"] = item
+	return result
+}
+
+func templateItem(element interface{}) (interface{}, error) {
+	obj, ok := element.(map[string]interface{})
+	if !ok {
+		return marshalValue(element)
+	}
+	item := make(templateObject, len(obj))
+	for name, value := range obj {
+		v, err := templateItem(value)
+		if err != nil {
+			return nil, err
+		}
+		item[name] = v
+	}
+	return item, nil
+}
+
+// templateText returns the value as JSON
+func templateText(val interface{}) (string, error) {
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	case templateObject:
+		return v.String(), nil
+	}
+	return marshalValue(val)
+}
+
+// decodeTemplateValue decodes a value held in the template. Strings are JSON, objects hold JSON values in their fields
+func decodeTemplateValue(val interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case string:
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(v), &decoded); err != nil {
+			return nil, fmt.Errorf("%w: not a JSON value: %s", ErrUnmarshal, v)
+		}
+		return decoded, nil
+	case templateObject:
+		result := make(map[string]interface{}, len(v))
+		for name, value := range v {
+			decoded, err := decodeTemplateValue(value)
+			if err != nil {
+				return nil, err
+			}
+			result[name] = decoded
+		}
+		return result, nil
+	}
+	return val, nil
+}
+
+// funcToTemplate converts arguments of a template function call to go template syntax.
+// Only variables, 'quoted' strings, numbers and booleans are accepted
+func funcToTemplate(name, args string) (string, error) {
+	converted := []string{name}
+	for _, arg := range funcArgRegexp.FindAllString(args, -1) {
+		switch {
+		case envRefRegexp.MatchString(arg):
+			converted = append(converted, strings.TrimPrefix(arg, "env"))
+		case strings.HasPrefix(arg, "'") && strings.HasSuffix(arg, "'") && len(arg) > 1:
+			str, err := marshalValue(strings.Trim(arg, "'"))
+			if err != nil {
+				return "", err
+			}
+			converted = append(converted, strconv.Quote(str))
+		case literalRegexp.MatchString(arg):
+			converted = append(converted, arg)
+		default:
+			return "", fmt.Errorf("%w: invalid argument of %s: %s", ErrTemplateFunc, name, arg)
+		}
+	}
+	return strings.Join(converted, " "), nil
+}
+
+// funcEnvRefs returns names of the variables passed to a template function call
+func funcEnvRefs(args string) []string {
+	var names []string
+	for _, arg := range funcArgRegexp.FindAllString(args, -1) {
+		if match := envRefRegexp.FindStringSubmatch(arg); match != nil {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// removeOmitted removes the values rendered for snippets which were not included, along with their commas
+func removeOmitted(rendered []byte) []byte {
+	rendered = omittedFirstRegexp.ReplaceAll(rendered, nil)
+	return omittedRegexp.ReplaceAll(rendered, nil)
+}
@@ -0,0 +1,158 @@
+package property
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	tests := map[string]struct {
+		call      func() (interface{}, error)
+		expected  interface{}
+		withError error
+	}{
+		"lower": {
+			call:     func() (interface{}, error) { return templateLower(`"WWW.Example.com"`) },
+			expected: `"www.example.com"`,
+		},
+		"lower of a number": {
+			call:      func() (interface{}, error) { return templateLower(float64(1)) },
+			withError: ErrTemplateFunc,
+		},
+		"join": {
+			call:     func() (interface{}, error) { return templateJoin(`", "`, `["a", 1, true]`) },
+			expected: `"a, 1, true"`,
+		},
+		"join of a string": {
+			call:      func() (interface{}, error) { return templateJoin(`","`, `"abc"`) },
+			withError: ErrTemplateFunc,
+		},
+		"default of null": {
+			call:     func() (interface{}, error) { return templateDefault(`"none"`, "null") },
+			expected: `"none"`,
+		},
+		"default of empty string": {
+			call:     func() (interface{}, error) { return templateDefault(1, `""`) },
+			expected: "1",
+		},
+		"default of a value": {
+			call:     func() (interface{}, error) { return templateDefault(`"none"`, false) },
+			expected: "false",
+		},
+		"toJson": {
+			call:     func() (interface{}, error) { return templateToJSON(`{"a": "<b>"}`) },
+			expected: `"{\"a\":\"<b>\"}"`,
+		},
+		"toJson of an item": {
+			call:     func() (interface{}, error) { return templateToJSON(templateObject{"a": `"b"`}) },
+			expected: `"{\"a\":\"b\"}"`,
+		},
+		"isTrue of null": {
+			call:     func() (interface{}, error) { return templateIsTrue("null") },
+			expected: false,
+		},
+		"isTrue of a string": {
+			call:      func() (interface{}, error) { return templateIsTrue(`"true"`) },
+			withError: ErrTemplateFunc,
+		},
+		"items": {
+			call: func() (interface{}, error) {
+				return templateItems(`[{"name": "a", "tags": ["x"], "meta": {"id": 1}}, "b"]`)
+			},
+			expected: []interface{}{
+				templateObject{"name": `"a"`, "tags": `["x"]`, "meta": templateObject{"id": "1"}},
+				`"b"`,
+			},
+		},
+		"items of null": {
+			call:     func() (interface{}, error) { return templateItems("null") },
+			expected: []interface{}(nil),
+		},
+		"items of an object": {
+			call:      func() (interface{}, error) { return templateItems(`{"a": 1}`) },
+			withError: ErrTemplateFunc,
+		},
+		"invalid JSON value": {
+			call:      func() (interface{}, error) { return templateLower("abc") },
+			withError: ErrUnmarshal,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := test.call()
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestTemplateObjectString(t *testing.T) {
+	obj := templateObject{"name": `"a & b"`, "meta": templateObject{"id": "1"}}
+	assert.Equal(t, `{"meta":{"id":1},"name":"a & b"}`, obj.String())
+}
+
+func TestFuncToTemplate(t *testing.T) {
+	tests := map[string]struct {
+		name      string
+		args      string
+		expected  string
+		withError error
+	}{
+		"variables": {
+			name:     "join",
+			args:     "env.separator env.item.paths",
+			expected: "join .separator .item.paths",
+		},
+		"quoted string": {
+			name:     "default",
+			args:     `'No "comments", sorry' env.comments`,
+			expected: `default "\"No \\\"comments\\\", sorry\"" .comments`,
+		},
+		"number and boolean": {
+			name:     "default",
+			args:     "-1.5 true",
+			expected: "default -1.5 true",
+		},
+		"template syntax is rejected": {
+			name:      "lower",
+			args:      "(print .name)",
+			withError: ErrTemplateFunc,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := funcToTemplate(test.name, test.args)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestRemoveOmitted(t *testing.T) {
+	tests := map[string]struct {
+		given    string
+		expected string
+	}{
+		"only element":  {given: `["#omitted#"]`, expected: `[]`},
+		"first element": {given: `["#omitted#", "a"]`, expected: `["a"]`},
+		"last element":  {given: `["a", "#omitted#"]`, expected: `["a"]`},
+		"in the middle": {given: "[\n  \"a\",\n  \"#omitted#\",\n  \"b\"\n]", expected: "[\n  \"a\",\n  \"b\"\n]"},
+		"all elements":  {given: `["#omitted#", "#omitted#"]`, expected: `[]`},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, string(removeOmitted([]byte(test.given))))
+		})
+	}
+}
@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_rules_builder" "default" {
+  rule_format = "v2023-01-05"
+  rules {
+    name     = "default"
+    children = [jsonencode({ name = "Static content", behaviour = [] })]
+  }
+}
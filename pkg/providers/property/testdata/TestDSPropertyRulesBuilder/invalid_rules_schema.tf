@@ -0,0 +1,17 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_rules_builder" "default" {
+  rule_format = "v2023-01-05"
+  rules {
+    name = "default"
+    behavior {
+      name = "caching"
+      options = jsonencode({
+        behavior = "MAX_AGE"
+        ttl      = "7 days"
+      })
+    }
+  }
+}
@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_rules_builder" "default" {
+  rule_format = "v2023-01-05"
+  rules {
+    name = "default"
+    variable {
+      name  = "origin"
+      value = "origin.example.com"
+    }
+  }
+}
@@ -0,0 +1,67 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_rules_builder" "default" {
+  rule_format = "v2023-01-05"
+  rules {
+    name      = "default"
+    is_secure = false
+    comments  = "The behaviors in the default rule apply to all requests"
+    variable {
+      name        = "PMUSER_ORIGIN"
+      value       = "origin.example.com"
+      description = "The origin hostname"
+      hidden      = true
+      sensitive   = false
+    }
+    behavior {
+      name = "origin"
+      options = jsonencode({
+        originType         = "CUSTOMER"
+        hostname           = "origin.example.com"
+        forwardHostHeader  = "REQUEST_HOST_HEADER"
+        httpPort           = 80
+        enableTrueClientIp = false
+      })
+    }
+    behavior {
+      name = "cpCode"
+      options = jsonencode({
+        value = {
+          id = 12345
+        }
+      })
+    }
+    children = [
+      data.akamai_property_rules_builder.static.json,
+    ]
+  }
+}
+
+data "akamai_property_rules_builder" "static" {
+  rule_format = "v2023-01-05"
+  rules {
+    name                  = "Static content"
+    criteria_must_satisfy = "any"
+    criterion {
+      name = "fileExtension"
+      options = jsonencode({
+        matchOperator      = "IS_ONE_OF"
+        values             = ["css", "js"]
+        matchCaseSensitive = false
+      })
+    }
+    behavior {
+      name = "caching"
+      options = jsonencode({
+        behavior       = "MAX_AGE"
+        mustRevalidate = false
+        ttl            = "7d"
+      })
+    }
+    behavior {
+      name = "prefreshCache"
+    }
+  }
+}
@@ -0,0 +1,32 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_rules_template" "test" {
+  template_file = "testdata/TestDSRulesTemplate/functions/property-snippets/main.json"
+  variables {
+    name  = "name"
+    value = "WWW.Example.com"
+    type  = "string"
+  }
+  variables {
+    name  = "comments"
+    value = ""
+    type  = "string"
+  }
+  variables {
+    name  = "debug"
+    value = "false"
+    type  = "bool"
+  }
+  variables {
+    name  = "origins"
+    value = jsonencode([{ name = "Images", hostname = "images.example.com", paths = ["/img/*", "/media/*"] }, { name = "API", hostname = "api.example.com", paths = ["/api/*"] }])
+    type  = "jsonBlock"
+  }
+  variables {
+    name  = "tags"
+    value = jsonencode({ team = "web" })
+    type  = "jsonBlock"
+  }
+}
@@ -0,0 +1,32 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_rules_template" "test" {
+  template_file = "testdata/TestDSRulesTemplate/functions/property-snippets/main.json"
+  variables {
+    name  = "name"
+    value = "WWW.Example.com"
+    type  = "string"
+  }
+  variables {
+    name  = "comments"
+    value = "Debug & static"
+    type  = "string"
+  }
+  variables {
+    name  = "debug"
+    value = "true"
+    type  = "bool"
+  }
+  variables {
+    name  = "origins"
+    value = "[]"
+    type  = "jsonBlock"
+  }
+  variables {
+    name  = "tags"
+    value = jsonencode({ team = "web" })
+    type  = "jsonBlock"
+  }
+}
@@ -0,0 +1,22 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_rules_template" "test" {
+  template {
+    template_data = <<EOT
+{
+  "rules": {
+    "name": "$${lower .name}",
+    "children": []
+  }
+}
+EOT
+    template_dir  = "testdata/TestDSRulesTemplate/functions/property-snippets"
+  }
+  variables {
+    name  = "name"
+    value = "default"
+    type  = "string"
+  }
+}
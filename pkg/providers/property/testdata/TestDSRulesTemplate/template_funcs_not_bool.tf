@@ -0,0 +1,24 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_rules_template" "test" {
+  template {
+    template_data = <<EOT
+{
+  "rules": {
+    "name": "default",
+    "children": [
+      "#includeIf:env.debug:snippets/debug.json"
+    ]
+  }
+}
+EOT
+    template_dir  = "testdata/TestDSRulesTemplate/functions/property-snippets"
+  }
+  variables {
+    name  = "debug"
+    value = "yes"
+    type  = "string"
+  }
+}
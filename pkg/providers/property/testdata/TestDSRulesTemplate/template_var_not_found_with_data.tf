@@ -0,0 +1,23 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_rules_template" "test" {
+  template {
+    template_data = <<EOT
+{
+  "rules": {
+    "name": "$${env.name}",
+    "comments": "$${env.comments}",
+    "children": []
+  }
+}
+EOT
+    template_dir  = "testdata/TestDSRulesTemplate/rules/property-snippets"
+  }
+  variables {
+    name  = "name"
+    value = "default"
+    type  = "string"
+  }
+}
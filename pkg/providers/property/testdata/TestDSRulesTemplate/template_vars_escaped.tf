@@ -0,0 +1,28 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_rules_template" "test" {
+  template {
+    template_data = <<EOT
+{
+  "rules": {
+    "name": "$${env.name}",
+    "comments": "$${env.comments}",
+    "children": []
+  }
+}
+EOT
+    template_dir  = "testdata/TestDSRulesTemplate/rules/property-snippets"
+  }
+  variables {
+    name  = "name"
+    value = "default"
+    type  = "string"
+  }
+  variables {
+    name  = "comments"
+    value = "Say \"hi\" from C:\\temp & <b>bold</b>"
+    type  = "string"
+  }
+}
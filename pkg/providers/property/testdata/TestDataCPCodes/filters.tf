@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_cp_codes" "test" {
+  contract_id = "ctr_1"
+  group_id    = "grp_2"
+  name_regex  = "^static-"
+  product_id  = "SPM"
+}
@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_edge_hostnames" "test" {
+  contract_id   = "ctr_1"
+  group_id      = "grp_2"
+  domain_suffix = "edgekey.net"
+  ip_behavior   = "IPV6_COMPLIANCE"
+  secure        = true
+}
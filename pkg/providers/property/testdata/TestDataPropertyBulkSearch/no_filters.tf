@@ -0,0 +1,7 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_bulk_search" "test" {
+  match = "$..behaviors[?(@.name == 'origin')]"
+}
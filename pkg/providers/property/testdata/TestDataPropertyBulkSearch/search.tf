@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_bulk_search" "test" {
+  contract_id = "1"
+  group_id    = "grp_2"
+  match       = "$..behaviors[?(@.name == 'origin')]"
+}
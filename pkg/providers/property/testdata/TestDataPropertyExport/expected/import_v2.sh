@@ -0,0 +1,5 @@
+terraform import akamai_cp_code.www_example_com cpc_123,ctr_1,grp_2
+terraform import akamai_edge_hostname.www_example_com_edgesuite_net ehn_1,ctr_1,grp_2
+terraform import akamai_property.www_example_com prp_1,ctr_1,grp_2,2
+terraform import akamai_property_activation.www_example_com_staging prp_1:STAGING:v2
+terraform import akamai_property_activation.www_example_com_production prp_1:PRODUCTION:v1
@@ -0,0 +1,61 @@
+resource "akamai_cp_code" "www_example_com" {
+  name        = "www.example.com"
+  contract_id = "ctr_1"
+  group_id    = "grp_2"
+  product_id  = "prd_Fresca"
+}
+
+resource "akamai_edge_hostname" "www_example_com_edgesuite_net" {
+  contract_id   = "ctr_1"
+  group_id      = "grp_2"
+  product_id    = "prd_Fresca"
+  edge_hostname = "www.example.com.edgesuite.net"
+  ip_behavior   = "IPV6_COMPLIANCE"
+}
+
+data "akamai_property_rules_template" "www_example_com" {
+  template_file = abspath("${path.module}/property-snippets/main.json")
+}
+
+resource "akamai_property" "www_example_com" {
+  name        = "www.example.com"
+  contract_id = "ctr_1"
+  group_id    = "grp_2"
+  product_id  = "prd_Fresca"
+  rule_format = "v2023-01-05"
+
+  hostnames {
+    cname_from             = "www.example.com"
+    cname_to               = akamai_edge_hostname.www_example_com_edgesuite_net.edge_hostname
+    cert_provisioning_type = "CPS_MANAGED"
+  }
+
+  hostnames {
+    cname_from             = "example.com"
+    cname_to               = akamai_edge_hostname.www_example_com_edgesuite_net.edge_hostname
+    cert_provisioning_type = "CPS_MANAGED"
+  }
+
+  hostnames {
+    cname_from             = "static.example.com"
+    cname_to               = "static.other.net"
+    cert_provisioning_type = "DEFAULT"
+  }
+
+  rules = data.akamai_property_rules_template.www_example_com.json
+}
+
+resource "akamai_property_activation" "www_example_com_staging" {
+  property_id = akamai_property.www_example_com.id
+  network     = "STAGING"
+  version     = 2
+  contact     = ["dev@example.com", "ops@example.com"]
+  note        = "staging rollout"
+}
+
+resource "akamai_property_activation" "www_example_com_production" {
+  property_id = akamai_property.www_example_com.id
+  network     = "PRODUCTION"
+  version     = 1
+  contact     = ["ops@example.com"]
+}
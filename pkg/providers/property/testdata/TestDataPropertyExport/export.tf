@@ -0,0 +1,7 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_export" "test" {
+  property_id = "prp_1"
+}
@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+data "akamai_property_export" "test" {
+  property_id = "1"
+  contract_id = "ctr_1"
+  group_id    = "grp_2"
+  version     = 2
+}
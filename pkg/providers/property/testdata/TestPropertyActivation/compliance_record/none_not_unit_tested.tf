@@ -0,0 +1,17 @@
+provider "akamai" {
+  edgerc                    = "../../test/edgerc"
+  require_compliance_record = true
+}
+
+resource "akamai_property_activation" "test" {
+  property_id = "test"
+  contact     = ["user@example.com"]
+  version     = 1
+  network     = "PRODUCTION"
+
+  compliance_record {
+    noncompliance_reason = "NONE"
+    customer_email       = "user@example.com"
+    peer_reviewed_by     = "reviewer@example.com"
+  }
+}
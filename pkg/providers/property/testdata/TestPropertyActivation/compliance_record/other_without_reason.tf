@@ -0,0 +1,15 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_property_activation" "test" {
+  property_id = "test"
+  contact     = ["user@example.com"]
+  version     = 1
+  network     = "PRODUCTION"
+
+  compliance_record {
+    noncompliance_reason = "OTHER"
+    ticket_id            = "CHG-123"
+  }
+}
@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc                    = "../../test/edgerc"
+  require_compliance_record = true
+}
+
+resource "akamai_property_activation" "test" {
+  property_id = "test"
+  contact     = ["user@example.com"]
+  version     = 1
+  network     = "PRODUCTION"
+}
@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+  defaults {
+    notification_emails    = ["user@example.com"]
+    activation_note_prefix = "[terraform] "
+  }
+}
+
+resource "akamai_property_activation" "test" {
+  property_id                    = "test"
+  version                        = 1
+  auto_acknowledge_rule_warnings = true
+  note                           = "property activation note for creating"
+}
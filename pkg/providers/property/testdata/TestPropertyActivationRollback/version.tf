@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_property_activation_rollback" "test" {
+  property_id = "prp_test"
+  network     = "PRODUCTION"
+  version     = 1
+  contact     = ["user@example.com"]
+  note        = "roll back"
+}
@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_cp_code" "test" {
+  name         = "test cpcode"
+  contract_id  = "ctr_1"
+  group_id     = "grp_1"
+  product_id   = "prd_1"
+  purgeable    = true
+  time_zone_id = "GMT+1"
+}
@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_cp_code_reporting_group" "test" {
+  name        = "renamed reporting group"
+  contract_id = "ctr_1-ABC"
+  group_id    = "grp_2"
+  cp_codes    = ["cpc_123"]
+}
@@ -0,0 +1,19 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_property_bulk_activation" "test" {
+  network = "PRODUCTION"
+  contact = ["user@example.com"]
+  note    = "bulk activation"
+
+  property {
+    property_id = "prp_1"
+    version     = 3
+  }
+
+  property {
+    property_id = "2"
+    version     = 5
+  }
+}
@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc                    = "../../test/edgerc"
+  require_compliance_record = true
+}
+
+resource "akamai_property_bulk_activation" "test" {
+  network = "PRODUCTION"
+  contact = ["user@example.com"]
+
+  property {
+    property_id = "prp_1"
+    version     = 3
+  }
+}
@@ -0,0 +1,15 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_property_bulk_patch" "test" {
+  contract_id = "ctr_1"
+  group_id    = "grp_2"
+  match       = "$..behaviors[?(@.name == 'origin')]"
+
+  patch {
+    op    = "replace"
+    path  = "/options/hostname"
+    value = jsonencode("origin.example.com")
+  }
+}
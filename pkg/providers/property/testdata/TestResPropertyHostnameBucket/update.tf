@@ -0,0 +1,22 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_property_hostname_bucket" "test" {
+  property_id   = "prp_1"
+  contract_id   = "ctr_1"
+  group_id      = "grp_2"
+  network       = "STAGING"
+  notify_emails = ["user@example.com"]
+  note          = "onboard customers"
+
+  hostnames {
+    cname_from = "a.example.com"
+    cname_to   = "example.com.edgekey.net"
+  }
+  hostnames {
+    cname_from             = "c.example.com"
+    cname_to               = "example.com.edgesuite.net"
+    cert_provisioning_type = "DEFAULT"
+  }
+}
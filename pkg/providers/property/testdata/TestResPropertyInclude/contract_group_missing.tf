@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_property_include" "test" {
+  name        = "test include"
+  type        = "MICROSERVICES"
+  rule_format = "v2022-06-28"
+  rules       = "{}"
+}
@@ -0,0 +1,27 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_property_include" "test" {
+  contract_id = "ctr_0"
+  group_id    = "grp_0"
+  name        = "test_include"
+  type        = "MICROSERVICES"
+  rule_format = "v2023-01-05"
+  rules = jsonencode({
+    rules = {
+      name = "default"
+      children = [
+        {
+          name = "Static"
+          behaviors = [
+            {
+              name    = "caching"
+              options = { ttl = "7 days" }
+            },
+          ]
+        },
+      ]
+    }
+  })
+}
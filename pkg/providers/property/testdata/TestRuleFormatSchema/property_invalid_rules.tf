@@ -0,0 +1,26 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_property" "test" {
+  name        = "test_property"
+  contract_id = "ctr_0"
+  group_id    = "grp_0"
+  product_id  = "prd_Fresca"
+  rule_format = "v2023-01-05"
+  rules = jsonencode({
+    rules = {
+      name = "default"
+      behaviors = [
+        {
+          name    = "origin"
+          options = { hostname = "origin.example.com", httpPort = "80" }
+        },
+        {
+          name    = "cachng"
+          options = {}
+        },
+      ]
+    }
+  })
+}
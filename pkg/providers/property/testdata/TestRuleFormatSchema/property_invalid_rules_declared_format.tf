@@ -0,0 +1,22 @@
+provider "akamai" {
+  edgerc = "../../test/edgerc"
+}
+
+resource "akamai_property" "test" {
+  name        = "test_property"
+  contract_id = "ctr_0"
+  group_id    = "grp_0"
+  product_id  = "prd_Fresca"
+  rules = jsonencode({
+    ruleFormat = "v2023-01-05"
+    rules = {
+      name = "default"
+      criteria = [
+        {
+          name    = "path"
+          options = {}
+        },
+      ]
+    }
+  })
+}
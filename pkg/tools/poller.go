@@ -0,0 +1,220 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// PollIntervalKey is the name of the attribute setting the interval between status checks
+	PollIntervalKey = "poll_interval"
+
+	// PollTimeoutKey is the name of the attribute setting how long the status is polled
+	PollTimeoutKey = "poll_timeout"
+
+	// pollBackoffFactor is the factor by which the interval grows after every status check
+	pollBackoffFactor = 1.5
+
+	// pollMaxIntervalFactor limits the interval growth to a multiple of the initial interval
+	pollMaxIntervalFactor = 4
+
+	// pollTracerName is the instrumentation name of the polling spans
+	pollTracerName = "github.com/akamai/terraform-provider-akamai/pkg/tools"
+)
+
+var (
+	// ErrPollTimeout is returned when the terminal state is not reached within the poll timeout
+	ErrPollTimeout = fmt.Errorf("polling timed out: %w", context.DeadlineExceeded)
+)
+
+type (
+	// Poller repeatedly checks the status of a long running operation until it reaches a terminal state
+	Poller struct {
+		// Name describes the operation in the logs
+		Name string
+		// Interval is the time before the first status check
+		Interval time.Duration
+		// MaxInterval is the limit of the interval, which grows after every status check. The interval is constant if MaxInterval is not greater than Interval
+		MaxInterval time.Duration
+		// Timeout limits the polling. If it is zero, only the context limits the polling
+		Timeout time.Duration
+		// Log receives the progress of the polling
+		Log log.Interface
+	}
+
+	// PollFunc checks the status of the operation, returning true once it reached a successful terminal state
+	// and an error once it reached a failed terminal state
+	PollFunc func(ctx context.Context) (bool, error)
+
+	// detachedContext keeps the values of its parent, but not its deadline
+	detachedContext struct {
+		context.Context
+	}
+)
+
+// PollIntervalSchema returns the schema of the poll_interval attribute
+func PollIntervalSchema(defaultInterval time.Duration) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: ValidateDuration,
+		Description:      fmt.Sprintf("The initial interval between status checks, e.g. 30s. The interval grows slowly while waiting. Defaults to %s", defaultInterval),
+	}
+}
+
+// PollTimeoutSchema returns the schema of the poll_timeout attribute
+func PollTimeoutSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: ValidateDuration,
+		Description:      "How long to wait for the terminal state, e.g. 3h. When set, it replaces the timeout of the Terraform operation for the waiting",
+	}
+}
+
+// NewPoller creates the poller configured with the poll_interval and poll_timeout attributes of the resource
+//
+// the interval is never shorter than minInterval
+func NewPoller(name string, rd ResourceDataFetcher, defaultInterval, minInterval time.Duration, logger log.Interface) (*Poller, error) {
+	interval, err := getDurationValue(PollIntervalKey, rd)
+	if err != nil {
+		return nil, err
+	}
+	if interval == 0 {
+		interval = defaultInterval
+	}
+	interval = MaxDuration(interval, minInterval)
+
+	timeout, err := getDurationValue(PollTimeoutKey, rd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Poller{
+		Name:        name,
+		Interval:    interval,
+		MaxInterval: interval * pollMaxIntervalFactor,
+		Timeout:     timeout,
+		Log:         logger,
+	}, nil
+}
+
+// Poll waits for the interval and calls f until it reports a terminal state, the timeout passes or the context is done
+//
+// ErrPollTimeout is returned when the timeout passes or the context deadline is exceeded.
+// The polling is recorded in a span when the operation in ctx is traced
+func (p *Poller) Poll(ctx context.Context, f PollFunc) (err error) {
+	ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(pollTracerName).Start(ctx, fmt.Sprintf("poll %s", p.Name))
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = withDetachedTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	interval := p.Interval
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return p.contextError(ctx.Err(), time.Since(start))
+		}
+
+		span.SetAttributes(attribute.Int("poll.attempts", attempt))
+		done, err := f(ctx)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil {
+				return p.contextError(ctx.Err(), time.Since(start))
+			}
+			return err
+		}
+		if done {
+			p.logger().Debugf("%s: done after %d status checks in %s", p.Name, attempt, time.Since(start).Round(time.Second))
+			return nil
+		}
+		p.logger().Debugf("%s: still in progress after %d status checks in %s", p.Name, attempt, time.Since(start).Round(time.Second))
+
+		if p.MaxInterval > interval {
+			interval = time.Duration(float64(interval) * pollBackoffFactor)
+			if interval > p.MaxInterval {
+				interval = p.MaxInterval
+			}
+		}
+	}
+}
+
+func (p *Poller) contextError(err error, elapsed time.Duration) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		p.logger().Warnf("%s: timed out after %s", p.Name, elapsed.Round(time.Second))
+		return ErrPollTimeout
+	}
+	return err
+}
+
+func (p *Poller) logger() log.Interface {
+	if p.Log == nil {
+		return log.Log
+	}
+	return p.Log
+}
+
+// withDetachedTimeout returns a context with the given timeout, ignoring the deadline of the parent,
+// which is still cancelled when the parent is cancelled
+func withDetachedTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(detachedContext{parent}, timeout)
+	go func() {
+		select {
+		case <-parent.Done():
+			if errors.Is(parent.Err(), context.Canceled) {
+				cancel()
+			}
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// Deadline implements context.Context
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// Done implements context.Context
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+// Err implements context.Context
+func (detachedContext) Err() error {
+	return nil
+}
+
+func getDurationValue(key string, rd ResourceDataFetcher) (time.Duration, error) {
+	value, err := GetStringValue(key, rd)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %s", ErrInvalidType, key, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPoller(t *testing.T) {
+	tests := map[string]struct {
+		init             func(*mocked)
+		expectedInterval time.Duration
+		expectedTimeout  time.Duration
+		withError        error
+	}{
+		"defaults": {
+			init: func(m *mocked) {
+				m.On("GetOk", PollIntervalKey).Return("", false).Once()
+				m.On("GetOk", PollTimeoutKey).Return("", false).Once()
+			},
+			expectedInterval: time.Minute,
+		},
+		"configured": {
+			init: func(m *mocked) {
+				m.On("GetOk", PollIntervalKey).Return("2m", true).Once()
+				m.On("GetOk", PollTimeoutKey).Return("3h", true).Once()
+			},
+			expectedInterval: 2 * time.Minute,
+			expectedTimeout:  3 * time.Hour,
+		},
+		"interval below minimum": {
+			init: func(m *mocked) {
+				m.On("GetOk", PollIntervalKey).Return("1s", true).Once()
+				m.On("GetOk", PollTimeoutKey).Return("", false).Once()
+			},
+			expectedInterval: 10 * time.Second,
+		},
+		"invalid interval": {
+			init: func(m *mocked) {
+				m.On("GetOk", PollIntervalKey).Return("often", true).Once()
+			},
+			withError: ErrInvalidType,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := &mocked{}
+			test.init(m)
+			poller, err := NewPoller("test", m, time.Minute, 10*time.Second, nil)
+			m.AssertExpectations(t)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedInterval, poller.Interval)
+			assert.Equal(t, 4*test.expectedInterval, poller.MaxInterval)
+			assert.Equal(t, test.expectedTimeout, poller.Timeout)
+		})
+	}
+}
+
+func TestPoll(t *testing.T) {
+	failure := errors.New("activation failed")
+	tests := map[string]struct {
+		results       []bool
+		err           error
+		timeout       time.Duration
+		expectedCalls int
+		withError     error
+	}{
+		"done": {
+			results:       []bool{false, false, true},
+			expectedCalls: 3,
+		},
+		"failed": {
+			results:       []bool{false},
+			err:           failure,
+			expectedCalls: 2,
+			withError:     failure,
+		},
+		"timeout": {
+			timeout:   20 * time.Millisecond,
+			withError: ErrPollTimeout,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			poller := Poller{Name: "test", Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond, Timeout: test.timeout}
+			var calls int
+			err := poller.Poll(context.Background(), func(context.Context) (bool, error) {
+				calls++
+				if calls > len(test.results) {
+					return false, test.err
+				}
+				return test.results[calls-1], nil
+			})
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if test.expectedCalls > 0 {
+				assert.Equal(t, test.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestPollTimeoutOutlivesContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	poller := Poller{Name: "test", Interval: 10 * time.Millisecond, Timeout: time.Second}
+	var calls int
+	err := poller.Poll(ctx, func(context.Context) (bool, error) {
+		calls++
+		return calls == 2, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestPollCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	poller := Poller{Name: "test", Interval: time.Millisecond, Timeout: time.Second}
+	err := poller.Poll(ctx, func(context.Context) (bool, error) {
+		cancel()
+		return false, nil
+	})
+	assert.True(t, errors.Is(err, context.Canceled), "want: %s; got: %s", context.Canceled, err)
+	assert.False(t, errors.Is(err, ErrPollTimeout))
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 
@@ -101,6 +102,18 @@ func ValidateStringInSlice(valid []string) schema.SchemaValidateDiagFunc {
 	}
 }
 
+// ValidateDuration checks if value is a valid duration string accepted by time.ParseDuration, e.g. 90s or 1h30m
+func ValidateDuration(v interface{}, _ cty.Path) diag.Diagnostics {
+	str, ok := v.(string)
+	if !ok {
+		return diag.Errorf("value is not a string: %v", v)
+	}
+	if _, err := time.ParseDuration(str); err != nil {
+		return diag.Errorf("invalid duration: %s", err)
+	}
+	return nil
+}
+
 var (
 	isRuleFormatValid = regexp.MustCompile(`^v[0-9]{4}-[0-9]{2}-[0-9]{2}$`).MatchString
 )
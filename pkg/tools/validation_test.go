@@ -168,6 +168,37 @@ func TestValidateStringInSlice(t *testing.T) {
 	}
 }
 
+func TestValidateDuration(t *testing.T) {
+	tests := map[string]struct {
+		givenVal      interface{}
+		expectedError string
+	}{
+		"valid duration": {
+			givenVal: "1h30m",
+		},
+		"passed value is not a string": {
+			givenVal:      1,
+			expectedError: "value is not a string",
+		},
+		"invalid duration": {
+			givenVal:      "abc",
+			expectedError: "invalid duration",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res := ValidateDuration(test.givenVal, nil)
+			if test.expectedError != "" {
+				assert.NotEmpty(t, res)
+				assert.Contains(t, res[0].Summary, test.expectedError)
+				return
+			}
+			assert.Empty(t, res)
+		})
+	}
+}
+
 func TestValidateRuleForamt(t *testing.T) {
 	tests := map[string]struct {
 		input        interface{}